@@ -130,6 +130,67 @@ func BenchmarkPipelineEnd2End(b *testing.B) {
 	}
 }
 
+// BenchmarkPipelineEnd2End_IncrementalResynthesis measures how much
+// cheaper a re-run is when only one sentence changed: SynthesizeSegmented
+// should reuse every unaffected segment from cache and resynthesize just
+// the one containing the edit. Runs in dry-run mode (no Piper/FFmpeg
+// binaries required) so it's comparable across environments.
+func BenchmarkPipelineEnd2End_IncrementalResynthesis(b *testing.B) {
+	tempDir := b.TempDir()
+	synthAgent := agents.NewSynthAgent("piper", tempDir)
+	synthAgent.SetDryRun(true)
+
+	cacheAgent := agents.NewCacheAgent(filepath.Join(tempDir, "cache"))
+	if err := cacheAgent.Initialize(); err != nil {
+		b.Fatal(err)
+	}
+
+	pipeline := agents.NewPipeline(synthAgent, cacheAgent).
+		WithSegmentation(agents.SegmentOptions{SentencesPerSegment: 1})
+
+	voice := &agents.Voice{ID: "en-US-female", SampleRate: 22050}
+	params := &agents.SynthParams{Speed: 1.0}
+	normalizeAgent := agents.NewNormalizeAgent()
+	content := &agents.TextContent{
+		Paragraphs: []string{
+			"Hello world. This is a comprehensive benchmark test. " +
+				"It measures the performance of the entire TTS pipeline.",
+		},
+		Language: "en-US",
+	}
+
+	normalized, err := normalizeAgent.Normalize(content)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	outDir := filepath.Join(tempDir, "out")
+	if _, err := pipeline.SynthesizeSegmented(normalized, voice, params, outDir); err != nil {
+		b.Fatal(err)
+	}
+
+	// Edit the last sentence only; every earlier segment's cache key is
+	// unaffected and should be served from cache on each iteration below.
+	editedContent := &agents.TextContent{
+		Paragraphs: []string{
+			"Hello world. This is a comprehensive benchmark test. " +
+				"It measures the performance of the entire TTS pipeline, edited.",
+		},
+		Language: "en-US",
+	}
+	editedNormalized, err := normalizeAgent.Normalize(editedContent)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := pipeline.SynthesizeSegmented(editedNormalized, voice, params, outDir); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 // BenchmarkMemoryUsage benchmarks memory allocation patterns
 func BenchmarkMemoryUsage(b *testing.B) {
 	agent := agents.NewNormalizeAgent()
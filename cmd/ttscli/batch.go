@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"studiospeech/internal/agents"
+	"studiospeech/internal/agents/extractor"
+)
+
+// executeChapteredSynthesisPipeline runs the synthesis pipeline once per
+// chapter (as split into by --split), instead of producing one combined
+// file. It writes each chapter's audio next to outputFile, an M3U8
+// playlist, an ffmetadata chapters file, and a CUE sheet, and optionally
+// muxes the chapters into a single .m4b audiobook.
+func executeChapteredSynthesisPipeline() error {
+	fmt.Println("🔄 Starting chapter-aware synthesis pipeline...")
+
+	tier, err := agents.ParseQualityTier(quality)
+	if err != nil {
+		return err
+	}
+	spec := tier.Spec()
+
+	fmt.Printf("📖 Reading input: %s\n", inputFile)
+	var content *agents.TextContent
+	if ext := extractor.Resolve(inputFile); ext != nil {
+		content, err = ext.Extract(context.Background(), inputFile)
+	} else {
+		content, err = agents.NewTextIngestAgent().ProcessFile(inputFile)
+	}
+	if err != nil {
+		return fmt.Errorf("text ingestion failed: %w", err)
+	}
+
+	if language != "auto" {
+		content.Language = language
+		content.LanguageConfidence = 1.0
+	}
+
+	chapters, err := content.SplitPlan(split)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("   ✓ Split into %d unit(s) using --split=%s\n", len(chapters), split)
+
+	fmt.Printf("🎭 Selecting voice (quality=%s)...\n", quality)
+	catalogPath := filepath.Join("voices", "catalog.json")
+	voiceAgent := agents.NewVoiceCatalogAgent(catalogPath)
+	if err := voiceAgent.LoadCatalog(); err != nil {
+		return fmt.Errorf("voice catalog loading failed: %w", err)
+	}
+
+	selectedVoice, warning, err := voiceAgent.SelectVoiceForQuality(content.Language, voiceID, gender, backend, tier)
+	if err != nil {
+		return fmt.Errorf("voice selection failed: %w", err)
+	}
+	if warning != "" {
+		fmt.Printf("   ⚠️  %s\n", warning)
+	}
+	fmt.Printf("   ✓ Selected voice: %s (%s %s)\n", selectedVoice.ID, selectedVoice.Gender, selectedVoice.Style)
+
+	tempDir, err := os.MkdirTemp("", "studiospeech_*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	normalizeAgent := agents.NewNormalizeAgent()
+	synthAgent := agents.NewSynthAgent("piper", tempDir)
+	synthAgent.SetDryRun(false)
+	postAgent := agents.NewPostProcessAgent("ffmpeg", tempDir)
+	postAgent.SetDryRun(false)
+
+	synthParams := &agents.SynthParams{Speed: speed, Noise: noise, NoiseW: noisew}
+	postParams := &agents.PostProcessParams{
+		Format:       agents.AudioFormat(format),
+		SampleRate:   spec.SampleRate,
+		Bitrate:      spec.Bitrate,
+		LoudnessLUFS: -16.0,
+	}
+
+	base := strings.TrimSuffix(outputFile, filepath.Ext(outputFile))
+	ext := filepath.Ext(outputFile)
+	if ext == "" {
+		ext = ".mp3"
+	}
+
+	var chapterFiles []agents.ChapterFile
+	for i, chapter := range chapters {
+		fmt.Printf("🎤 Synthesizing chapter %d/%d...\n", i+1, len(chapters))
+
+		chapterContent := &agents.TextContent{
+			Paragraphs:         chapter.Paragraphs,
+			Language:           content.Language,
+			LanguageConfidence: content.LanguageConfidence,
+			Source:             content.Source,
+			WordCount:          countWords(chapter.Paragraphs),
+		}
+
+		normalized, err := normalizeAgent.Normalize(chapterContent)
+		if err != nil {
+			return fmt.Errorf("chapter %d normalization failed: %w", i+1, err)
+		}
+
+		synthResult, err := synthAgent.Synthesize(normalized, selectedVoice, synthParams)
+		if err != nil {
+			return fmt.Errorf("chapter %d synthesis failed: %w", i+1, err)
+		}
+
+		chapterPath := fmt.Sprintf("%s.%02d%s", base, i+1, ext)
+		postResult, err := postAgent.Process(synthResult.OutputPath, chapterPath, postParams)
+		if err != nil {
+			return fmt.Errorf("chapter %d post-processing failed: %w", i+1, err)
+		}
+
+		duration, err := agents.WavDuration(synthResult.OutputPath)
+		if err != nil {
+			duration = 0 // best effort; playlist/chapters timing degrades gracefully to 0s entries
+		}
+
+		fmt.Printf("   ✓ Wrote %s\n", postResult.OutputPath)
+		chapterFiles = append(chapterFiles, agents.ChapterFile{
+			Title:    chapter.Title,
+			Path:     postResult.OutputPath,
+			Duration: duration,
+		})
+	}
+
+	playlistPath := base + ".m3u8"
+	if err := agents.WriteM3U8(chapterFiles, playlistPath); err != nil {
+		return fmt.Errorf("failed to write playlist: %w", err)
+	}
+	fmt.Printf("   ✓ Wrote %s\n", playlistPath)
+
+	chaptersMetaPath := base + ".chapters.txt"
+	if err := agents.WriteFFMetadataChapters(chapterFiles, chaptersMetaPath); err != nil {
+		return fmt.Errorf("failed to write chapters metadata: %w", err)
+	}
+	fmt.Printf("   ✓ Wrote %s\n", chaptersMetaPath)
+
+	cuePath := base + ".cue"
+	if err := agents.WriteCueSheet(chapterFiles, base+ext, cuePath); err != nil {
+		return fmt.Errorf("failed to write cue sheet: %w", err)
+	}
+	fmt.Printf("   ✓ Wrote %s\n", cuePath)
+
+	if makeM4B {
+		m4bPath := base + ".m4b"
+		if err := muxM4B(chapterFiles, chaptersMetaPath, m4bPath); err != nil {
+			return fmt.Errorf("failed to build m4b: %w", err)
+		}
+		fmt.Printf("   ✓ Wrote %s\n", m4bPath)
+	}
+
+	return nil
+}
+
+// countWords counts the total number of words across paragraphs, mirroring
+// TextIngestAgent's own word counting for the per-chapter TextContent
+// stand-ins built here.
+func countWords(paragraphs []string) int {
+	total := 0
+	for _, para := range paragraphs {
+		total += len(strings.Fields(para))
+	}
+	return total
+}
+
+// muxM4B concatenates the chapter audio files and embeds chapter marks
+// using ffmpeg's concat demuxer and the ffmetadata chapters file written
+// by WriteFFMetadataChapters.
+func muxM4B(chapters []agents.ChapterFile, chaptersMetaPath, outPath string) error {
+	concatListPath := outPath + ".concat.txt"
+	file, err := os.Create(concatListPath)
+	if err != nil {
+		return fmt.Errorf("failed to write concat list: %w", err)
+	}
+	for _, ch := range chapters {
+		fmt.Fprintf(file, "file '%s'\n", filepath.Base(ch.Path))
+	}
+	file.Close()
+	defer os.Remove(concatListPath)
+
+	cmd := exec.Command("ffmpeg",
+		"-y",
+		"-f", "concat", "-safe", "0", "-i", concatListPath,
+		"-i", chaptersMetaPath,
+		"-map_metadata", "1",
+		"-c:a", "aac",
+		"-f", "mp4",
+		outPath,
+	)
+	cmd.Dir = filepath.Dir(concatListPath)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("ffmpeg failed: %w\nOutput: %s", err, string(output))
+	}
+	return nil
+}
@@ -0,0 +1,328 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"studiospeech/internal/agents"
+	"studiospeech/internal/agents/respipeline"
+)
+
+// batchCmd walks a directory and synthesizes every matching file
+// concurrently, independent of the chapter-aware --split batch pipeline
+// executeChapteredSynthesisPipeline runs for a single input.
+var batchCmd = &cobra.Command{
+	Use:   "batch <dir>",
+	Short: "Synthesize every matching text file in a directory concurrently",
+	Long: `Walk <dir> (optionally recursively with --recursive), matching files
+against --pattern (repeatable; default "*.txt" and "*.md"), and run each
+one through normalize|detectLang|chunk|synthesize|transcodeMP3|
+loudnessNorm|fingerprint, built from the same stages the "pipeline"
+command offers.
+
+Two independent limits bound concurrency: -n caps how many files are in
+flight at once (default runtime.NumCPU()); -l separately caps how many
+files may be inside the memory-heavy synthesize/transcodeMP3/
+loudnessNorm stages at the same time, so small files can keep
+normalizing and chunking while a handful of large synthesis jobs are
+throttled.
+
+A JSON summary (file counts, p50/p95 durations, and per-file status) is
+printed to stdout, or written to --summary if given.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBatch,
+}
+
+var (
+	batchRecursive   bool
+	batchPatterns    []string
+	batchOutDir      string
+	batchWorkers     int
+	batchSynthLimit  int
+	batchSummaryPath string
+	batchLanguage    string
+)
+
+func init() {
+	rootCmd.AddCommand(batchCmd)
+
+	batchCmd.Flags().BoolVar(&batchRecursive, "recursive", false, "descend into subdirectories")
+	batchCmd.Flags().StringArrayVar(&batchPatterns, "pattern", []string{"*.txt", "*.md"}, "glob pattern to match against each file's base name (repeatable)")
+	batchCmd.Flags().StringVar(&batchOutDir, "out-dir", "", "directory to write audio into, mirroring each input's path relative to <dir> (defaults to alongside each input file)")
+	batchCmd.Flags().IntVarP(&batchWorkers, "workers", "n", runtime.NumCPU(), "number of files to process concurrently")
+	batchCmd.Flags().IntVarP(&batchSynthLimit, "synth-limit", "l", runtime.NumCPU(), "max files concurrently inside synthesize/transcodeMP3/loudnessNorm")
+	batchCmd.Flags().StringVar(&batchSummaryPath, "summary", "", "write the JSON run summary to this path instead of stdout")
+	batchCmd.Flags().StringVar(&batchLanguage, "lang", "auto", "language code (en-US, en-UK, el-GR, or auto to detect per file)")
+}
+
+// batchFileResult is one file's outcome, and the unit the JSON summary's
+// "results" array is built from.
+type batchFileResult struct {
+	Path       string `json:"path"`
+	Output     string `json:"output,omitempty"`
+	Status     string `json:"status"` // "ok" or "error"
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// batchSummary is the JSON document emitted at the end of a run.
+type batchSummary struct {
+	Files       int               `json:"files"`
+	Succeeded   int               `json:"succeeded"`
+	Failed      int               `json:"failed"`
+	DurationP50 int64             `json:"duration_p50_ms"`
+	DurationP95 int64             `json:"duration_p95_ms"`
+	Results     []batchFileResult `json:"results"`
+}
+
+// batchRunOptions carries everything runBatchSynthesis needs, separated
+// from the package-level flag variables so tests can drive it directly
+// with different worker/synth-limit combinations without going through
+// cobra.
+type batchRunOptions struct {
+	Recursive   bool
+	Patterns    []string
+	OutDir      string
+	Workers     int
+	SynthLimit  int
+	CatalogPath string
+	Language    string
+	DryRun      bool
+}
+
+func runBatch(cmd *cobra.Command, args []string) error {
+	opts := batchRunOptions{
+		Recursive:   batchRecursive,
+		Patterns:    batchPatterns,
+		OutDir:      batchOutDir,
+		Workers:     batchWorkers,
+		SynthLimit:  batchSynthLimit,
+		CatalogPath: filepath.Join("voices", "catalog.json"),
+		Language:    batchLanguage,
+	}
+
+	summary, err := runBatchSynthesis(args[0], opts)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal summary: %w", err)
+	}
+
+	if batchSummaryPath != "" {
+		if err := os.WriteFile(batchSummaryPath, data, 0644); err != nil {
+			return fmt.Errorf("failed to write summary: %w", err)
+		}
+		fmt.Printf("✓ Wrote %s\n", batchSummaryPath)
+	} else {
+		fmt.Println(string(data))
+	}
+
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d of %d files failed", summary.Failed, summary.Files)
+	}
+	return nil
+}
+
+// runBatchSynthesis discovers files under root and synthesizes them
+// concurrently per opts, gating the outer file-level fan-out at
+// opts.Workers and the synthesize/transcodeMP3/loudnessNorm stages at
+// opts.SynthLimit independently of it. Results are always returned
+// sorted by path, so the summary is deterministic regardless of how the
+// two limits are set.
+func runBatchSynthesis(root string, opts batchRunOptions) (batchSummary, error) {
+	files, err := discoverBatchFiles(root, opts.Recursive, opts.Patterns)
+	if err != nil {
+		return batchSummary{}, err
+	}
+	if len(files) == 0 {
+		return batchSummary{}, fmt.Errorf("no files under %s matched %s", root, strings.Join(opts.Patterns, ", "))
+	}
+
+	preStages, err := respipeline.ParseStages("normalize|detectLang:lang=" + opts.Language + "|chunk")
+	if err != nil {
+		return batchSummary{}, fmt.Errorf("internal error parsing preprocessing stages: %w", err)
+	}
+	synthStages, err := respipeline.ParseStages("synthesize|transcodeMP3|loudnessNorm|fingerprint:as=speech")
+	if err != nil {
+		return batchSummary{}, fmt.Errorf("internal error parsing synthesis stages: %w", err)
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	synthLimit := opts.SynthLimit
+	if synthLimit <= 0 {
+		synthLimit = runtime.NumCPU()
+	}
+
+	fileGate := make(chan struct{}, workers)
+	synthGate := make(chan struct{}, synthLimit)
+
+	results := make([]batchFileResult, len(files))
+	var wg sync.WaitGroup
+	for i, path := range files {
+		wg.Add(1)
+		fileGate <- struct{}{}
+		go func(i int, path string) {
+			defer wg.Done()
+			defer func() { <-fileGate }()
+			results[i] = synthesizeBatchFile(path, root, opts, preStages, synthStages, synthGate)
+		}(i, path)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Path < results[j].Path })
+
+	return summarizeBatch(results), nil
+}
+
+// discoverBatchFiles walks root, collecting files whose base name matches
+// any of patterns. Subdirectories are skipped unless recursive is set.
+func discoverBatchFiles(root string, recursive bool, patterns []string) ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if !recursive && path != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		for _, pattern := range patterns {
+			if ok, _ := filepath.Match(pattern, filepath.Base(path)); ok {
+				files = append(files, path)
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", root, err)
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// synthesizeBatchFile runs path through preStages unrestricted, then
+// acquires synthGate before running synthStages, releasing it as soon as
+// synthesis/transcoding/normalization finish so the slot is free for the
+// next large file.
+func synthesizeBatchFile(path, root string, opts batchRunOptions, preStages, synthStages []respipeline.StageSpec, synthGate chan struct{}) batchFileResult {
+	start := time.Now()
+	result := batchFileResult{Path: path}
+
+	content, err := agents.NewTextIngestAgent().ProcessFile(path)
+	if err != nil {
+		return batchFailure(result, start, fmt.Errorf("ingestion failed: %w", err))
+	}
+
+	tempDir, err := os.MkdirTemp("", "studiospeech_batch_*")
+	if err != nil {
+		return batchFailure(result, start, fmt.Errorf("failed to create temp dir: %w", err))
+	}
+	defer os.RemoveAll(tempDir)
+
+	runOpts := &respipeline.Options{DryRun: opts.DryRun, TempDir: tempDir, CatalogPath: opts.CatalogPath}
+
+	in := respipeline.NewTextResource(content)
+	prepared, _, err := respipeline.New(preStages).Run(runOpts, in)
+	if err != nil {
+		return batchFailure(result, start, fmt.Errorf("preprocessing failed: %w", err))
+	}
+
+	synthGate <- struct{}{}
+	out, _, err := respipeline.New(synthStages).Run(runOpts, prepared)
+	<-synthGate
+	if err != nil {
+		return batchFailure(result, start, fmt.Errorf("synthesis failed: %w", err))
+	}
+
+	outPath, err := batchOutputPath(path, root, out, opts.OutDir)
+	if err != nil {
+		return batchFailure(result, start, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
+		return batchFailure(result, start, fmt.Errorf("failed to create output directory: %w", err))
+	}
+	if err := copyFile(out.AudioPath, outPath); err != nil {
+		return batchFailure(result, start, fmt.Errorf("failed to write output: %w", err))
+	}
+
+	result.Output = outPath
+	result.Status = "ok"
+	result.DurationMS = time.Since(start).Milliseconds()
+	return result
+}
+
+// batchOutputPath places out's (possibly fingerprint-renamed) audio next
+// to its input, or under outDir mirroring the input's path relative to
+// root when outDir is set.
+func batchOutputPath(inputPath, root string, out *respipeline.Resource, outDir string) (string, error) {
+	base := strings.TrimSuffix(filepath.Base(inputPath), filepath.Ext(inputPath)) + filepath.Ext(out.AudioPath)
+
+	if outDir == "" {
+		return filepath.Join(filepath.Dir(inputPath), base), nil
+	}
+
+	rel, err := filepath.Rel(root, filepath.Dir(inputPath))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve output path for %s: %w", inputPath, err)
+	}
+	return filepath.Join(outDir, rel, base), nil
+}
+
+func batchFailure(result batchFileResult, start time.Time, err error) batchFileResult {
+	result.Status = "error"
+	result.Error = err.Error()
+	result.DurationMS = time.Since(start).Milliseconds()
+	return result
+}
+
+// summarizeBatch totals successes/failures and computes p50/p95 over
+// every file's duration, including failed ones.
+func summarizeBatch(results []batchFileResult) batchSummary {
+	summary := batchSummary{Files: len(results), Results: results}
+
+	durations := make([]int64, len(results))
+	for i, r := range results {
+		durations[i] = r.DurationMS
+		if r.Status == "ok" {
+			summary.Succeeded++
+		} else {
+			summary.Failed++
+		}
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	summary.DurationP50 = percentile(durations, 0.50)
+	summary.DurationP95 = percentile(durations, 0.95)
+	return summary
+}
+
+// percentile returns the p-th percentile (0..1) of sorted as the element
+// at index floor(p*(len-1)); it returns 0 for an empty slice.
+func percentile(sorted []int64, p float64) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(p * float64(len(sorted)-1))
+	return sorted[idx]
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeBatchTestCatalog(t *testing.T, dir string) string {
+	t.Helper()
+	catalogPath := filepath.Join(dir, "catalog.json")
+	catalog := `{"voices":[{"id":"en_US-test-medium","language":"en-US","gender":"female",` +
+		`"commercial_use_allowed":true,"license_name":"CC0","sample_rate":22050}]}`
+	if err := os.WriteFile(catalogPath, []byte(catalog), 0644); err != nil {
+		t.Fatalf("failed to write test catalog: %v", err)
+	}
+	return catalogPath
+}
+
+func writeBatchTestFiles(t *testing.T, dir string, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		name := filepath.Join(dir, "doc"+string(rune('a'+i))+".txt")
+		content := "This is a short test document. It has a couple of sentences."
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+}
+
+// TestBatchConcurrency runs the same directory through runBatchSynthesis
+// with two very different worker/synth-limit combinations and checks the
+// resulting summary is identical either way: the file-level and
+// synthesize-stage semaphores bound how much work happens at once, not
+// what the final output is.
+func TestBatchConcurrency(t *testing.T) {
+	dir := t.TempDir()
+	writeBatchTestFiles(t, dir, 5)
+	catalogPath := writeBatchTestCatalog(t, t.TempDir())
+
+	baseOpts := batchRunOptions{
+		Patterns:    []string{"*.txt"},
+		CatalogPath: catalogPath,
+		Language:    "en-US",
+		DryRun:      true,
+	}
+
+	serial := baseOpts
+	serial.Workers = 1
+	serial.SynthLimit = 1
+	serialSummary, err := runBatchSynthesis(dir, serial)
+	if err != nil {
+		t.Fatalf("runBatchSynthesis(workers=1) error = %v", err)
+	}
+
+	parallel := baseOpts
+	parallel.Workers = 8
+	parallel.SynthLimit = 3
+	parallelSummary, err := runBatchSynthesis(dir, parallel)
+	if err != nil {
+		t.Fatalf("runBatchSynthesis(workers=8) error = %v", err)
+	}
+
+	if serialSummary.Files != 5 || serialSummary.Succeeded != 5 || serialSummary.Failed != 0 {
+		t.Fatalf("serial summary = %+v, want 5 files all succeeded", serialSummary)
+	}
+	if parallelSummary.Files != serialSummary.Files ||
+		parallelSummary.Succeeded != serialSummary.Succeeded ||
+		parallelSummary.Failed != serialSummary.Failed {
+		t.Fatalf("parallel summary = %+v, want the same counts as serial %+v", parallelSummary, serialSummary)
+	}
+
+	if len(serialSummary.Results) != len(parallelSummary.Results) {
+		t.Fatalf("got %d results in parallel run, want %d", len(parallelSummary.Results), len(serialSummary.Results))
+	}
+	for i := range serialSummary.Results {
+		got, want := parallelSummary.Results[i], serialSummary.Results[i]
+		if got.Path != want.Path || got.Status != want.Status || got.Output != want.Output {
+			t.Errorf("result[%d] = %+v, want {Path:%q Status:%q Output:%q}", i, got, want.Path, want.Status, want.Output)
+		}
+	}
+}
+
+func TestRunBatchSynthesis_NoMatches(t *testing.T) {
+	dir := t.TempDir()
+	catalogPath := writeBatchTestCatalog(t, t.TempDir())
+
+	_, err := runBatchSynthesis(dir, batchRunOptions{Patterns: []string{"*.txt"}, CatalogPath: catalogPath, DryRun: true})
+	if err == nil {
+		t.Fatal("expected an error when no files match, got nil")
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"studiospeech/internal/synthcache"
+)
+
+// cacheCmd is the parent command for managing the pipeline's
+// content-addressed synthesized-audio cache (see the "pipeline" command's
+// --no-cache/--cache-ttl flags for how it's populated).
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect and manage the synthesized-audio cache",
+}
+
+var cacheTTL string
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show cache entry count and total size on disk",
+	RunE:  runCacheStats,
+}
+
+var cacheGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Remove cache entries older than --cache-ttl (a no-op if it's 0)",
+	RunE:  runCacheGC,
+}
+
+var cachePurgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Remove every cache entry unconditionally",
+	RunE:  runCachePurge,
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.PersistentFlags().StringVar(&cacheTTL, "cache-ttl", "0", "entry lifetime used by gc (e.g. 720h); 0 disables expiry")
+
+	cacheCmd.AddCommand(cacheStatsCmd, cacheGCCmd, cachePurgeCmd)
+}
+
+func openDiskCache() (*synthcache.DiskCache, error) {
+	ttl, err := time.ParseDuration(cacheTTL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --cache-ttl: %w", err)
+	}
+	return synthcache.NewDiskCache(synthcache.DefaultDiskCacheDir(), "wav", ttl), nil
+}
+
+func runCacheStats(cmd *cobra.Command, args []string) error {
+	cache, err := openDiskCache()
+	if err != nil {
+		return err
+	}
+
+	stats, err := cache.Stats()
+	if err != nil {
+		return fmt.Errorf("failed to read cache stats: %w", err)
+	}
+
+	fmt.Printf("%s\n", synthcache.DefaultDiskCacheDir())
+	fmt.Printf("  entries: %d\n", stats.Entries)
+	fmt.Printf("  size:    %d bytes\n", stats.Bytes)
+	return nil
+}
+
+func runCacheGC(cmd *cobra.Command, args []string) error {
+	cache, err := openDiskCache()
+	if err != nil {
+		return err
+	}
+
+	removed, err := cache.GC()
+	if err != nil {
+		return fmt.Errorf("cache gc failed: %w", err)
+	}
+
+	fmt.Printf("✓ Removed %d expired entries\n", removed)
+	return nil
+}
+
+func runCachePurge(cmd *cobra.Command, args []string) error {
+	cache, err := openDiskCache()
+	if err != nil {
+		return err
+	}
+
+	removed, err := cache.Purge()
+	if err != nil {
+		return fmt.Errorf("cache purge failed: %w", err)
+	}
+
+	fmt.Printf("✓ Removed %d entries\n", removed)
+	return nil
+}
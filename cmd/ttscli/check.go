@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"runtime"
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"studiospeech/internal/agents/tts"
 )
 
 // checkCmd represents the check command
@@ -37,13 +40,16 @@ func runCheck(cmd *cobra.Command, args []string) {
 	
 	// Check Piper TTS
 	checkPiper()
-	
+
 	// Check FFmpeg
 	checkFFmpeg()
-	
+
 	// Check voice catalog
 	checkVoices()
-	
+
+	// Check all registered synthesis backends
+	checkBackends()
+
 	fmt.Println("\n✅ System check complete!")
 }
 
@@ -96,6 +102,20 @@ func checkVoices() {
 	fmt.Println("⚠️  Voice catalog validation not yet implemented")
 }
 
+// checkBackends reports availability of every registered TTS backend.
+func checkBackends() {
+	fmt.Println("Checking synthesis backends...")
+
+	for _, backend := range tts.All() {
+		fmt.Printf("  %s: ", backend.Name())
+		if err := backend.Available(context.Background()); err != nil {
+			fmt.Printf("❌ unavailable (%v)\n", err)
+			continue
+		}
+		fmt.Println("✅ available")
+	}
+}
+
 // printPiperInstallGuide provides OS-specific installation instructions for Piper
 func printPiperInstallGuide() {
 	fmt.Println("\n📋 Piper TTS Installation Guide:")
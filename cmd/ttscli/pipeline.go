@@ -1,21 +1,48 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"studiospeech/internal/agents"
+	"studiospeech/internal/agents/extractor"
+	"studiospeech/internal/agents/ssml"
 )
 
+// languageConfidenceThreshold is the trigram-detector confidence that
+// auto-detected language must exceed to be trusted; at or below this the
+// user must pass --lang explicitly.
+const languageConfidenceThreshold = 0.6
+
 // executeSynthesisPipeline runs the complete TTS pipeline
 func executeSynthesisPipeline() error {
+	// SSML detection only applies to local plain-text input: a source an
+	// extractor already resolves (a URL, yt:, feed:, or .epub) is read
+	// and reshaped by that extractor, not passed through verbatim, so it
+	// is never SSML markup.
+	if extractor.Resolve(inputFile) == nil {
+		if raw, err := os.ReadFile(inputFile); err == nil && ssml.IsSSML(string(raw)) {
+			return executeSSMLSynthesisPipeline(string(raw))
+		}
+	}
+
 	fmt.Println("🔄 Starting synthesis pipeline...")
 
 	// Step 1: Text Ingestion
-	fmt.Printf("📖 Reading input file: %s\n", inputFile)
+	fmt.Printf("📖 Reading input: %s\n", inputFile)
 	textAgent := agents.NewTextIngestAgent()
-	content, err := textAgent.ProcessFile(inputFile)
+
+	var content *agents.TextContent
+	var err error
+	if ext := extractor.Resolve(inputFile); ext != nil {
+		content, err = ext.Extract(context.Background(), inputFile)
+	} else {
+		content, err = textAgent.ProcessFile(inputFile)
+	}
 	if err != nil {
 		return fmt.Errorf("text ingestion failed: %w", err)
 	}
@@ -25,12 +52,15 @@ func executeSynthesisPipeline() error {
 	}
 
 	fmt.Printf("   ✓ Processed %d paragraphs, %d words\n", len(content.Paragraphs), content.WordCount)
-	fmt.Printf("   ✓ Detected language: %s\n", content.Language)
+	fmt.Printf("   ✓ Detected language: %s (confidence %.0f%%)\n", content.Language, content.LanguageConfidence*100)
 
 	// Override language if specified
 	if language != "auto" {
 		content.Language = language
+		content.LanguageConfidence = 1.0
 		fmt.Printf("   ✓ Language override: %s\n", language)
+	} else if content.LanguageConfidence <= languageConfidenceThreshold {
+		return fmt.Errorf("language detection confidence too low (%.0f%%) - pass --lang explicitly", content.LanguageConfidence*100)
 	}
 
 	// Step 2: Voice Selection
@@ -42,7 +72,7 @@ func executeSynthesisPipeline() error {
 		return fmt.Errorf("voice catalog loading failed: %w", err)
 	}
 
-	selectedVoice, err := voiceAgent.SelectVoice(content.Language, voiceID, gender)
+	selectedVoice, err := voiceAgent.SelectVoiceForBackend(content.Language, voiceID, gender, backend)
 	if err != nil {
 		return fmt.Errorf("voice selection failed: %w", err)
 	}
@@ -93,6 +123,13 @@ func executeSynthesisPipeline() error {
 	fmt.Printf("   ✓ Generated audio: %s\n", result.OutputPath)
 	fmt.Printf("   ✓ Sample rate: %d Hz, Channels: %d\n", result.SampleRate, result.Channels)
 
+	// Step 4b: Subtitles (optional)
+	if subs != "" {
+		if err := generateSubtitles(synthAgent, content, selectedVoice, params); err != nil {
+			fmt.Printf("   ⚠️  Subtitle generation failed: %v\n", err)
+		}
+	}
+
 	// Step 5: Check Cache
 	fmt.Printf("💾 Checking cache...\n")
 	cacheDir := filepath.Join(os.TempDir(), "studiospeech_cache")
@@ -158,3 +195,65 @@ func executeSynthesisPipeline() error {
 
 	return nil
 }
+
+// copyFile copies src to dst, overwriting dst if it already exists.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", src, err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", dst, err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return fmt.Errorf("failed to copy %s to %s: %w", src, dst, err)
+	}
+
+	return nil
+}
+
+// generateSubtitles synthesizes per-chunk timing and writes the requested
+// subtitle formats next to outputFile, reusing the same voice and synthesis
+// parameters as the main audio track.
+func generateSubtitles(synthAgent *agents.SynthAgent, content *agents.TextContent, voice *agents.Voice, params *agents.SynthParams) error {
+	granularity := agents.GranularitySentence
+	if subsGranularity == "word" {
+		granularity = agents.GranularityWord
+	}
+
+	fmt.Printf("📝 Generating subtitles (%s, granularity=%s)...\n", subs, subsGranularity)
+
+	subtitleAgent := agents.NewSubtitleAgent(synthAgent)
+	cues, err := subtitleAgent.Generate(content, voice, params, granularity)
+	if err != nil {
+		return fmt.Errorf("subtitle timing failed: %w", err)
+	}
+
+	base := strings.TrimSuffix(outputFile, filepath.Ext(outputFile))
+
+	for _, wanted := range strings.Split(subs, ",") {
+		switch strings.ToLower(strings.TrimSpace(wanted)) {
+		case "srt":
+			path := base + ".srt"
+			if err := subtitleAgent.WriteSRT(cues, path); err != nil {
+				return fmt.Errorf("failed to write SRT: %w", err)
+			}
+			fmt.Printf("   ✓ Wrote %s\n", path)
+		case "vtt":
+			path := base + ".vtt"
+			if err := subtitleAgent.WriteVTT(cues, path); err != nil {
+				return fmt.Errorf("failed to write VTT: %w", err)
+			}
+			fmt.Printf("   ✓ Wrote %s\n", path)
+		default:
+			return fmt.Errorf("unsupported subtitle format: %s (supported: srt, vtt)", wanted)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"studiospeech/internal/agents"
+	"studiospeech/internal/agents/extractor"
+	"studiospeech/internal/agents/respipeline"
+	"studiospeech/internal/synthcache"
+)
+
+// pipelineCmd runs a respipeline.Pipeline composed from a flat
+// "--stages=a|b:param=value|c" flag, for users who want to pick and
+// chain individual stages (normalize, detectLang, chunk, synthesize,
+// transcodeMP3, loudnessNorm, fingerprint) rather than going through the
+// fixed synth pipeline.
+var pipelineCmd = &cobra.Command{
+	Use:   "pipeline",
+	Short: "Run a composable stage pipeline (normalize|synthesize|fingerprint|...)",
+	Long: fmt.Sprintf(`Run a chain of named stages over --in, each one reading the
+previous stage's output and producing the next. Registered stages: %s.
+
+Example:
+  ttscli pipeline --in script.txt --out speech.mp3 \
+    --stages "normalize|detectLang|synthesize:voice=auto|transcodeMP3|loudnessNorm|fingerprint:as=speech"`, joinNames()),
+	RunE: runPipeline,
+}
+
+var (
+	pipelineStages      string
+	pipelineManifestOut string
+	pipelineNoCache     bool
+	pipelineCacheTTL    string
+)
+
+func joinNames() string {
+	names := respipeline.Names()
+	out := ""
+	for i, name := range names {
+		if i > 0 {
+			out += ", "
+		}
+		out += name
+	}
+	return out
+}
+
+func init() {
+	rootCmd.AddCommand(pipelineCmd)
+
+	pipelineCmd.Flags().StringVarP(&inputFile, "in", "i", "", "input source: a .txt/.docx/.pdf/.epub file, an https:// article URL, yt:VIDEOID, or feed:<url>")
+	pipelineCmd.Flags().StringVarP(&outputFile, "out", "o", "", "output audio file (before any fingerprint stage renames it)")
+	pipelineCmd.MarkFlagRequired("in")
+	pipelineCmd.MarkFlagRequired("out")
+
+	pipelineCmd.Flags().StringVarP(&language, "lang", "l", "auto", "language code (en-US, en-UK, el-GR, or auto)")
+	pipelineCmd.Flags().StringVar(&pipelineStages, "stages", "normalize|detectLang|synthesize|transcodeMP3|loudnessNorm|fingerprint", "pipe-separated stage chain, each optionally followed by :key=value,... params")
+	pipelineCmd.Flags().StringVar(&pipelineManifestOut, "manifest", "", "write a JSON manifest of fingerprinted outputs to this path (defaults to <out dir>/manifest.json)")
+	pipelineCmd.Flags().BoolVar(&pipelineNoCache, "no-cache", false, "skip the synthesized-audio cache, always invoking the TTS engine")
+	pipelineCmd.Flags().StringVar(&pipelineCacheTTL, "cache-ttl", "0", "expire cached audio older than this (e.g. 720h); 0 disables expiry")
+}
+
+// runPipeline builds a text Resource from --in and runs it through the
+// stage chain --stages describes, writing a manifest for any fingerprint
+// stages it ran.
+func runPipeline(cmd *cobra.Command, args []string) error {
+	specs, err := respipeline.ParseStages(pipelineStages)
+	if err != nil {
+		return fmt.Errorf("invalid --stages: %w", err)
+	}
+
+	fmt.Printf("🔄 Running pipeline: %s\n", pipelineStages)
+
+	var content *agents.TextContent
+	if ext := extractor.Resolve(inputFile); ext != nil {
+		content, err = ext.Extract(context.Background(), inputFile)
+	} else {
+		content, err = agents.NewTextIngestAgent().ProcessFile(inputFile)
+	}
+	if err != nil {
+		return fmt.Errorf("text ingestion failed: %w", err)
+	}
+
+	if language != "auto" {
+		content.Language = language
+		content.LanguageConfidence = 1.0
+	}
+
+	tempDir, err := os.MkdirTemp("", "studiospeech_pipeline_*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	manifest := &respipeline.Manifest{}
+	opts := &respipeline.Options{
+		TempDir:     tempDir,
+		CatalogPath: filepath.Join("voices", "catalog.json"),
+		Manifest:    manifest,
+	}
+
+	if !pipelineNoCache {
+		ttl, err := time.ParseDuration(pipelineCacheTTL)
+		if err != nil {
+			return fmt.Errorf("invalid --cache-ttl: %w", err)
+		}
+		opts.Cache = synthcache.NewDiskCache(synthcache.DefaultDiskCacheDir(), "wav", ttl)
+	}
+
+	in := respipeline.NewTextResource(content)
+	out, results, err := respipeline.New(specs).Run(opts, in)
+	if err != nil {
+		return fmt.Errorf("pipeline run failed: %w", err)
+	}
+
+	for _, r := range results {
+		fmt.Printf("   ✓ %s (%s)\n", r.Name, r.Duration)
+	}
+
+	if out.Kind == respipeline.KindAudio && out.AudioPath != "" {
+		if err := copyFile(out.AudioPath, finalOutputPath(out)); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+		fmt.Printf("   ✓ Wrote %s\n", finalOutputPath(out))
+	}
+
+	if len(manifest.Entries) > 0 {
+		manifestPath := pipelineManifestOut
+		if manifestPath == "" {
+			manifestPath = filepath.Join(filepath.Dir(outputFile), "manifest.json")
+		}
+		if err := manifest.WriteFile(manifestPath); err != nil {
+			return fmt.Errorf("failed to write manifest: %w", err)
+		}
+		fmt.Printf("   ✓ Wrote %s\n", manifestPath)
+	}
+
+	fmt.Println("✅ Pipeline completed successfully!")
+	return nil
+}
+
+// finalOutputPath places the pipeline's (possibly fingerprint-renamed)
+// output next to --out, keeping the fingerprinted basename so the
+// manifest's recorded path matches what's on disk.
+func finalOutputPath(out *respipeline.Resource) string {
+	if out.Fingerprint == "" {
+		return outputFile
+	}
+	return filepath.Join(filepath.Dir(outputFile), filepath.Base(out.AudioPath))
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"studiospeech/internal/agents"
+	"studiospeech/internal/agents/recipe"
+)
+
+// runCmd executes a declarative recipe file end to end: ingest its
+// inputs, then run the recipe's own ordered "stages" list (splitChapters,
+// assignVoices, generateSSML, synthesize, mux, ...) over the result.
+var runCmd = &cobra.Command{
+	Use:   "run <recipe.yaml>",
+	Short: "Run a declarative recipe file (inputs, variables, and a stage chain) end to end",
+	Long: fmt.Sprintf(`Load a YAML recipe describing a book-length synthesis job --
+"inputs" (file paths to ingest and concatenate), "variables" (substituted
+into inputs/stages via "${name}"), and an ordered "stages" list (each
+optionally followed by ":key=value,..." params, the same link syntax the
+"pipeline" command's --stages flag uses) -- and run it end to end.
+
+Registered stages: %s.
+
+Example recipe:
+  inputs:
+    - ${book}
+  variables:
+    book: manuscript.md
+  stages:
+    - splitChapters
+    - assignVoices:default=en_US-amy-medium
+    - generateSSML:sentenceBreak=300,commaBreak=120
+    - synthesize
+    - mux:title=My Book
+
+A JSON run log (one entry per completed stage, plus the final output and
+sidecar file paths) is printed to stdout, or written to --summary if given.`, joinRecipeNames()),
+	Args: cobra.ExactArgs(1),
+	RunE: runRecipe,
+}
+
+var (
+	runOutPath     string
+	runLanguage    string
+	runSummaryPath string
+)
+
+func joinRecipeNames() string {
+	names := recipe.Names()
+	out := ""
+	for i, name := range names {
+		if i > 0 {
+			out += ", "
+		}
+		out += name
+	}
+	return out
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+
+	runCmd.Flags().StringVarP(&runOutPath, "out", "o", "", "output MP3 path")
+	runCmd.MarkFlagRequired("out")
+	runCmd.Flags().StringVarP(&runLanguage, "lang", "l", "auto", "language code (en-US, en-UK, el-GR, or auto to detect)")
+	runCmd.Flags().StringVar(&runSummaryPath, "summary", "", "write the JSON run log to this path instead of stdout")
+}
+
+// runStageLog is one completed stage's entry in a run's JSON log.
+type runStageLog struct {
+	Name       string `json:"name"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// runLog is the JSON document runRecipe prints or writes to --summary,
+// mirroring the batch/walk commands' own run-summary convention.
+type runLog struct {
+	Recipe       string        `json:"recipe"`
+	Chapters     int           `json:"chapters"`
+	Stages       []runStageLog `json:"stages"`
+	Output       string        `json:"output,omitempty"`
+	Playlist     string        `json:"playlist,omitempty"`
+	ChaptersMeta string        `json:"chapters_meta,omitempty"`
+	Cue          string        `json:"cue,omitempty"`
+	Error        string        `json:"error,omitempty"`
+}
+
+// runRecipeOptions carries everything runRecipeFile needs, separate from
+// the package-level flag variables so tests can drive it directly.
+type runRecipeOptions struct {
+	OutputPath  string
+	Language    string
+	CatalogPath string
+	DryRun      bool
+}
+
+func runRecipe(cmd *cobra.Command, args []string) error {
+	opts := runRecipeOptions{
+		OutputPath:  runOutPath,
+		Language:    runLanguage,
+		CatalogPath: filepath.Join("voices", "catalog.json"),
+	}
+
+	log, runErr := runRecipeFile(args[0], opts)
+
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal run log: %w", err)
+	}
+
+	if runSummaryPath != "" {
+		if err := os.WriteFile(runSummaryPath, data, 0644); err != nil {
+			return fmt.Errorf("failed to write run log: %w", err)
+		}
+		fmt.Printf("✓ Wrote %s\n", runSummaryPath)
+	} else {
+		fmt.Println(string(data))
+	}
+
+	return runErr
+}
+
+// runRecipeFile loads the recipe at path, ingests its inputs, and runs
+// its stage chain over them, returning a JSON-serializable run log
+// regardless of whether the pipeline succeeded (log.Error is set on
+// failure).
+func runRecipeFile(path string, opts runRecipeOptions) (runLog, error) {
+	r, err := recipe.Load(path)
+	if err != nil {
+		return runLog{Recipe: path, Error: err.Error()}, err
+	}
+
+	content, err := ingestRecipeInputs(r.Inputs)
+	if err != nil {
+		return runLog{Recipe: path, Error: err.Error()}, err
+	}
+	if opts.Language != "" && opts.Language != "auto" {
+		content.Language = opts.Language
+		content.LanguageConfidence = 1.0
+	}
+
+	tempDir, err := os.MkdirTemp("", "studiospeech_recipe_*")
+	if err != nil {
+		err = fmt.Errorf("failed to create temp directory: %w", err)
+		return runLog{Recipe: path, Error: err.Error()}, err
+	}
+	defer os.RemoveAll(tempDir)
+
+	book := &recipe.Book{
+		Content:     content,
+		Language:    content.Language,
+		CatalogPath: opts.CatalogPath,
+		TempDir:     tempDir,
+		OutputPath:  opts.OutputPath,
+		DryRun:      opts.DryRun,
+	}
+
+	results, runErr := recipe.New(r.Stages).Run(book)
+
+	log := runLog{
+		Recipe:       path,
+		Chapters:     len(book.Chapters),
+		Stages:       make([]runStageLog, len(results)),
+		Output:       book.OutputPath,
+		Playlist:     book.PlaylistPath,
+		ChaptersMeta: book.ChaptersMetaPath,
+		Cue:          book.CuePath,
+	}
+	for i, res := range results {
+		log.Stages[i] = runStageLog{Name: res.Name, DurationMS: res.Duration.Milliseconds()}
+	}
+	if runErr != nil {
+		log.Error = runErr.Error()
+	}
+
+	return log, runErr
+}
+
+// ingestRecipeInputs reads and concatenates every recipe input file into
+// a single TextContent, offsetting each file's own chapter marks by the
+// paragraph count ingested so far, so splitChapters still finds the
+// right heading boundaries across a multi-file book. The first input
+// with a detected language sets the combined document's language.
+func ingestRecipeInputs(inputs []string) (*agents.TextContent, error) {
+	textAgent := agents.NewTextIngestAgent()
+	combined := &agents.TextContent{}
+
+	for _, path := range inputs {
+		content, err := textAgent.ProcessFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to ingest %s: %w", path, err)
+		}
+
+		offset := len(combined.Paragraphs)
+		for _, mark := range content.Chapters {
+			combined.Chapters = append(combined.Chapters, agents.ChapterMark{
+				ParagraphIndex: mark.ParagraphIndex + offset,
+				Title:          mark.Title,
+			})
+		}
+
+		combined.Paragraphs = append(combined.Paragraphs, content.Paragraphs...)
+		combined.WordCount += content.WordCount
+		if combined.Language == "" {
+			combined.Language = content.Language
+			combined.LanguageConfidence = content.LanguageConfidence
+		}
+	}
+
+	if len(combined.Paragraphs) == 0 {
+		return nil, fmt.Errorf("no paragraphs ingested from recipe inputs")
+	}
+
+	return combined, nil
+}
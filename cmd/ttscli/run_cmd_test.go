@@ -0,0 +1,110 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeRunTestCatalog(t *testing.T, dir string) string {
+	t.Helper()
+	catalogPath := filepath.Join(dir, "catalog.json")
+	catalog := `{"voices":[{"id":"en_US-test-medium","language":"en-US","gender":"female",` +
+		`"commercial_use_allowed":true,"license_name":"CC0","sample_rate":22050}]}`
+	if err := os.WriteFile(catalogPath, []byte(catalog), 0644); err != nil {
+		t.Fatalf("failed to write test catalog: %v", err)
+	}
+	return catalogPath
+}
+
+func writeRunTestRecipe(t *testing.T, dir, inputPath string) string {
+	t.Helper()
+	recipePath := filepath.Join(dir, "book.yaml")
+	body := "inputs:\n" +
+		"  - " + inputPath + "\n" +
+		"stages:\n" +
+		"  - splitChapters\n" +
+		"  - generateSSML:sentenceBreak=300\n" +
+		"  - synthesize\n" +
+		"  - mux:title=Test Book\n"
+	if err := os.WriteFile(recipePath, []byte(body), 0644); err != nil {
+		t.Fatalf("failed to write test recipe: %v", err)
+	}
+	return recipePath
+}
+
+func TestRunRecipeFile_EndToEnd(t *testing.T) {
+	dir := t.TempDir()
+	catalogPath := writeRunTestCatalog(t, dir)
+
+	inputPath := filepath.Join(dir, "book.txt")
+	content := "# Chapter One\nThe room was quiet.\n\n# Chapter Two\nThe door creaked open.\n"
+	if err := os.WriteFile(inputPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test input: %v", err)
+	}
+
+	recipePath := writeRunTestRecipe(t, dir, inputPath)
+
+	log, err := runRecipeFile(recipePath, runRecipeOptions{
+		OutputPath:  filepath.Join(dir, "book.mp3"),
+		Language:    "en-US",
+		CatalogPath: catalogPath,
+		DryRun:      true,
+	})
+	if err != nil {
+		t.Fatalf("runRecipeFile() error = %v", err)
+	}
+
+	if log.Chapters != 2 {
+		t.Errorf("Chapters = %d, want 2", log.Chapters)
+	}
+	if len(log.Stages) != 4 {
+		t.Errorf("got %d stage log entries, want 4", len(log.Stages))
+	}
+	if log.Output == "" {
+		t.Error("log.Output is empty")
+	}
+	if _, err := os.Stat(log.Output); err != nil {
+		t.Errorf("mux output %s does not exist: %v", log.Output, err)
+	}
+	if _, err := os.Stat(log.ChaptersMeta); err != nil {
+		t.Errorf("chapters metadata %s does not exist: %v", log.ChaptersMeta, err)
+	}
+}
+
+func TestRunRecipeFile_UnknownStage(t *testing.T) {
+	dir := t.TempDir()
+	inputPath := filepath.Join(dir, "book.txt")
+	if err := os.WriteFile(inputPath, []byte("Hello there."), 0644); err != nil {
+		t.Fatalf("failed to write test input: %v", err)
+	}
+
+	recipePath := filepath.Join(dir, "book.yaml")
+	body := "inputs:\n  - " + inputPath + "\nstages:\n  - doesNotExist\n"
+	if err := os.WriteFile(recipePath, []byte(body), 0644); err != nil {
+		t.Fatalf("failed to write test recipe: %v", err)
+	}
+
+	log, err := runRecipeFile(recipePath, runRecipeOptions{OutputPath: filepath.Join(dir, "out.mp3"), DryRun: true})
+	if err == nil {
+		t.Fatal("expected an error for an unregistered stage, got nil")
+	}
+	if !strings.Contains(log.Error, "unknown stage") {
+		t.Errorf("log.Error = %q, want it to mention the unknown stage", log.Error)
+	}
+}
+
+func TestRunRecipeFile_MissingInput(t *testing.T) {
+	dir := t.TempDir()
+	recipePath := filepath.Join(dir, "book.yaml")
+	body := "inputs:\n  - " + filepath.Join(dir, "does-not-exist.txt") + "\nstages:\n  - splitChapters\n"
+	if err := os.WriteFile(recipePath, []byte(body), 0644); err != nil {
+		t.Fatalf("failed to write test recipe: %v", err)
+	}
+
+	_, err := runRecipeFile(recipePath, runRecipeOptions{OutputPath: filepath.Join(dir, "out.mp3"), DryRun: true})
+	if err == nil {
+		t.Fatal("expected an error for a missing input file, got nil")
+	}
+}
@@ -0,0 +1,438 @@
+package main
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"studiospeech/internal/agents"
+	"studiospeech/internal/agents/ssml"
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run StudioSpeech as an HTTP service",
+	Long: `Start an HTTP server exposing the synthesis pipeline over REST, so
+other tools can POST text and receive audio without spawning a CLI
+process per call.
+
+Endpoints:
+  POST /v1/synth         synthesize text to audio
+  GET  /v1/voices        list available voices
+  GET  /v1/attributions  required attribution text for licensed voices
+  GET  /healthz          backend availability`,
+	Run: runServe,
+}
+
+var (
+	listenAddr     string
+	maxConcurrency int
+	serveCacheDir  string
+	authToken      string
+)
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().StringVar(&listenAddr, "listen", ":8080", "address to listen on")
+	serveCmd.Flags().IntVar(&maxConcurrency, "max-concurrency", 4, "maximum number of concurrent synthesis requests")
+	serveCmd.Flags().StringVar(&serveCacheDir, "cache-dir", filepath.Join(os.TempDir(), "studiospeech_cache"), "directory for cached synthesis results")
+	serveCmd.Flags().StringVar(&authToken, "auth-token", "", "if set, required as a Bearer token on every /v1/ request (/healthz stays open)")
+}
+
+// synthRequest is the JSON body accepted by POST /v1/synth.
+type synthRequest struct {
+	Text    string  `json:"text"`
+	Lang    string  `json:"lang"`
+	VoiceID string  `json:"voice_id"`
+	Gender  string  `json:"gender"`
+	Format  string  `json:"format"`
+	Speed   float64 `json:"speed"`
+	SSML    bool    `json:"ssml"`
+}
+
+// maxPooledSynthesizers bounds how many per-voice synthAgentPool entries
+// serve keeps warm at once, so a catalog with many voices (or a client
+// cycling through voice_id values) can't accumulate one temp dir per
+// voice forever.
+const maxPooledSynthesizers = 8
+
+// pooledSynthesizer is a SynthAgent scoped to a single voice model and
+// kept alive across requests, so a voice's first Piper invocation pays
+// the model's cold-start cost and later requests for the same voice
+// reuse the warm agent instead of tearing down and recreating one (a
+// fresh temp dir plus agents.NewSynthAgent) on every single call.
+// refCount tracks how many in-flight requests are currently using this
+// entry's tempDir, so synthAgentPool.get's eviction can't pull it out
+// from under them (see release).
+type pooledSynthesizer struct {
+	agent    *agents.SynthAgent
+	tempDir  string
+	refCount int
+}
+
+// synthAgentPool is an LRU cache of pooledSynthesizers keyed by voice ID,
+// bounded at maxPooledSynthesizers entries; the same shape as
+// synthcache.MemoryCache, but evicting by entry count rather than bytes
+// since each entry is a live temp dir rather than an in-memory blob.
+type synthAgentPool struct {
+	mu    sync.Mutex
+	order *list.List // front = most recently used
+	items map[string]*list.Element
+}
+
+type synthPoolEntry struct {
+	voiceID string
+	synth   *pooledSynthesizer
+}
+
+func newSynthAgentPool() *synthAgentPool {
+	return &synthAgentPool{
+		order: list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// get returns the pooled synthesizer for voiceID, creating one (in its
+// own temp dir) on a miss and evicting least-recently-used entries if the
+// pool is already at maxPooledSynthesizers. Every call to get must be
+// paired with a later call to release, which makes the entry eligible
+// for eviction again; until then its refCount keeps it - and its tempDir
+// - alive no matter how many other voices cycle through the pool.
+func (p *synthAgentPool) get(voiceID string) (*pooledSynthesizer, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if elem, ok := p.items[voiceID]; ok {
+		p.order.MoveToFront(elem)
+		entry := elem.Value.(*synthPoolEntry)
+		entry.synth.refCount++
+		return entry.synth, nil
+	}
+
+	tempDir, err := os.MkdirTemp("", "studiospeech_serve_*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory for voice %s: %w", voiceID, err)
+	}
+
+	synth := &pooledSynthesizer{
+		agent:    agents.NewSynthAgent("piper", tempDir),
+		tempDir:  tempDir,
+		refCount: 1,
+	}
+	elem := p.order.PushFront(&synthPoolEntry{voiceID: voiceID, synth: synth})
+	p.items[voiceID] = elem
+
+	// Walk from the least-recently-used end, evicting until back under
+	// the cap. An entry still serving a request (refCount > 0) is left
+	// in place rather than deleted, so the pool can temporarily exceed
+	// maxPooledSynthesizers while every warm voice is busy instead of
+	// removing a tempDir a live synthesis is still reading from.
+	for elem := p.order.Back(); p.order.Len() > maxPooledSynthesizers && elem != nil; {
+		prev := elem.Prev()
+		entry := elem.Value.(*synthPoolEntry)
+		if entry.synth.refCount == 0 {
+			p.order.Remove(elem)
+			delete(p.items, entry.voiceID)
+			os.RemoveAll(entry.synth.tempDir)
+		}
+		elem = prev
+	}
+
+	return synth, nil
+}
+
+// release decrements voiceID's in-use count after a request has finished
+// with the pooled synthesizer get returned, making it eligible for
+// eviction again.
+func (p *synthAgentPool) release(voiceID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	elem, ok := p.items[voiceID]
+	if !ok {
+		return
+	}
+	if entry := elem.Value.(*synthPoolEntry); entry.synth.refCount > 0 {
+		entry.synth.refCount--
+	}
+}
+
+// server holds the long-lived state shared by request handlers: the
+// voice catalog (loaded once), a cache directory, a pool of warm
+// per-voice synthesizers, and a semaphore bounding how many synthesis
+// pipelines run concurrently so Piper invocations for large models don't
+// exhaust memory.
+type server struct {
+	voiceAgent *agents.VoiceCatalogAgent
+	cacheAgent *agents.CacheAgent
+	synthPool  *synthAgentPool
+	sem        chan struct{}
+}
+
+func newServer(catalogPath, cacheDir string, concurrency int) (*server, error) {
+	voiceAgent := agents.NewVoiceCatalogAgent(catalogPath)
+	if err := voiceAgent.LoadCatalog(); err != nil {
+		return nil, fmt.Errorf("failed to load voice catalog: %w", err)
+	}
+
+	cacheAgent := agents.NewCacheAgent(cacheDir)
+	if err := cacheAgent.Initialize(); err != nil {
+		return nil, fmt.Errorf("failed to initialize cache: %w", err)
+	}
+	cacheAgent.StartBackgroundEviction(15 * time.Minute)
+
+	return &server{
+		voiceAgent: voiceAgent,
+		cacheAgent: cacheAgent,
+		synthPool:  newSynthAgentPool(),
+		sem:        make(chan struct{}, concurrency),
+	}, nil
+}
+
+func (s *server) authMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if authToken == "" {
+			next(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer "+authToken {
+			http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	envAgent := agents.NewEnvironmentAgent()
+	info, err := envAgent.Check()
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":%q}`, err.Error()), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":          "ok",
+		"has_piper":       info.HasPiper,
+		"has_ffmpeg":      info.HasFFmpeg,
+		"compiled_codecs": info.CompiledCodecs,
+	})
+}
+
+func (s *server) handleVoices(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.voiceAgent.GetAvailableVoices())
+}
+
+func (s *server) handleAttributions(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.voiceAgent.GetAttributionText())
+}
+
+func (s *server) handleSynth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req synthRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"invalid request body: %s"}`, err), http.StatusBadRequest)
+		return
+	}
+	if req.Text == "" {
+		http.Error(w, `{"error":"text is required"}`, http.StatusBadRequest)
+		return
+	}
+	if req.Format == "" {
+		req.Format = "mp3"
+	}
+	if req.Speed == 0 {
+		req.Speed = 1.03
+	}
+
+	// Bound concurrent synthesis independently of how many HTTP
+	// requests are in flight, since encoding is the memory-heavy step.
+	select {
+	case s.sem <- struct{}{}:
+		defer func() { <-s.sem }()
+	case <-r.Context().Done():
+		return
+	}
+
+	voice, err := s.voiceAgent.SelectVoice(req.Lang, req.VoiceID, req.Gender)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"voice selection failed: %s"}`, err), http.StatusBadRequest)
+		return
+	}
+
+	pooled, err := s.synthPool.get(voice.ID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"%s"}`, err), http.StatusInternalServerError)
+		return
+	}
+	defer s.synthPool.release(voice.ID)
+
+	params := &agents.SynthParams{Speed: req.Speed, Noise: 0.667, NoiseW: 0.8}
+	postParams := &agents.PostProcessParams{
+		Format:       agents.AudioFormat(req.Format),
+		SampleRate:   48000,
+		Bitrate:      192,
+		LoudnessLUFS: -16.0,
+	}
+
+	if !req.SSML {
+		// Plain text skips the file-based path entirely: SynthesizeStream
+		// and ProcessStream are wired together through an io.Pipe
+		// straight to the response, so a sentence's audio never has to
+		// land on disk.
+		content := &agents.TextContent{
+			Paragraphs: []string{req.Text},
+			Language:   voice.Language,
+		}
+
+		normalizeAgent := agents.NewNormalizeAgent()
+		normalized, err := normalizeAgent.Normalize(content)
+		if err != nil {
+			http.Error(w, fmt.Sprintf(`{"error":"normalization failed: %s"}`, err), http.StatusInternalServerError)
+			return
+		}
+
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+
+		stream, streamResult, err := pooled.agent.SynthesizeStream(ctx, normalized, voice, params)
+		if err != nil {
+			http.Error(w, fmt.Sprintf(`{"error":"synthesis failed: %s"}`, err), http.StatusInternalServerError)
+			return
+		}
+		defer stream.Close()
+
+		postAgent := agents.NewPostProcessAgent("ffmpeg", pooled.tempDir)
+
+		w.Header().Set("Content-Type", contentTypeForFormat(req.Format))
+		w.Header().Set("Transfer-Encoding", "chunked")
+		w.WriteHeader(http.StatusOK)
+
+		if err := postAgent.ProcessStream(ctx, cancel, stream, streamResult.SampleRate, streamResult.Channels, w, postParams); err != nil {
+			fmt.Printf("synth stream for voice %s failed mid-response: %v\n", voice.ID, err)
+		}
+		return
+	}
+
+	doc, err := ssml.Parse([]byte(req.Text), voice.Language)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"SSML parsing failed: %s"}`, err), http.StatusBadRequest)
+		return
+	}
+
+	normalizeAgent := agents.NewNormalizeAgent()
+	segments, err := normalizeAgent.NormalizeSSML(doc, voice.Language)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"SSML normalization failed: %s"}`, err), http.StatusInternalServerError)
+		return
+	}
+
+	resolveVoice := func(name string) (*agents.Voice, error) {
+		return s.voiceAgent.SelectVoice(req.Lang, name, "")
+	}
+
+	result, err := pooled.agent.SynthesizeSSML(segments, voice, params, resolveVoice)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"synthesis failed: %s"}`, err), http.StatusInternalServerError)
+		return
+	}
+	defer pooled.agent.CleanupTempFiles(result)
+
+	postAgent := agents.NewPostProcessAgent("ffmpeg", pooled.tempDir)
+	outPath := filepath.Join(pooled.tempDir, fmt.Sprintf("out_%d.%s", time.Now().UnixNano(), req.Format))
+	postResult, err := postAgent.Process(result.OutputPath, outPath, postParams)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"post-processing failed: %s"}`, err), http.StatusInternalServerError)
+		return
+	}
+	defer postAgent.CleanupTempFiles(postResult)
+
+	audio, err := os.Open(postResult.OutputPath)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"%s"}`, err), http.StatusInternalServerError)
+		return
+	}
+	defer audio.Close()
+
+	w.Header().Set("Content-Type", contentTypeForFormat(req.Format))
+	w.Header().Set("Transfer-Encoding", "chunked")
+	w.WriteHeader(http.StatusOK)
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, readErr := audio.Read(buf)
+		if n > 0 {
+			w.Write(buf[:n])
+			if flusher, ok := w.(http.Flusher); ok {
+				flusher.Flush()
+			}
+		}
+		if readErr != nil {
+			return
+		}
+	}
+}
+
+func contentTypeForFormat(format string) string {
+	switch format {
+	case "mp3":
+		return "audio/mpeg"
+	case "opus":
+		return "audio/ogg"
+	case "flac":
+		return "audio/flac"
+	case "aac":
+		return "audio/aac"
+	default:
+		return "audio/wav"
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// runServe starts the HTTP server and blocks until it exits.
+func runServe(cmd *cobra.Command, args []string) {
+	catalogPath := filepath.Join("voices", "catalog.json")
+
+	srv, err := newServer(catalogPath, serveCacheDir, maxConcurrency)
+	if err != nil {
+		fmt.Printf("❌ Failed to start server: %v\n", err)
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", srv.handleHealthz)
+	mux.HandleFunc("/v1/voices", srv.authMiddleware(srv.handleVoices))
+	mux.HandleFunc("/v1/attributions", srv.authMiddleware(srv.handleAttributions))
+	mux.HandleFunc("/v1/synth", srv.authMiddleware(srv.handleSynth))
+
+	httpServer := &http.Server{
+		Addr:         listenAddr,
+		Handler:      mux,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 5 * time.Minute,
+	}
+
+	fmt.Printf("🌐 StudioSpeech serving on %s (max-concurrency=%d)\n", listenAddr, maxConcurrency)
+	if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		fmt.Printf("❌ Server error: %v\n", err)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"studiospeech/internal/agents"
+	"studiospeech/internal/agents/ssml"
+)
+
+// executeSSMLSynthesisPipeline runs the synthesis pipeline for an --in
+// file whose contents are SSML markup rather than plain text: it parses
+// the markup into a tree of segments, resolves each segment's own
+// voice/prosody overrides against the voice catalog, synthesizes and
+// concatenates the segments into one WAV, then runs the result through
+// the same caching and post-processing steps as the plain-text pipeline.
+func executeSSMLSynthesisPipeline(raw string) error {
+	fmt.Println("🔄 Starting SSML synthesis pipeline...")
+
+	resolvedLanguage := language
+	if resolvedLanguage == "auto" || resolvedLanguage == "" {
+		resolvedLanguage = "en-US"
+	}
+
+	fmt.Printf("📖 Parsing SSML: %s\n", inputFile)
+	doc, err := ssml.Parse([]byte(raw), resolvedLanguage)
+	if err != nil {
+		return fmt.Errorf("SSML parsing failed: %w", err)
+	}
+	fmt.Printf("   ✓ Parsed %d segments\n", len(doc.Segments))
+
+	fmt.Printf("🎭 Selecting voice...\n")
+	catalogPath := filepath.Join("voices", "catalog.json")
+	voiceAgent := agents.NewVoiceCatalogAgent(catalogPath)
+	if err := voiceAgent.LoadCatalog(); err != nil {
+		return fmt.Errorf("voice catalog loading failed: %w", err)
+	}
+
+	selectedVoice, err := voiceAgent.SelectVoiceForBackend(resolvedLanguage, voiceID, gender, backend)
+	if err != nil {
+		return fmt.Errorf("voice selection failed: %w", err)
+	}
+	fmt.Printf("   ✓ Selected voice: %s (%s %s)\n", selectedVoice.ID, selectedVoice.Gender, selectedVoice.Style)
+
+	fmt.Printf("🔧 Normalizing segments...\n")
+	normalizeAgent := agents.NewNormalizeAgent()
+	segments, err := normalizeAgent.NormalizeSSML(doc, resolvedLanguage)
+	if err != nil {
+		return fmt.Errorf("SSML normalization failed: %w", err)
+	}
+
+	tempDir, err := os.MkdirTemp("", "studiospeech_ssml_*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	synthAgent := agents.NewSynthAgent("piper", tempDir)
+	synthAgent.SetDryRun(false)
+
+	synthParams := &agents.SynthParams{Speed: speed, Noise: noise, NoiseW: noisew, Speaker: 0}
+
+	resolveVoice := func(name string) (*agents.Voice, error) {
+		return voiceAgent.SelectVoice(resolvedLanguage, name, "")
+	}
+
+	fmt.Printf("🎤 Synthesizing speech...\n")
+	result, err := synthAgent.SynthesizeSSML(segments, selectedVoice, synthParams, resolveVoice)
+	if err != nil {
+		return fmt.Errorf("synthesis failed: %w", err)
+	}
+	fmt.Printf("   ✓ Generated audio: %s\n", result.OutputPath)
+	fmt.Printf("   ✓ Sample rate: %d Hz, Channels: %d\n", result.SampleRate, result.Channels)
+
+	fmt.Printf("💾 Checking cache...\n")
+	cacheDir := filepath.Join(os.TempDir(), "studiospeech_cache")
+	cacheAgent := agents.NewCacheAgent(cacheDir)
+	if err := cacheAgent.Initialize(); err != nil {
+		return fmt.Errorf("cache initialization failed: %w", err)
+	}
+
+	postParams := &agents.PostProcessParams{
+		Format:       agents.AudioFormat(format),
+		SampleRate:   sampleRate,
+		Bitrate:      bitrate,
+		LoudnessLUFS: -16.0,
+	}
+
+	cacheKey := cacheAgent.GenerateSSMLKey(doc, selectedVoice, synthParams, postParams)
+
+	if entry, err := cacheAgent.Get(cacheKey); err == nil && entry != nil {
+		fmt.Printf("   ✅ Cache hit! Using cached audio\n")
+		fmt.Printf("   ✓ Cached file: %s\n", entry.FilePath)
+
+		if err := copyFile(entry.FilePath, outputFile); err != nil {
+			return fmt.Errorf("failed to copy cached file: %w", err)
+		}
+		return nil
+	}
+
+	fmt.Printf("   ⚠️  Cache miss - will synthesize and cache result\n")
+
+	fmt.Printf("🎵 Post-processing...\n")
+	postAgent := agents.NewPostProcessAgent("ffmpeg", tempDir)
+	postAgent.SetDryRun(false)
+
+	postResult, err := postAgent.Process(result.OutputPath, outputFile, postParams)
+	if err != nil {
+		return fmt.Errorf("post-processing failed: %w", err)
+	}
+	fmt.Printf("   ✓ Processed audio: %s\n", postResult.OutputPath)
+	fmt.Printf("   ✓ Format: %s, Sample rate: %d Hz\n", postResult.Format, postResult.SampleRate)
+
+	fmt.Printf("💾 Caching result...\n")
+	metadata := map[string]interface{}{
+		"voice":    selectedVoice.ID,
+		"language": resolvedLanguage,
+		"format":   string(postParams.Format),
+		"segments": len(doc.Segments),
+	}
+	if err := cacheAgent.Put(cacheKey, postResult.OutputPath, metadata); err != nil {
+		fmt.Printf("   ⚠️  Failed to cache result: %v\n", err)
+	} else {
+		fmt.Printf("   ✓ Result cached for future use\n")
+	}
+
+	return nil
+}
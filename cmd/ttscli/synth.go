@@ -6,19 +6,28 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"studiospeech/internal/agents"
+	"studiospeech/internal/agents/extractor"
+	"studiospeech/internal/audio/encoder"
 )
 
 // synthCmd represents the synth command
 var synthCmd = &cobra.Command{
 	Use:   "synth",
 	Short: "Synthesize speech from text files",
-	Long: `Convert text files (.txt, .docx, or .pdf) to high-quality speech audio.
+	Long: `Convert text to high-quality speech audio. --in accepts a local
+.txt/.docx/.pdf/.epub file, an https:// article URL, a "yt:VIDEOID"
+YouTube caption source, or a "feed:<url>" RSS/Atom feed.
 
 Examples:
   ttscli synth --in script.txt --lang en-US --gender female --out voice.mp3
   ttscli synth --in document.docx --lang el-GR --gender male --format wav
   ttscli synth --in document.pdf --lang en-US --gender female --out speech.mp3
-  ttscli synth --in story.txt --speed 1.05 --gender auto --out narration.mp3`,
+  ttscli synth --in story.txt --speed 1.05 --gender auto --out narration.mp3
+  ttscli synth --in https://example.com/article --out article.mp3
+  ttscli synth --in yt:dQw4w9WgXcQ --out video.mp3
+  ttscli synth --in feed:https://example.com/posts.xml --out posts.mp3`,
 	Run: runSynth,
 }
 
@@ -31,11 +40,16 @@ var (
 	language string
 	voiceID  string
 	gender   string
+	backend  string
 	
 	// Audio format flags
 	format     string
 	sampleRate int
 	bitrate    int
+
+	// Per-codec knobs
+	opusBitrate     int
+	flacCompression int
 	
 	// Synthesis parameters
 	speed  float64
@@ -44,13 +58,22 @@ var (
 	
 	// Processing flags
 	noCache bool
+
+	// Subtitle flags
+	subs            string
+	subsGranularity string
+
+	// Chapter-aware batch synthesis flags
+	split   string
+	quality string
+	makeM4B bool
 )
 
 func init() {
 	rootCmd.AddCommand(synthCmd)
 	
 	// Input/Output flags
-	synthCmd.Flags().StringVarP(&inputFile, "in", "i", "", "input text file (.txt or .docx)")
+	synthCmd.Flags().StringVarP(&inputFile, "in", "i", "", "input source: a .txt/.docx/.pdf/.epub file, an https:// article URL, yt:VIDEOID, or feed:<url>")
 	synthCmd.Flags().StringVarP(&outputFile, "out", "o", "", "output audio file")
 	synthCmd.MarkFlagRequired("in")
 	synthCmd.MarkFlagRequired("out")
@@ -59,12 +82,15 @@ func init() {
 	synthCmd.Flags().StringVarP(&language, "lang", "l", "auto", "language code (en-US, en-UK, el-GR, or auto)")
 	synthCmd.Flags().StringVar(&voiceID, "voice", "auto", "voice ID from catalog (or auto for default)")
 	synthCmd.Flags().StringVarP(&gender, "gender", "g", "auto", "voice gender (male, female, or auto)")
+	synthCmd.Flags().StringVar(&backend, "backend", "auto", "tts backend to use (piper, espeak, coqui, grpc, or auto)")
 
 	
 	// Audio format flags
-	synthCmd.Flags().StringVarP(&format, "format", "f", "mp3", "output format (wav, mp3)")
+	synthCmd.Flags().StringVarP(&format, "format", "f", "mp3", "output format (wav, mp3, opus, flac, aac - availability depends on build tags)")
 	synthCmd.Flags().IntVar(&sampleRate, "sample-rate", 48000, "output sample rate in Hz")
 	synthCmd.Flags().IntVar(&bitrate, "bitrate", 192, "MP3 bitrate in kbps")
+	synthCmd.Flags().IntVar(&opusBitrate, "opus-bitrate", 96, "Opus bitrate in kbps")
+	synthCmd.Flags().IntVar(&flacCompression, "flac-compression", 5, "FLAC compression level (0-8)")
 	
 	// Synthesis parameters
 	synthCmd.Flags().Float64Var(&speed, "speed", 1.03, "speech speed multiplier (0.5-2.0)")
@@ -73,6 +99,15 @@ func init() {
 	
 	// Processing flags
 	synthCmd.Flags().BoolVar(&noCache, "no-cache", false, "disable caching of synthesized audio")
+
+	// Subtitle flags
+	synthCmd.Flags().StringVar(&subs, "subs", "", "comma-separated subtitle formats to emit alongside the audio (srt,vtt)")
+	synthCmd.Flags().StringVar(&subsGranularity, "subs-granularity", "sentence", "subtitle cue granularity (sentence, word)")
+
+	// Chapter-aware batch synthesis flags
+	synthCmd.Flags().StringVar(&split, "split", "", "synthesize one file per unit instead of one combined file: chapters, paragraphs, or size:<minutes>")
+	synthCmd.Flags().StringVar(&quality, "quality", "standard", "quality tier (standard, high, studio) - picks sample rate/bitrate targets and a voice that meets them")
+	synthCmd.Flags().BoolVar(&makeM4B, "m4b", false, "also mux split output into an .m4b audiobook container with embedded chapters")
 }
 
 // runSynth executes the speech synthesis pipeline
@@ -109,49 +144,65 @@ func runSynth(cmd *cobra.Command, args []string) {
 		fmt.Printf("🎵 Bitrate: %d kbps\n", bitrate)
 	}
 	fmt.Printf("📈 Sample Rate: %d Hz\n\n", sampleRate)
-	
+
 	// Execute synthesis pipeline
-	if err := executeSynthesisPipeline(); err != nil {
+	var err error
+	if split != "" {
+		err = executeChapteredSynthesisPipeline()
+	} else {
+		err = executeSynthesisPipeline()
+	}
+	if err != nil {
 		fmt.Printf("❌ Synthesis failed: %v\n", err)
 		return
 	}
-	
+
 	fmt.Println("✅ Synthesis completed successfully!")
 }
 
-// validateInputFile checks if input file exists and has supported extension
+// validateInputFile checks that the --in value is either a remote/special
+// source one of the registered extractors understands (an https:// URL,
+// yt:VIDEOID, feed:<url>, or a local .epub), or a local file with a
+// supported extension.
 func validateInputFile(path string) error {
 	if path == "" {
 		return fmt.Errorf("input file is required")
 	}
-	
+
+	if extractor.Resolve(path) != nil {
+		return nil
+	}
+
 	ext := strings.ToLower(filepath.Ext(path))
 	if ext != ".txt" && ext != ".docx" && ext != ".pdf" {
-		return fmt.Errorf("unsupported file type: %s (supported: .txt, .docx, .pdf)", ext)
+		return fmt.Errorf("unsupported input: %s (supported: .txt, .docx, .pdf, .epub, https://, yt:, feed:)", path)
 	}
-	
+
 	// TODO: Check if file exists
 	return nil
 }
 
-// validateOutputFile checks output file path and extension
+// validateOutputFile checks output file path and extension against the
+// registry of codecs actually compiled into this binary (wav is always
+// supported; every other format depends on which encoder/*.go files were
+// built with their codec's build tag enabled).
 func validateOutputFile(path string) error {
 	if path == "" {
 		return fmt.Errorf("output file is required")
 	}
-	
-	ext := strings.ToLower(filepath.Ext(path))
-	if ext != ".wav" && ext != ".mp3" {
-		return fmt.Errorf("unsupported output format: %s (supported: .wav, .mp3)", ext)
+
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+	if ext != "wav" {
+		if _, ok := encoder.Get(ext); !ok {
+			return fmt.Errorf("unsupported output format: .%s (enabled: wav, %s)", ext, strings.Join(encoder.Enabled(), ", "))
+		}
 	}
-	
+
 	// Auto-detect format from extension if not explicitly set
-	if format == "mp3" && ext == ".wav" {
-		format = "wav"
-	} else if format == "wav" && ext == ".mp3" {
-		format = "mp3"
+	if format != ext {
+		format = ext
 	}
-	
+
 	return nil
 }
 
@@ -172,6 +223,31 @@ func validateSynthParams() error {
 	if bitrate < 64 || bitrate > 320 {
 		return fmt.Errorf("bitrate must be between 64 and 320 kbps, got %d", bitrate)
 	}
-	
+
+	if subsGranularity != "sentence" && subsGranularity != "word" {
+		return fmt.Errorf("subs-granularity must be 'sentence' or 'word', got %s", subsGranularity)
+	}
+
+	if subs != "" {
+		for _, f := range strings.Split(subs, ",") {
+			f = strings.ToLower(strings.TrimSpace(f))
+			if f != "srt" && f != "vtt" {
+				return fmt.Errorf("unsupported subtitle format: %s (supported: srt, vtt)", f)
+			}
+		}
+	}
+
+	if _, err := agents.ParseQualityTier(quality); err != nil {
+		return err
+	}
+
+	if split != "" && split != "chapters" && split != "paragraphs" && !strings.HasPrefix(split, "size:") {
+		return fmt.Errorf("unsupported --split mode: %s (expected chapters, paragraphs, or size:<minutes>)", split)
+	}
+
+	if makeM4B && split == "" {
+		return fmt.Errorf("--m4b requires --split")
+	}
+
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"studiospeech/internal/agents"
+)
+
+// voicesCmd is the parent command for catalog-level voice management:
+// listing, downloading, verifying, and pruning installed voice models.
+var voicesCmd = &cobra.Command{
+	Use:   "voices",
+	Short: "Manage voice models referenced by the catalog",
+}
+
+var (
+	voicesCatalogPath  string
+	allowNonCommercial bool
+	downloadAllVoices  bool
+	offlineMode        bool
+)
+
+var voicesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List voices in the catalog and whether they're installed",
+	RunE:  runVoicesList,
+}
+
+var voicesDownloadCmd = &cobra.Command{
+	Use:   "download [voice-id]",
+	Short: "Download a voice model (or all of them with --all)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runVoicesDownload,
+}
+
+var voicesVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify installed voice files against the catalog's recorded SHA-256",
+	RunE:  runVoicesVerify,
+}
+
+var voicesPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove corrupt voice files and leftover partial downloads",
+	RunE:  runVoicesPrune,
+}
+
+func init() {
+	rootCmd.AddCommand(voicesCmd)
+	voicesCmd.PersistentFlags().StringVar(&voicesCatalogPath, "catalog", "voices/catalog.json", "path to the voice catalog JSON file")
+
+	voicesDownloadCmd.Flags().BoolVar(&allowNonCommercial, "allow-non-commercial", false, "allow downloading voices not licensed for commercial use")
+	voicesDownloadCmd.Flags().BoolVar(&downloadAllVoices, "all", false, "download every voice in the catalog")
+	voicesDownloadCmd.Flags().BoolVar(&offlineMode, "offline", false, "refuse network access; fail instead of downloading anything not already cached")
+
+	voicesCmd.AddCommand(voicesListCmd, voicesDownloadCmd, voicesVerifyCmd, voicesPruneCmd)
+}
+
+func loadVoiceCatalog() (*agents.VoiceCatalogAgent, error) {
+	catalog := agents.NewVoiceCatalogAgent(voicesCatalogPath)
+	if err := catalog.LoadCatalog(); err != nil {
+		return nil, fmt.Errorf("failed to load catalog: %w", err)
+	}
+	return catalog, nil
+}
+
+func runVoicesList(cmd *cobra.Command, args []string) error {
+	catalog, err := loadVoiceCatalog()
+	if err != nil {
+		return err
+	}
+
+	for _, voice := range catalog.GetAvailableVoices() {
+		status := "not installed"
+		if err := catalog.ValidateVoiceFile(&voice); err == nil {
+			status = "installed"
+		}
+		fmt.Printf("%-20s %-8s %-8s %-8s backend=%-8s %s\n",
+			voice.ID, voice.Language, voice.Gender, voice.Style, voiceBackend(voice), status)
+	}
+
+	return nil
+}
+
+// voiceBackend mirrors the agents package's unexported default-to-"piper"
+// helper so the CLI can print the same backend name it selects on.
+func voiceBackend(voice agents.Voice) string {
+	if voice.Backend == "" {
+		return "piper"
+	}
+	return voice.Backend
+}
+
+func runVoicesDownload(cmd *cobra.Command, args []string) error {
+	catalog, err := loadVoiceCatalog()
+	if err != nil {
+		return err
+	}
+
+	opts := agents.DownloadOptions{
+		AllowNonCommercial: allowNonCommercial,
+		Progress:           printDownloadProgress,
+	}
+
+	envAgent := agents.NewEnvironmentAgent()
+	envAgent.SetOffline(offlineMode)
+
+	var voiceIDs []string
+	if downloadAllVoices {
+		for _, voice := range catalog.GetAvailableVoices() {
+			voiceIDs = append(voiceIDs, voice.ID)
+		}
+	} else {
+		if len(args) != 1 {
+			return fmt.Errorf("expected a voice ID, or pass --all to download every voice")
+		}
+		voiceIDs = []string{args[0]}
+	}
+
+	results := envAgent.Fetch(catalog, voiceIDs, opts)
+
+	var failed int
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			fmt.Printf("❌ %s: %v\n", result.VoiceID, result.Err)
+			continue
+		}
+		fmt.Printf("\n✓ Downloaded %s\n", result.VoiceID)
+		for _, voice := range catalog.GetAvailableVoices() {
+			if voice.ID == result.VoiceID && voice.AttributionRequired {
+				fmt.Println(agents.AttributionTextForVoice(voice))
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d voice(s) failed to download", failed)
+	}
+	return nil
+}
+
+func printDownloadProgress(url string, downloaded, total int64) {
+	if total > 0 {
+		fmt.Printf("\r%s: %d/%d bytes (%.0f%%)", url, downloaded, total, float64(downloaded)/float64(total)*100)
+	} else {
+		fmt.Printf("\r%s: %d bytes", url, downloaded)
+	}
+}
+
+func runVoicesVerify(cmd *cobra.Command, args []string) error {
+	catalog, err := loadVoiceCatalog()
+	if err != nil {
+		return err
+	}
+
+	installer := agents.NewVoiceInstaller(catalog)
+	errs := installer.Verify()
+	if len(errs) == 0 {
+		fmt.Println("✓ All voices verified")
+		return nil
+	}
+
+	for _, err := range errs {
+		fmt.Printf("❌ %v\n", err)
+	}
+	return fmt.Errorf("%d voice(s) failed verification", len(errs))
+}
+
+func runVoicesPrune(cmd *cobra.Command, args []string) error {
+	catalog, err := loadVoiceCatalog()
+	if err != nil {
+		return err
+	}
+
+	installer := agents.NewVoiceInstaller(catalog)
+	removed, err := installer.Prune()
+	if err != nil {
+		return err
+	}
+
+	if len(removed) == 0 {
+		fmt.Println("Nothing to prune")
+		return nil
+	}
+
+	for _, path := range removed {
+		fmt.Printf("removed %s\n", path)
+	}
+	return nil
+}
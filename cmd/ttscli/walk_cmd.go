@@ -0,0 +1,422 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"studiospeech/internal/agents"
+	"studiospeech/internal/agents/respipeline"
+	"studiospeech/internal/synthcache"
+)
+
+// walkCmd recursively visits a source tree (the same way walkPkgDirs
+// visits a Go source root in the standard library's own types2 tests)
+// and mirrors it as synthesized .mp3 files under --output-root.
+var walkCmd = &cobra.Command{
+	Use:   "walk <root>",
+	Short: "Recursively synthesize a source tree, mirroring it under --output-root",
+	Long: `Walk <root>, matching files against --pattern (repeatable; default
+"*.txt" and "*.md"), and write each one's synthesized audio to the same
+relative path under --output-root with a .mp3 extension.
+
+A ".ttsignore" file in any directory excludes matching paths beneath it,
+using the same pattern syntax as .gitignore (comments, "!" negation,
+trailing "/" for directory-only patterns). A file whose content starts
+with a "---" front-matter block containing "tts: skip" is excluded too.
+
+With --incremental (the default), a file is skipped when its mtime is no
+newer than its existing output's mtime. The synthesized-audio cache (see
+the "pipeline" command's --no-cache/--cache-ttl) backs this up: even a
+file that's re-visited because its mtime looks newer will hit the cache
+and skip the TTS engine if its normalized text hasn't actually changed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWalk,
+}
+
+var (
+	walkOutputRoot  string
+	walkLanguage    string
+	walkPatterns    []string
+	walkIncremental bool
+	walkNoCache     bool
+	walkCacheTTL    string
+	walkSummaryPath string
+)
+
+func init() {
+	rootCmd.AddCommand(walkCmd)
+
+	walkCmd.Flags().StringVar(&walkOutputRoot, "output-root", "", "directory to mirror the source tree's synthesized .mp3 files into (required)")
+	walkCmd.MarkFlagRequired("output-root")
+	walkCmd.Flags().StringVar(&walkLanguage, "lang", "auto", "language code (en-US, en-UK, el-GR, or auto to detect per file)")
+	walkCmd.Flags().StringArrayVar(&walkPatterns, "pattern", []string{"*.txt", "*.md"}, "glob pattern to match against each file's base name (repeatable)")
+	walkCmd.Flags().BoolVar(&walkIncremental, "incremental", true, "skip files whose mtime is no newer than their existing output's")
+	walkCmd.Flags().BoolVar(&walkNoCache, "no-cache", false, "skip the synthesized-audio cache, always invoking the TTS engine")
+	walkCmd.Flags().StringVar(&walkCacheTTL, "cache-ttl", "0", "expire cached audio older than this (e.g. 720h); 0 disables expiry")
+	walkCmd.Flags().StringVar(&walkSummaryPath, "summary", "", "write the JSON run summary to this path instead of stdout")
+}
+
+// walkFileResult is one visited file's outcome.
+type walkFileResult struct {
+	Path   string `json:"path"`
+	Output string `json:"output,omitempty"`
+	Status string `json:"status"` // "synthesized", "skipped", "ignored", or "error"
+	Error  string `json:"error,omitempty"`
+}
+
+// walkSummary is the JSON document emitted at the end of a run.
+type walkSummary struct {
+	Files       int              `json:"files"`
+	Synthesized int              `json:"synthesized"`
+	Skipped     int              `json:"skipped"`
+	Ignored     int              `json:"ignored"`
+	Failed      int              `json:"failed"`
+	Results     []walkFileResult `json:"results"`
+}
+
+// walkRunOptions carries everything runWalkSynthesis needs, separate
+// from the package-level flag variables so tests can drive it directly.
+type walkRunOptions struct {
+	OutputRoot  string
+	Language    string
+	Patterns    []string
+	CatalogPath string
+	Incremental bool
+	Cache       synthcache.Cache
+	DryRun      bool
+}
+
+func runWalk(cmd *cobra.Command, args []string) error {
+	opts := walkRunOptions{
+		OutputRoot:  walkOutputRoot,
+		Language:    walkLanguage,
+		Patterns:    walkPatterns,
+		CatalogPath: filepath.Join("voices", "catalog.json"),
+		Incremental: walkIncremental,
+	}
+
+	if !walkNoCache {
+		ttl, err := time.ParseDuration(walkCacheTTL)
+		if err != nil {
+			return fmt.Errorf("invalid --cache-ttl: %w", err)
+		}
+		opts.Cache = synthcache.NewDiskCache(synthcache.DefaultDiskCacheDir(), "wav", ttl)
+	}
+
+	summary, err := runWalkSynthesis(args[0], opts)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal summary: %w", err)
+	}
+
+	if walkSummaryPath != "" {
+		if err := os.WriteFile(walkSummaryPath, data, 0644); err != nil {
+			return fmt.Errorf("failed to write summary: %w", err)
+		}
+		fmt.Printf("✓ Wrote %s\n", walkSummaryPath)
+	} else {
+		fmt.Println(string(data))
+	}
+
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d of %d files failed", summary.Failed, summary.Files)
+	}
+	return nil
+}
+
+// runWalkSynthesis recursively visits root and synthesizes every
+// matching, non-ignored file into opts.OutputRoot.
+func runWalkSynthesis(root string, opts walkRunOptions) (walkSummary, error) {
+	patterns := opts.Patterns
+	if len(patterns) == 0 {
+		patterns = []string{"*.txt", "*.md"}
+	}
+
+	rules, err := loadIgnoreRules(root)
+	if err != nil {
+		return walkSummary{}, fmt.Errorf("failed to load .ttsignore rules: %w", err)
+	}
+
+	var results []walkFileResult
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == root {
+			return nil
+		}
+
+		if d.IsDir() {
+			if isIgnored(rules, path, true) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		if !matchesAnyPattern(patterns, filepath.Base(path)) {
+			return nil
+		}
+		if isIgnored(rules, path, false) {
+			results = append(results, walkFileResult{Path: rel, Status: "ignored"})
+			return nil
+		}
+
+		skip, err := hasSkipFrontMatter(path)
+		if err != nil {
+			results = append(results, walkFailure(rel, fmt.Errorf("reading front matter failed: %w", err)))
+			return nil
+		}
+		if skip {
+			results = append(results, walkFileResult{Path: rel, Status: "ignored"})
+			return nil
+		}
+
+		outputPath := walkOutputPath(opts.OutputRoot, rel)
+
+		if opts.Incremental {
+			upToDate, err := isUpToDate(path, outputPath)
+			if err != nil {
+				results = append(results, walkFailure(rel, fmt.Errorf("checking mtime failed: %w", err)))
+				return nil
+			}
+			if upToDate {
+				results = append(results, walkFileResult{Path: rel, Output: outputPath, Status: "skipped"})
+				return nil
+			}
+		}
+
+		results = append(results, synthesizeWalkFile(path, rel, outputPath, opts))
+		return nil
+	})
+	if err != nil {
+		return walkSummary{}, fmt.Errorf("failed to walk %s: %w", root, err)
+	}
+
+	return summarizeWalk(results), nil
+}
+
+// matchesAnyPattern reports whether name matches any of patterns.
+func matchesAnyPattern(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// walkOutputPath mirrors rel (a path relative to the walked root) under
+// outputRoot, swapping its extension for .mp3.
+func walkOutputPath(outputRoot, rel string) string {
+	base := strings.TrimSuffix(rel, filepath.Ext(rel)) + ".mp3"
+	return filepath.Join(outputRoot, base)
+}
+
+// isUpToDate reports whether inputPath's mtime is no newer than
+// outputPath's, meaning a previous run's output is still current.
+func isUpToDate(inputPath, outputPath string) (bool, error) {
+	in, err := os.Stat(inputPath)
+	if err != nil {
+		return false, err
+	}
+	out, err := os.Stat(outputPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return !in.ModTime().After(out.ModTime()), nil
+}
+
+// hasSkipFrontMatter reports whether path opens with a "---" front-matter
+// block containing a "tts: skip" line.
+func hasSkipFrontMatter(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() || strings.TrimSpace(scanner.Text()) != "---" {
+		return false, scanner.Err()
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "---" {
+			break
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+		if strings.TrimSpace(key) == "tts" && value == "skip" {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+// synthesizeWalkFile runs path through normalize|detectLang|synthesize|
+// transcodeMP3|loudnessNorm and writes the result to outputPath.
+func synthesizeWalkFile(path, rel, outputPath string, opts walkRunOptions) walkFileResult {
+	content, err := agents.NewTextIngestAgent().ProcessFile(path)
+	if err != nil {
+		return walkFailure(rel, fmt.Errorf("ingestion failed: %w", err))
+	}
+
+	tempDir, err := os.MkdirTemp("", "studiospeech_walk_*")
+	if err != nil {
+		return walkFailure(rel, fmt.Errorf("failed to create temp dir: %w", err))
+	}
+	defer os.RemoveAll(tempDir)
+
+	stages, err := respipeline.ParseStages("normalize|detectLang:lang=" + opts.Language + "|synthesize|transcodeMP3|loudnessNorm")
+	if err != nil {
+		return walkFailure(rel, fmt.Errorf("internal error parsing stages: %w", err))
+	}
+
+	runOpts := &respipeline.Options{
+		DryRun:      opts.DryRun,
+		TempDir:     tempDir,
+		CatalogPath: opts.CatalogPath,
+		Cache:       opts.Cache,
+	}
+
+	in := respipeline.NewTextResource(content)
+	out, _, err := respipeline.New(stages).Run(runOpts, in)
+	if err != nil {
+		return walkFailure(rel, fmt.Errorf("synthesis failed: %w", err))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return walkFailure(rel, fmt.Errorf("failed to create output directory: %w", err))
+	}
+	if err := copyFile(out.AudioPath, outputPath); err != nil {
+		return walkFailure(rel, fmt.Errorf("failed to write output: %w", err))
+	}
+
+	return walkFileResult{Path: rel, Output: outputPath, Status: "synthesized"}
+}
+
+func walkFailure(rel string, err error) walkFileResult {
+	return walkFileResult{Path: rel, Status: "error", Error: err.Error()}
+}
+
+func summarizeWalk(results []walkFileResult) walkSummary {
+	summary := walkSummary{Files: len(results), Results: results}
+	for _, r := range results {
+		switch r.Status {
+		case "synthesized":
+			summary.Synthesized++
+		case "skipped":
+			summary.Skipped++
+		case "ignored":
+			summary.Ignored++
+		default:
+			summary.Failed++
+		}
+	}
+	return summary
+}
+
+// ignoreRule is one line parsed out of a .ttsignore file, scoped to the
+// directory it was found in, using .gitignore pattern syntax.
+type ignoreRule struct {
+	baseDir string
+	pattern string
+	negate  bool
+	dirOnly bool
+}
+
+// loadIgnoreRules finds every .ttsignore file under root and parses it
+// into ignoreRules scoped to its containing directory.
+func loadIgnoreRules(root string) ([]ignoreRule, error) {
+	var rules []ignoreRule
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() != ".ttsignore" {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		dir := filepath.Dir(path)
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			negate := strings.HasPrefix(line, "!")
+			if negate {
+				line = line[1:]
+			}
+			dirOnly := strings.HasSuffix(line, "/")
+			line = strings.TrimSuffix(line, "/")
+
+			rules = append(rules, ignoreRule{baseDir: dir, pattern: line, negate: negate, dirOnly: dirOnly})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// isIgnored reports whether path is excluded by rules, applying them in
+// order (later rules win, so a "!" negation can re-include a path an
+// earlier pattern excluded) -- the same last-match-wins semantics
+// .gitignore uses.
+func isIgnored(rules []ignoreRule, path string, isDir bool) bool {
+	ignored := false
+	for _, rule := range rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+
+		rel, err := filepath.Rel(rule.baseDir, path)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+
+		var matched bool
+		if strings.Contains(rule.pattern, "/") {
+			matched, _ = filepath.Match(rule.pattern, rel)
+		} else {
+			matched, _ = filepath.Match(rule.pattern, filepath.Base(path))
+		}
+		if matched {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
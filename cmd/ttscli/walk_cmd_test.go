@@ -0,0 +1,196 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeWalkTestCatalog(t *testing.T, dir string) string {
+	t.Helper()
+	catalogPath := filepath.Join(dir, "catalog.json")
+	catalog := `{"voices":[{"id":"en_US-test-medium","language":"en-US","gender":"female",` +
+		`"commercial_use_allowed":true,"license_name":"CC0","sample_rate":22050}]}`
+	if err := os.WriteFile(catalogPath, []byte(catalog), 0644); err != nil {
+		t.Fatalf("failed to write test catalog: %v", err)
+	}
+	return catalogPath
+}
+
+func walkResultByPath(results []walkFileResult, path string) (walkFileResult, bool) {
+	for _, r := range results {
+		if r.Path == path {
+			return r, true
+		}
+	}
+	return walkFileResult{}, false
+}
+
+func TestWalk_IncrementalSync(t *testing.T) {
+	root := t.TempDir()
+	outputRoot := t.TempDir()
+	catalogPath := writeWalkTestCatalog(t, t.TempDir())
+
+	write := func(rel, content string) {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+
+	// touch sets both the atime and mtime of path to t.
+	touch := func(path string, at time.Time) {
+		if err := os.Chtimes(path, at, at); err != nil {
+			t.Fatalf("failed to set mtime on %s: %v", path, err)
+		}
+	}
+
+	// A fake, monotonically-increasing clock: real wall-clock gaps
+	// between a test's statements are too small (and platform mtime
+	// resolution too coarse) to reliably order writes, so every
+	// meaningful timestamp below is drawn from this clock instead.
+	clock := time.Now().Add(-24 * time.Hour)
+	tick := func() time.Time {
+		clock = clock.Add(time.Hour)
+		return clock
+	}
+
+	onePath := filepath.Join(root, "one.txt")
+	twoPath := filepath.Join(root, "sub", "two.txt")
+	oneOutput := filepath.Join(outputRoot, "one.mp3")
+	twoOutput := filepath.Join(outputRoot, "sub", "two.mp3")
+
+	write("one.txt", "The first short document.")
+	touch(onePath, tick())
+	write("sub/two.txt", "The second short document, in a subdirectory.")
+	touch(twoPath, tick())
+
+	opts := walkRunOptions{
+		OutputRoot:  outputRoot,
+		Language:    "en-US",
+		CatalogPath: catalogPath,
+		Incremental: true,
+		DryRun:      true,
+	}
+
+	// First run: both files are new, both should be synthesized.
+	summary, err := runWalkSynthesis(root, opts)
+	if err != nil {
+		t.Fatalf("runWalkSynthesis() error = %v", err)
+	}
+	if summary.Synthesized != 2 || summary.Skipped != 0 {
+		t.Fatalf("first run summary = %+v, want 2 synthesized, 0 skipped", summary)
+	}
+	touch(oneOutput, tick())
+	touch(twoOutput, tick())
+
+	// Second run, nothing changed: both files should be skipped.
+	summary, err = runWalkSynthesis(root, opts)
+	if err != nil {
+		t.Fatalf("runWalkSynthesis() (second run) error = %v", err)
+	}
+	if summary.Synthesized != 0 || summary.Skipped != 2 {
+		t.Fatalf("second run summary = %+v, want 0 synthesized, 2 skipped", summary)
+	}
+
+	// Modify one.txt and bump its mtime past its existing output's.
+	write("one.txt", "The first short document, now rewritten.")
+	touch(onePath, tick())
+
+	summary, err = runWalkSynthesis(root, opts)
+	if err != nil {
+		t.Fatalf("runWalkSynthesis() (after modify) error = %v", err)
+	}
+	if summary.Synthesized != 1 || summary.Skipped != 1 {
+		t.Fatalf("modify-one summary = %+v, want exactly 1 synthesized, 1 skipped", summary)
+	}
+	one, ok := walkResultByPath(summary.Results, "one.txt")
+	if !ok || one.Status != "synthesized" {
+		t.Errorf("one.txt result = %+v, want status=synthesized", one)
+	}
+	two, ok := walkResultByPath(summary.Results, filepath.Join("sub", "two.txt"))
+	if !ok || two.Status != "skipped" {
+		t.Errorf("sub/two.txt result = %+v, want status=skipped (untouched)", two)
+	}
+	touch(oneOutput, tick())
+
+	// Remove sub/two.txt: only one.txt should remain, and re-run should
+	// skip it (nothing else to resynthesize).
+	if err := os.Remove(twoPath); err != nil {
+		t.Fatalf("failed to remove sub/two.txt: %v", err)
+	}
+
+	summary, err = runWalkSynthesis(root, opts)
+	if err != nil {
+		t.Fatalf("runWalkSynthesis() (after remove) error = %v", err)
+	}
+	if summary.Files != 1 || summary.Synthesized != 0 || summary.Skipped != 1 {
+		t.Fatalf("after-remove summary = %+v, want 1 file, 0 synthesized, 1 skipped", summary)
+	}
+
+	// Add a brand-new file: only it should synthesize.
+	write("three.txt", "A brand new document added after the fact.")
+	touch(filepath.Join(root, "three.txt"), tick())
+
+	summary, err = runWalkSynthesis(root, opts)
+	if err != nil {
+		t.Fatalf("runWalkSynthesis() (after add) error = %v", err)
+	}
+	if summary.Synthesized != 1 || summary.Skipped != 1 {
+		t.Fatalf("after-add summary = %+v, want exactly 1 synthesized, 1 skipped", summary)
+	}
+	three, ok := walkResultByPath(summary.Results, "three.txt")
+	if !ok || three.Status != "synthesized" {
+		t.Errorf("three.txt result = %+v, want status=synthesized", three)
+	}
+}
+
+func TestWalk_TTSIgnoreAndFrontMatter(t *testing.T) {
+	root := t.TempDir()
+	outputRoot := t.TempDir()
+	catalogPath := writeWalkTestCatalog(t, t.TempDir())
+
+	must := func(rel, content string) {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+
+	must(".ttsignore", "draft/\n")
+	must("keep.txt", "This file should be synthesized.")
+	must("draft/skip-me.txt", "This file lives under an ignored directory.")
+	must("skip-front-matter.txt", "---\ntts: skip\n---\nThis file opts out via front matter.")
+
+	summary, err := runWalkSynthesis(root, walkRunOptions{
+		OutputRoot:  outputRoot,
+		Language:    "en-US",
+		CatalogPath: catalogPath,
+		Incremental: true,
+		DryRun:      true,
+	})
+	if err != nil {
+		t.Fatalf("runWalkSynthesis() error = %v", err)
+	}
+
+	if summary.Synthesized != 1 {
+		t.Errorf("summary.Synthesized = %d, want 1 (only keep.txt)", summary.Synthesized)
+	}
+	keep, ok := walkResultByPath(summary.Results, "keep.txt")
+	if !ok || keep.Status != "synthesized" {
+		t.Errorf("keep.txt result = %+v, want status=synthesized", keep)
+	}
+	if skipFM, ok := walkResultByPath(summary.Results, "skip-front-matter.txt"); !ok || skipFM.Status != "ignored" {
+		t.Errorf("skip-front-matter.txt result = %+v, want status=ignored", skipFM)
+	}
+	if _, ok := walkResultByPath(summary.Results, filepath.Join("draft", "skip-me.txt")); ok {
+		t.Error("draft/skip-me.txt appeared in results, want it excluded by .ttsignore before being visited")
+	}
+}
@@ -1,306 +1,891 @@
 package agents
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"crypto/sha256"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"studiospeech/internal/agents/ssml"
+	"studiospeech/internal/fsys"
+)
+
+const (
+	defaultMaxAge     = 24 * time.Hour
+	defaultMaxBytes   = 1024 * 1024 * 1024 // 1GB
+	defaultMaxEntries = 10000
 )
 
-// CacheEntry represents a cached synthesis result
+// cacheSchemaSQL creates the entries table backing the cache index and
+// the indexes Get/Prune's lookups rely on. created_at/last_accessed_at
+// are stored as UnixNano rather than UnixSecond so LRU ordering stays
+// deterministic even for entries written within the same second.
+const cacheSchemaSQL = `
+CREATE TABLE IF NOT EXISTS entries (
+	key               TEXT PRIMARY KEY,
+	sha256            TEXT NOT NULL,
+	path              TEXT NOT NULL,
+	size              INTEGER NOT NULL,
+	created_at        INTEGER NOT NULL,
+	last_accessed_at  INTEGER NOT NULL,
+	hit_count         INTEGER NOT NULL DEFAULT 0,
+	metadata_json     TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_entries_last_accessed ON entries(last_accessed_at);
+CREATE INDEX IF NOT EXISTS idx_entries_sha256 ON entries(sha256);
+`
+
+// CacheEntry represents a cached synthesis result. Sha256 is the hash of
+// the cached file's contents (what FilePath is addressed by), which may
+// be shared by several Keys whose synthesis happened to produce
+// byte-identical audio; LastAccess and HitCount drive LRU eviction.
 type CacheEntry struct {
-	Key       string    `json:"key"`
-	FilePath  string    `json:"file_path"`
-	CreatedAt time.Time `json:"created_at"`
-	FileSize  int64     `json:"file_size"`
-	Metadata  map[string]interface{} `json:"metadata"`
+	Key        string                 `json:"key"`
+	Sha256     string                 `json:"sha256"`
+	FilePath   string                 `json:"file_path"`
+	CreatedAt  time.Time              `json:"created_at"`
+	LastAccess time.Time              `json:"last_access"`
+	FileSize   int64                  `json:"file_size"`
+	HitCount   int64                  `json:"hit_count"`
+	Metadata   map[string]interface{} `json:"metadata"`
 }
 
-// CacheIndex maintains the cache index
-type CacheIndex struct {
-	Entries map[string]*CacheEntry `json:"entries"`
-	Version string                 `json:"version"`
+// PrunePolicy overrides a CacheAgent's configured limits for a single
+// Prune call. A zero field falls back to the agent's own default (set
+// via SetMaxAge/SetMaxBytes/SetMaxEntries).
+type PrunePolicy struct {
+	MaxAge     time.Duration
+	MaxBytes   int64
+	MaxEntries int
 }
 
-// CacheAgent handles synthesis result caching
+// CacheAgent handles synthesis result caching. Cached files live in a
+// two-level sha256-sharded layout under cacheDir (cacheDir/xx/yy/<sha256>.<ext>)
+// so identical audio produced by different cache keys is stored once,
+// and so no single directory ends up with an unbounded number of
+// entries. Entry metadata (size, timestamps, hit counts) lives in a
+// SQLite database (index.db) rather than in memory, so the index itself
+// is crash-safe and safe for multiple studiospeech processes to share a
+// cache directory; db is additionally guarded by mu for in-process
+// callers.
 type CacheAgent struct {
-	cacheDir   string
-	indexPath  string
-	index      *CacheIndex
+	cacheDir string
+	dbPath   string
+	db       *sql.DB
+	mu       sync.RWMutex
+
+	// fs resolves the source file passed to Put, so a result can be
+	// cached straight from an fsys.RemoteFS URI without first staging it
+	// to disk by hand. The index itself (SQLite db, sharded content
+	// store) stays OS-native: the hardlink fast path in storeContent and
+	// the SQLite index both require a real local filesystem, and neither
+	// has a remote equivalent worth abstracting over.
+	fs fsys.FS
+
 	maxAge     time.Duration
-	maxSize    int64
+	maxBytes   int64
+	maxEntries int
+
+	hits      int64
+	misses    int64
+	evictions int64
+
+	stopEviction chan struct{}
+	evictionWG   sync.WaitGroup
 }
 
 // NewCacheAgent creates a new cache agent
 func NewCacheAgent(cacheDir string) *CacheAgent {
 	return &CacheAgent{
-		cacheDir:  cacheDir,
-		indexPath: filepath.Join(cacheDir, "index.json"),
-		maxAge:    24 * time.Hour, // 24 hours default
-		maxSize:   1024 * 1024 * 1024, // 1GB default
+		cacheDir:   cacheDir,
+		dbPath:     filepath.Join(cacheDir, "index.db"),
+		fs:         fsys.NewOSFS(),
+		maxAge:     defaultMaxAge,
+		maxBytes:   defaultMaxBytes,
+		maxEntries: defaultMaxEntries,
 	}
 }
 
-// Initialize creates cache directory and loads index
+// SetFS overrides the FS used to read Put's source file, e.g. with an
+// fsys.RemoteFS to cache a result addressed by an http(s):// or s3://
+// URI rather than a local path.
+func (c *CacheAgent) SetFS(fs fsys.FS) {
+	c.fs = fs
+}
+
+// SetMaxAge overrides the default 24-hour TTL after which an entry is
+// evicted on access or during Prune.
+func (c *CacheAgent) SetMaxAge(d time.Duration) { c.maxAge = d }
+
+// SetMaxBytes overrides the default 1GB total cache size cap enforced
+// by Put and Prune via LRU eviction.
+func (c *CacheAgent) SetMaxBytes(n int64) { c.maxBytes = n }
+
+// SetMaxEntries overrides the default 10000-entry count cap enforced by
+// Put and Prune via LRU eviction.
+func (c *CacheAgent) SetMaxEntries(n int) { c.maxEntries = n }
+
+// Initialize creates the cache directory and opens (or creates) the
+// SQLite index.
 func (c *CacheAgent) Initialize() error {
-	// Create cache directory
 	if err := os.MkdirAll(c.cacheDir, 0755); err != nil {
 		return fmt.Errorf("failed to create cache directory: %w", err)
 	}
-	
-	// Load or create index
-	if err := c.loadIndex(); err != nil {
-		return fmt.Errorf("failed to load cache index: %w", err)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.openDBLocked()
+}
+
+// openDBLocked opens c.dbPath and ensures its schema exists. WAL mode
+// lets Get (a reader) proceed without blocking on a concurrent Put from
+// another process, and the busy_timeout gives a writer a few seconds to
+// retry instead of failing immediately with SQLITE_BUSY. Callers must
+// hold mu.
+func (c *CacheAgent) openDBLocked() error {
+	db, err := sql.Open("sqlite3", c.dbPath+"?_busy_timeout=5000&_journal_mode=WAL")
+	if err != nil {
+		return fmt.Errorf("failed to open cache index: %w", err)
+	}
+	if _, err := db.Exec(cacheSchemaSQL); err != nil {
+		db.Close()
+		return fmt.Errorf("failed to initialize cache index schema: %w", err)
 	}
-	
+	c.db = db
 	return nil
 }
 
+// StartBackgroundEviction launches a goroutine that calls Prune with the
+// agent's configured limits every interval, so long-running processes
+// (an HTTP server, for instance) don't need to prune manually. Call
+// StopBackgroundEviction to stop it.
+func (c *CacheAgent) StartBackgroundEviction(interval time.Duration) {
+	c.stopEviction = make(chan struct{})
+	ticker := time.NewTicker(interval)
+
+	c.evictionWG.Add(1)
+	go func() {
+		defer c.evictionWG.Done()
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.Prune(PrunePolicy{})
+			case <-c.stopEviction:
+				return
+			}
+		}
+	}()
+}
+
+// StopBackgroundEviction stops a goroutine started by
+// StartBackgroundEviction and waits for it to exit.
+func (c *CacheAgent) StopBackgroundEviction() {
+	if c.stopEviction == nil {
+		return
+	}
+	close(c.stopEviction)
+	c.evictionWG.Wait()
+	c.stopEviction = nil
+}
+
 // GenerateKey creates a cache key from content and parameters
 func (c *CacheAgent) GenerateKey(content *TextContent, voice *Voice, synthParams *SynthParams, postParams *PostProcessParams) string {
 	hasher := sha256.New()
-	
+
 	// Hash text content
 	for _, paragraph := range content.Paragraphs {
 		hasher.Write([]byte(paragraph))
 	}
-	
+
 	// Hash voice ID
 	hasher.Write([]byte(voice.ID))
-	
+
 	// Hash synthesis parameters
 	if synthParams != nil {
-		hasher.Write([]byte(fmt.Sprintf("%.3f-%.3f-%.3f-%d", 
+		hasher.Write([]byte(fmt.Sprintf("%.3f-%.3f-%.3f-%d",
 			synthParams.Speed, synthParams.Noise, synthParams.NoiseW, synthParams.Speaker)))
 	}
-	
+
 	// Hash post-processing parameters
 	if postParams != nil {
-		hasher.Write([]byte(fmt.Sprintf("%s-%d-%d-%.1f", 
+		hasher.Write([]byte(fmt.Sprintf("%s-%d-%d-%.1f",
 			postParams.Format, postParams.SampleRate, postParams.Bitrate, postParams.LoudnessLUFS)))
 	}
-	
+
+	return fmt.Sprintf("%x", hasher.Sum(nil))
+}
+
+// GenerateSSMLKey creates a cache key for an SSML-driven synthesis,
+// hashing every segment of doc (text, break duration, and prosody/voice
+// overrides) rather than flattened paragraphs, so changing a single
+// <break> or <prosody> override anywhere in the tree invalidates the
+// cache entry.
+func (c *CacheAgent) GenerateSSMLKey(doc *ssml.Document, voice *Voice, synthParams *SynthParams, postParams *PostProcessParams) string {
+	hasher := sha256.New()
+
+	for _, seg := range doc.Segments {
+		speed := "default"
+		if seg.Speed != nil {
+			speed = fmt.Sprintf("%.3f", *seg.Speed)
+		}
+		hasher.Write([]byte(fmt.Sprintf("%s|%d|%s|%s|%s|%s\n", seg.Text, seg.Break, speed, seg.Pitch, seg.VoiceName, seg.Emphasis)))
+	}
+
+	hasher.Write([]byte(voice.ID))
+
+	if synthParams != nil {
+		hasher.Write([]byte(fmt.Sprintf("%.3f-%.3f-%.3f-%d",
+			synthParams.Speed, synthParams.Noise, synthParams.NoiseW, synthParams.Speaker)))
+	}
+
+	if postParams != nil {
+		hasher.Write([]byte(fmt.Sprintf("%s-%d-%d-%.1f",
+			postParams.Format, postParams.SampleRate, postParams.Bitrate, postParams.LoudnessLUFS)))
+	}
+
+	return fmt.Sprintf("%x", hasher.Sum(nil))
+}
+
+// GenerateSentenceKey creates a cache key for one segment of a
+// SegmentedWriter run: a sentence's SentenceID combined with voice and
+// speed, so re-running a long-form synthesis after editing a single
+// sentence only invalidates the segment(s) containing it.
+func (c *CacheAgent) GenerateSentenceKey(sentenceID, voiceID string, speed float64) string {
+	hasher := sha256.New()
+	hasher.Write([]byte(sentenceID))
+	hasher.Write([]byte(voiceID))
+	hasher.Write([]byte(fmt.Sprintf("%.3f", speed)))
 	return fmt.Sprintf("%x", hasher.Sum(nil))
 }
 
-// Get retrieves cached result if available
+// Get retrieves a cached result if available, updating its LRU recency
+// and hit count on a hit.
 func (c *CacheAgent) Get(key string) (*CacheEntry, error) {
-	if c.index == nil {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.db == nil {
 		return nil, fmt.Errorf("cache not initialized")
 	}
-	
-	entry, exists := c.index.Entries[key]
-	if !exists {
+
+	entry, err := c.lookupLocked(key)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		atomic.AddInt64(&c.misses, 1)
 		return nil, nil // Cache miss
 	}
-	
+
 	// Check if file still exists
 	if _, err := os.Stat(entry.FilePath); os.IsNotExist(err) {
 		// File missing, remove from index
-		delete(c.index.Entries, key)
-		c.saveIndex()
+		if err := c.removeLocked(key); err != nil {
+			return nil, err
+		}
+		atomic.AddInt64(&c.misses, 1)
 		return nil, nil
 	}
-	
+
 	// Check if entry is too old
-	if time.Since(entry.CreatedAt) > c.maxAge {
-		c.Remove(key)
+	if c.maxAge > 0 && time.Since(entry.CreatedAt) > c.maxAge {
+		if err := c.removeLocked(key); err != nil {
+			return nil, err
+		}
+		atomic.AddInt64(&c.misses, 1)
+		atomic.AddInt64(&c.evictions, 1)
 		return nil, nil
 	}
-	
+
+	if err := c.touchLocked(key); err != nil {
+		return nil, fmt.Errorf("failed to record cache access: %w", err)
+	}
+	entry.LastAccess = time.Now()
+	entry.HitCount++
+	atomic.AddInt64(&c.hits, 1)
+
 	return entry, nil
 }
 
-// Put stores a result in cache
-func (c *CacheAgent) Put(key, filePath string, metadata map[string]interface{}) error {
-	if c.index == nil {
+// Touch bumps an entry's last-accessed time and hit count without
+// fetching its contents, for callers that only need to keep an entry
+// warm against LRU eviction (e.g. after confirming via WalkOrphans that
+// its file is still in place).
+func (c *CacheAgent) Touch(key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.db == nil {
 		return fmt.Errorf("cache not initialized")
 	}
-	
-	// Get file info
-	fileInfo, err := os.Stat(filePath)
+	return c.touchLocked(key)
+}
+
+// touchLocked updates last_accessed_at/hit_count for key. Callers must
+// hold mu.
+func (c *CacheAgent) touchLocked(key string) error {
+	res, err := c.db.Exec(`UPDATE entries SET last_accessed_at = ?, hit_count = hit_count + 1 WHERE key = ?`,
+		time.Now().UnixNano(), key)
+	if err != nil {
+		return fmt.Errorf("failed to update cache entry: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("cache entry %q not found", key)
+	}
+	return nil
+}
+
+// lookupLocked fetches key's row, or (nil, nil) if it doesn't exist.
+// Callers must hold mu (read or write lock).
+func (c *CacheAgent) lookupLocked(key string) (*CacheEntry, error) {
+	var e CacheEntry
+	var createdAt, lastAccessed int64
+	var metaJSON sql.NullString
+
+	row := c.db.QueryRow(`SELECT key, sha256, path, size, created_at, last_accessed_at, hit_count, metadata_json
+		FROM entries WHERE key = ?`, key)
+	if err := row.Scan(&e.Key, &e.Sha256, &e.FilePath, &e.FileSize, &createdAt, &lastAccessed, &e.HitCount, &metaJSON); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query cache entry: %w", err)
+	}
+
+	e.CreatedAt = time.Unix(0, createdAt)
+	e.LastAccess = time.Unix(0, lastAccessed)
+	if metaJSON.Valid && metaJSON.String != "" && metaJSON.String != "null" {
+		if err := json.Unmarshal([]byte(metaJSON.String), &e.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to decode cache entry metadata: %w", err)
+		}
+	}
+	return &e, nil
+}
+
+// Put stores a result in the content-addressed cache under its sha256,
+// deduplicating against any existing entry whose file happens to have
+// identical contents, then enforces MaxAge/MaxBytes/MaxEntries via LRU
+// eviction.
+func (c *CacheAgent) Put(key, filePath string, metadata map[string]interface{}) error {
+	sum, err := sha256File(c.fs, filePath)
+	if err != nil {
+		return fmt.Errorf("failed to hash file for cache: %w", err)
+	}
+
+	fileInfo, err := c.fs.Stat(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to get file info: %w", err)
 	}
-	
-	// Create cache file path
-	cacheFilePath := filepath.Join(c.cacheDir, key+filepath.Ext(filePath))
-	
-	// Copy file to cache
-	if err := c.copyFile(filePath, cacheFilePath); err != nil {
-		return fmt.Errorf("failed to copy file to cache: %w", err)
-	}
-	
-	// Create cache entry
-	entry := &CacheEntry{
-		Key:       key,
-		FilePath:  cacheFilePath,
-		CreatedAt: time.Now(),
-		FileSize:  fileInfo.Size(),
-		Metadata:  metadata,
-	}
-	
-	// Add to index
-	c.index.Entries[key] = entry
-	
-	// Save index
-	if err := c.saveIndex(); err != nil {
-		return fmt.Errorf("failed to save cache index: %w", err)
-	}
-	
+
+	shardDir := filepath.Join(c.cacheDir, sum[0:2], sum[2:4])
+	cacheFilePath := filepath.Join(shardDir, sum+filepath.Ext(filePath))
+
+	metaJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry metadata: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.db == nil {
+		return fmt.Errorf("cache not initialized")
+	}
+
+	if _, err := os.Stat(cacheFilePath); os.IsNotExist(err) {
+		if err := os.MkdirAll(shardDir, 0755); err != nil {
+			return fmt.Errorf("failed to create cache shard directory: %w", err)
+		}
+		if err := storeContent(c.fs, filePath, cacheFilePath); err != nil {
+			return fmt.Errorf("failed to store file in content-addressed store: %w", err)
+		}
+	}
+
+	now := time.Now().UnixNano()
+	_, err = c.db.Exec(`INSERT INTO entries (key, sha256, path, size, created_at, last_accessed_at, hit_count, metadata_json)
+		VALUES (?, ?, ?, ?, ?, ?, 0, ?)
+		ON CONFLICT(key) DO UPDATE SET
+			sha256 = excluded.sha256,
+			path = excluded.path,
+			size = excluded.size,
+			created_at = excluded.created_at,
+			last_accessed_at = excluded.last_accessed_at,
+			hit_count = 0,
+			metadata_json = excluded.metadata_json`,
+		key, sum, cacheFilePath, fileInfo.Size(), now, now, string(metaJSON))
+	if err != nil {
+		return fmt.Errorf("failed to record cache entry: %w", err)
+	}
+
+	return c.enforceLimitsLocked(c.maxAge, c.maxBytes, c.maxEntries)
+}
+
+// storeContent places src's bytes at dst inside the content-addressed
+// store. It first tries a hardlink, which avoids a full io.Copy of
+// (potentially large) audio files whenever src and the cache directory
+// share a filesystem; if that fails (cross-device, src not on local
+// disk at all, or a filesystem that doesn't support hard links) it
+// falls back to reading src through fs and copying through a ".tmp"
+// sibling and os.Rename, so a concurrent reader never observes a
+// partially-written cache file.
+func storeContent(fs fsys.FS, src, dst string) error {
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+
+	tmp := dst + ".tmp"
+	in, err := fs.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		os.Remove(tmp)
+		return err
+	}
 	return nil
 }
 
-// Remove deletes a cache entry
+// Remove deletes a cache entry, removing its underlying file only if no
+// other entry still references the same content hash.
 func (c *CacheAgent) Remove(key string) error {
-	if c.index == nil {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.db == nil {
 		return fmt.Errorf("cache not initialized")
 	}
-	
-	entry, exists := c.index.Entries[key]
-	if !exists {
+	return c.removeLocked(key)
+}
+
+// removeLocked removes key from the index and, if no remaining entry
+// shares its Sha256, deletes its underlying file. Callers must hold mu.
+func (c *CacheAgent) removeLocked(key string) error {
+	entry, err := c.lookupLocked(key)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
 		return nil // Already removed
 	}
-	
-	// Remove file
-	if err := os.Remove(entry.FilePath); err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("failed to remove cache file: %w", err)
-	}
-	
-	// Remove from index
-	delete(c.index.Entries, key)
-	
-	// Save index
-	return c.saveIndex()
+
+	if _, err := c.db.Exec(`DELETE FROM entries WHERE key = ?`, key); err != nil {
+		return fmt.Errorf("failed to delete cache entry: %w", err)
+	}
+
+	var refCount int
+	if err := c.db.QueryRow(`SELECT COUNT(*) FROM entries WHERE sha256 = ?`, entry.Sha256).Scan(&refCount); err != nil {
+		return fmt.Errorf("failed to check remaining references: %w", err)
+	}
+	if refCount == 0 {
+		if err := os.Remove(entry.FilePath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove cache file: %w", err)
+		}
+	}
+
+	return nil
 }
 
-// Prune removes old or large cache entries
-func (c *CacheAgent) Prune() error {
-	if c.index == nil {
+// Prune removes entries violating policy (falling back to the agent's
+// configured defaults for any zero field) via real LRU eviction, ordering
+// candidates by last_accessed_at ascending until the index is back
+// within its limits.
+func (c *CacheAgent) Prune(policy PrunePolicy) error {
+	maxAge := c.maxAge
+	if policy.MaxAge > 0 {
+		maxAge = policy.MaxAge
+	}
+	maxBytes := c.maxBytes
+	if policy.MaxBytes > 0 {
+		maxBytes = policy.MaxBytes
+	}
+	maxEntries := c.maxEntries
+	if policy.MaxEntries > 0 {
+		maxEntries = policy.MaxEntries
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.db == nil {
 		return fmt.Errorf("cache not initialized")
 	}
-	
-	var totalSize int64
-	var toRemove []string
-	
-	// Calculate total size and find old entries
-	for key, entry := range c.index.Entries {
-		totalSize += entry.FileSize
-		
-		// Mark old entries for removal
-		if time.Since(entry.CreatedAt) > c.maxAge {
-			toRemove = append(toRemove, key)
-		}
-	}
-	
-	// Remove old entries
-	for _, key := range toRemove {
-		c.Remove(key)
-		totalSize -= c.index.Entries[key].FileSize
-	}
-	
-	// If still over size limit, remove oldest entries
-	if totalSize > c.maxSize {
-		// Sort by creation time and remove oldest
-		// Simplified: just remove entries until under limit
-		for key, entry := range c.index.Entries {
-			if totalSize <= c.maxSize {
+	return c.enforceLimitsLocked(maxAge, maxBytes, maxEntries)
+}
+
+// enforceLimitsLocked evicts entries older than maxAge, then evicts the
+// least-recently-used entries (ordered by last_accessed_at ascending)
+// until the index is within maxEntries and maxBytes. A zero/negative
+// limit disables that check. Callers must hold mu.
+func (c *CacheAgent) enforceLimitsLocked(maxAge time.Duration, maxBytes int64, maxEntries int) error {
+	if maxAge > 0 {
+		cutoff := time.Now().Add(-maxAge).UnixNano()
+		rows, err := c.db.Query(`SELECT key FROM entries WHERE created_at < ?`, cutoff)
+		if err != nil {
+			return fmt.Errorf("failed to query expired cache entries: %w", err)
+		}
+		var expired []string
+		for rows.Next() {
+			var key string
+			if err := rows.Scan(&key); err != nil {
+				rows.Close()
+				return err
+			}
+			expired = append(expired, key)
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return err
+		}
+		rows.Close()
+
+		for _, key := range expired {
+			if err := c.removeLocked(key); err != nil {
+				return err
+			}
+			atomic.AddInt64(&c.evictions, 1)
+		}
+	}
+
+	for maxEntries > 0 {
+		count, err := c.countLocked()
+		if err != nil {
+			return err
+		}
+		if count <= maxEntries {
+			break
+		}
+		key, err := c.lruKeyLocked()
+		if err != nil {
+			return err
+		}
+		if key == "" {
+			break
+		}
+		if err := c.removeLocked(key); err != nil {
+			return err
+		}
+		atomic.AddInt64(&c.evictions, 1)
+	}
+
+	if maxBytes > 0 {
+		for {
+			total, err := c.totalSizeLocked()
+			if err != nil {
+				return err
+			}
+			if total <= maxBytes {
+				break
+			}
+			key, err := c.lruKeyLocked()
+			if err != nil {
+				return err
+			}
+			if key == "" {
 				break
 			}
-			c.Remove(key)
-			totalSize -= entry.FileSize
+			if err := c.removeLocked(key); err != nil {
+				return err
+			}
+			atomic.AddInt64(&c.evictions, 1)
 		}
 	}
-	
+
 	return nil
 }
 
-// Stats returns cache statistics
+// lruKeyLocked returns the key of the least-recently-accessed entry, or
+// "" if the index is empty. Callers must hold mu.
+func (c *CacheAgent) lruKeyLocked() (string, error) {
+	var key string
+	err := c.db.QueryRow(`SELECT key FROM entries ORDER BY last_accessed_at ASC LIMIT 1`).Scan(&key)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to query least-recently-used cache entry: %w", err)
+	}
+	return key, nil
+}
+
+// countLocked returns the number of entries in the index. Callers must
+// hold mu (read or write lock).
+func (c *CacheAgent) countLocked() (int, error) {
+	var count int
+	if err := c.db.QueryRow(`SELECT COUNT(*) FROM entries`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count cache entries: %w", err)
+	}
+	return count, nil
+}
+
+// totalSizeLocked returns the sum of size across all entries. Callers
+// must hold mu (read or write lock).
+func (c *CacheAgent) totalSizeLocked() (int64, error) {
+	var total int64
+	if err := c.db.QueryRow(`SELECT COALESCE(SUM(size), 0) FROM entries`).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to sum cache entry sizes: %w", err)
+	}
+	return total, nil
+}
+
+// Stats returns cache statistics, including cumulative hit/miss/eviction
+// counters for observability.
 func (c *CacheAgent) Stats() map[string]interface{} {
-	if c.index == nil {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.db == nil {
 		return map[string]interface{}{"error": "cache not initialized"}
 	}
-	
-	var totalSize int64
-	entryCount := len(c.index.Entries)
-	
-	for _, entry := range c.index.Entries {
-		totalSize += entry.FileSize
+
+	count, err := c.countLocked()
+	if err != nil {
+		count = 0
 	}
-	
+	total, err := c.totalSizeLocked()
+	if err != nil {
+		total = 0
+	}
+
 	return map[string]interface{}{
-		"entries":    entryCount,
-		"total_size": totalSize,
+		"entries":    count,
+		"total_size": total,
 		"cache_dir":  c.cacheDir,
+		"hits":       atomic.LoadInt64(&c.hits),
+		"misses":     atomic.LoadInt64(&c.misses),
+		"evictions":  atomic.LoadInt64(&c.evictions),
 	}
 }
 
-// loadIndex loads the cache index from disk
-func (c *CacheAgent) loadIndex() error {
-	// Initialize empty index if file doesn't exist
-	if _, err := os.Stat(c.indexPath); os.IsNotExist(err) {
-		c.index = &CacheIndex{
-			Entries: make(map[string]*CacheEntry),
-			Version: "1.0",
-		}
-		return c.saveIndex()
+// WalkOrphans reconciles the on-disk sharded content store against the
+// index, returning every file under cacheDir that no index entry's path
+// points at. A cache directory accumulates orphans when a process dies
+// between writing content and recording its index row, or when a cache
+// directory is restored from a backup taken mid-write; WalkOrphans never
+// deletes anything itself, leaving that decision to the caller.
+func (c *CacheAgent) WalkOrphans() ([]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.db == nil {
+		return nil, fmt.Errorf("cache not initialized")
 	}
-	
-	// Load existing index
-	file, err := os.Open(c.indexPath)
+
+	referenced := make(map[string]bool)
+	rows, err := c.db.Query(`SELECT path FROM entries`)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to query cache entries: %w", err)
 	}
-	defer file.Close()
-	
-	c.index = &CacheIndex{}
-	if err := json.NewDecoder(file).Decode(c.index); err != nil {
-		// If index is corrupted, start fresh
-		c.index = &CacheIndex{
-			Entries: make(map[string]*CacheEntry),
-			Version: "1.0",
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			rows.Close()
+			return nil, err
 		}
-		return c.saveIndex()
+		referenced[path] = true
 	}
-	
-	// Ensure entries map is initialized
-	if c.index.Entries == nil {
-		c.index.Entries = make(map[string]*CacheEntry)
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, err
+	}
+	rows.Close()
+
+	dbFilePrefix := filepath.Base(c.dbPath)
+
+	var orphans []string
+	err = filepath.Walk(c.cacheDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if strings.HasPrefix(filepath.Base(path), dbFilePrefix) {
+			return nil // index.db itself, or its -wal/-shm/-journal siblings
+		}
+		if !referenced[path] {
+			orphans = append(orphans, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk cache directory: %w", err)
+	}
+	return orphans, nil
+}
+
+// Compact reclaims space freed by deleted rows by running SQLite's
+// VACUUM. It's not needed for correctness - Prune's deletes are visible
+// immediately - just for bounding index.db's on-disk size over the life
+// of a long-running cache directory.
+func (c *CacheAgent) Compact() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.db == nil {
+		return fmt.Errorf("cache not initialized")
+	}
+	if _, err := c.db.Exec(`VACUUM`); err != nil {
+		return fmt.Errorf("failed to vacuum cache index: %w", err)
 	}
-	
 	return nil
 }
 
-// saveIndex saves the cache index to disk
-func (c *CacheAgent) saveIndex() error {
-	file, err := os.Create(c.indexPath)
+// Export bundles the cache directory's SQLite index and all shard files
+// into a gzip-compressed tarball at tarPath, so a warm cache can be
+// shared between CI runners instead of rebuilt from scratch.
+func (c *CacheAgent) Export(tarPath string) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.db != nil {
+		if _, err := c.db.Exec(`PRAGMA wal_checkpoint(FULL)`); err != nil {
+			return fmt.Errorf("failed to checkpoint cache index before export: %w", err)
+		}
+	}
+
+	out, err := os.Create(tarPath)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to create export archive: %w", err)
 	}
-	defer file.Close()
-	
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(c.index)
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	defer gz.Close()
+	tw := tar.NewWriter(gz)
+	defer tw.Close()
+
+	return filepath.Walk(c.cacheDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(c.cacheDir, path)
+		if err != nil {
+			return err
+		}
+
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = rel
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(tw, f)
+		return err
+	})
 }
 
-// copyFile copies a file from src to dst
-func (c *CacheAgent) copyFile(src, dst string) error {
-	srcFile, err := os.Open(src)
+// Import extracts a tarball written by Export into cacheDir, then
+// reopens the index so the imported entries become visible to this
+// CacheAgent.
+func (c *CacheAgent) Import(tarPath string) error {
+	in, err := os.Open(tarPath)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to open import archive: %w", err)
 	}
-	defer srcFile.Close()
-	
-	dstFile, err := os.Create(dst)
+	defer in.Close()
+
+	gz, err := gzip.NewReader(in)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to read import archive: %w", err)
 	}
-	defer dstFile.Close()
-	
-	_, err = io.Copy(dstFile, srcFile)
-	return err
-}
\ No newline at end of file
+	defer gz.Close()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.db != nil {
+		c.db.Close()
+		c.db = nil
+	}
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read import archive entry: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		rel := filepath.Clean(hdr.Name)
+		if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || filepath.IsAbs(rel) {
+			return fmt.Errorf("import archive entry %q escapes the cache directory", hdr.Name)
+		}
+
+		dest := filepath.Join(c.cacheDir, rel)
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return fmt.Errorf("failed to create cache directory: %w", err)
+		}
+
+		f, err := os.Create(dest)
+		if err != nil {
+			return fmt.Errorf("failed to create cache file: %w", err)
+		}
+		if _, err := io.Copy(f, tr); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to write cache file: %w", err)
+		}
+		f.Close()
+	}
+
+	return c.openDBLocked()
+}
+
+// sha256File hashes a file's contents for content addressing.
+func sha256File(fs fsys.FS, path string) (string, error) {
+	f, err := fs.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
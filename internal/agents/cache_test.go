@@ -1,9 +1,15 @@
 package agents
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"studiospeech/internal/agents/ssml"
+	"studiospeech/internal/fsys"
 )
 
 func TestCacheAgent_GenerateKey(t *testing.T) {
@@ -12,117 +18,211 @@ func TestCacheAgent_GenerateKey(t *testing.T) {
 		t.Fatalf("Failed to create temp dir: %v", err)
 	}
 	defer os.RemoveAll(tempDir)
-	
+
 	agent := NewCacheAgent(tempDir)
-	
+
 	content := &TextContent{
 		Paragraphs: []string{"Hello world", "Test content"},
 		Language:   "en-US",
 	}
-	
+
 	voice := &Voice{
 		ID: "test_voice",
 	}
-	
+
 	synthParams := &SynthParams{
 		Speed:  1.0,
 		Noise:  0.5,
 		NoiseW: 0.8,
 	}
-	
+
 	postParams := &PostProcessParams{
 		Format:     FormatMP3,
 		SampleRate: 48000,
 		Bitrate:    192,
 	}
-	
+
 	key1 := agent.GenerateKey(content, voice, synthParams, postParams)
 	key2 := agent.GenerateKey(content, voice, synthParams, postParams)
-	
+
 	// Same inputs should generate same key
 	if key1 != key2 {
 		t.Error("Same inputs should generate same cache key")
 	}
-	
+
 	// Different content should generate different key
 	content2 := &TextContent{
 		Paragraphs: []string{"Different content"},
 		Language:   "en-US",
 	}
-	
+
 	key3 := agent.GenerateKey(content2, voice, synthParams, postParams)
 	if key1 == key3 {
 		t.Error("Different content should generate different cache key")
 	}
 }
 
+func TestCacheAgent_GenerateSSMLKey(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "cache_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent := NewCacheAgent(tempDir)
+	voice := &Voice{ID: "test_voice"}
+	synthParams := &SynthParams{Speed: 1.0, Noise: 0.5, NoiseW: 0.8}
+	postParams := &PostProcessParams{Format: FormatMP3, SampleRate: 48000, Bitrate: 192}
+
+	doc := &ssml.Document{
+		Segments: []ssml.Segment{
+			{Text: "Hello world"},
+			{Break: 500 * time.Millisecond},
+			{Text: "Goodbye"},
+		},
+	}
+
+	key1 := agent.GenerateSSMLKey(doc, voice, synthParams, postParams)
+	key2 := agent.GenerateSSMLKey(doc, voice, synthParams, postParams)
+	if key1 != key2 {
+		t.Error("Same SSML document should generate same cache key")
+	}
+
+	// Changing just the break duration should change the key
+	docDifferentBreak := &ssml.Document{
+		Segments: []ssml.Segment{
+			{Text: "Hello world"},
+			{Break: 200 * time.Millisecond},
+			{Text: "Goodbye"},
+		},
+	}
+	key3 := agent.GenerateSSMLKey(docDifferentBreak, voice, synthParams, postParams)
+	if key1 == key3 {
+		t.Error("Different break duration should generate different cache key")
+	}
+
+	// Changing just a prosody override should change the key
+	speed := 0.8
+	docWithProsody := &ssml.Document{
+		Segments: []ssml.Segment{
+			{Text: "Hello world", Speed: &speed},
+			{Break: 500 * time.Millisecond},
+			{Text: "Goodbye"},
+		},
+	}
+	key4 := agent.GenerateSSMLKey(docWithProsody, voice, synthParams, postParams)
+	if key1 == key4 {
+		t.Error("Different prosody override should generate different cache key")
+	}
+}
+
 func TestCacheAgent_PutAndGet(t *testing.T) {
 	tempDir, err := os.MkdirTemp("", "cache_test")
 	if err != nil {
 		t.Fatalf("Failed to create temp dir: %v", err)
 	}
 	defer os.RemoveAll(tempDir)
-	
+
 	agent := NewCacheAgent(tempDir)
 	if err := agent.Initialize(); err != nil {
 		t.Fatalf("Failed to initialize cache: %v", err)
 	}
-	
+
 	// Create a test file
 	testFile := filepath.Join(tempDir, "test.mp3")
 	if err := os.WriteFile(testFile, []byte("test audio data"), 0644); err != nil {
 		t.Fatalf("Failed to create test file: %v", err)
 	}
-	
+
 	key := "test_key_123"
 	metadata := map[string]interface{}{
-		"voice": "test_voice",
+		"voice":  "test_voice",
 		"format": "mp3",
 	}
-	
+
 	// Put file in cache
 	if err := agent.Put(key, testFile, metadata); err != nil {
 		t.Fatalf("Failed to put file in cache: %v", err)
 	}
-	
+
 	// Get file from cache
 	entry, err := agent.Get(key)
 	if err != nil {
 		t.Fatalf("Failed to get file from cache: %v", err)
 	}
-	
+
 	if entry == nil {
 		t.Fatal("Cache entry should not be nil")
 	}
-	
+
 	if entry.Key != key {
 		t.Errorf("Expected key %s, got %s", key, entry.Key)
 	}
-	
+
 	// Check cached file exists
 	if _, err := os.Stat(entry.FilePath); os.IsNotExist(err) {
 		t.Error("Cached file should exist")
 	}
 }
 
+func TestCacheAgent_Put_FromMemFS(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "cache_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent := NewCacheAgent(tempDir)
+	if err := agent.Initialize(); err != nil {
+		t.Fatalf("Failed to initialize cache: %v", err)
+	}
+
+	mem := fsys.NewMemFS()
+	if err := mem.WriteFile("/result.mp3", []byte("test audio data"), 0644); err != nil {
+		t.Fatalf("failed to seed in-memory source file: %v", err)
+	}
+	agent.SetFS(mem)
+
+	key := "mem_source_key"
+	if err := agent.Put(key, "/result.mp3", nil); err != nil {
+		t.Fatalf("Failed to put in-memory-sourced file in cache: %v", err)
+	}
+
+	entry, err := agent.Get(key)
+	if err != nil {
+		t.Fatalf("Failed to get file from cache: %v", err)
+	}
+	if entry == nil {
+		t.Fatal("Cache entry should not be nil")
+	}
+
+	data, err := os.ReadFile(entry.FilePath)
+	if err != nil {
+		t.Fatalf("failed to read cached file: %v", err)
+	}
+	if string(data) != "test audio data" {
+		t.Errorf("cached content = %q, want %q", data, "test audio data")
+	}
+}
+
 func TestCacheAgent_CacheMiss(t *testing.T) {
 	tempDir, err := os.MkdirTemp("", "cache_test")
 	if err != nil {
 		t.Fatalf("Failed to create temp dir: %v", err)
 	}
 	defer os.RemoveAll(tempDir)
-	
+
 	agent := NewCacheAgent(tempDir)
 	if err := agent.Initialize(); err != nil {
 		t.Fatalf("Failed to initialize cache: %v", err)
 	}
-	
+
 	// Try to get non-existent key
 	entry, err := agent.Get("non_existent_key")
 	if err != nil {
 		t.Fatalf("Get should not return error for cache miss: %v", err)
 	}
-	
+
 	if entry != nil {
 		t.Error("Cache miss should return nil entry")
 	}
@@ -134,19 +234,315 @@ func TestCacheAgent_Stats(t *testing.T) {
 		t.Fatalf("Failed to create temp dir: %v", err)
 	}
 	defer os.RemoveAll(tempDir)
-	
+
 	agent := NewCacheAgent(tempDir)
 	if err := agent.Initialize(); err != nil {
 		t.Fatalf("Failed to initialize cache: %v", err)
 	}
-	
+
 	stats := agent.Stats()
-	
+
 	if entries, ok := stats["entries"].(int); !ok || entries != 0 {
 		t.Errorf("Expected 0 entries, got %v", stats["entries"])
 	}
-	
+
 	if totalSize, ok := stats["total_size"].(int64); !ok || totalSize != 0 {
 		t.Errorf("Expected 0 total size, got %v", stats["total_size"])
 	}
-}
\ No newline at end of file
+
+	if hits, ok := stats["hits"].(int64); !ok || hits != 0 {
+		t.Errorf("Expected 0 hits, got %v", stats["hits"])
+	}
+}
+
+func TestCacheAgent_ContentDedup(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "cache_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent := NewCacheAgent(tempDir)
+	if err := agent.Initialize(); err != nil {
+		t.Fatalf("Failed to initialize cache: %v", err)
+	}
+
+	fileA := filepath.Join(tempDir, "a.mp3")
+	fileB := filepath.Join(tempDir, "b.mp3")
+	if err := os.WriteFile(fileA, []byte("identical audio bytes"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	if err := os.WriteFile(fileB, []byte("identical audio bytes"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	if err := agent.Put("key_a", fileA, nil); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if err := agent.Put("key_b", fileB, nil); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	entryA, _ := agent.Get("key_a")
+	entryB, _ := agent.Get("key_b")
+	if entryA == nil || entryB == nil {
+		t.Fatal("expected both entries to be retrievable")
+	}
+	if entryA.FilePath != entryB.FilePath {
+		t.Errorf("expected byte-identical content to share a stored file, got %q and %q", entryA.FilePath, entryB.FilePath)
+	}
+
+	// Removing one key should not delete the shared file out from under
+	// the other key.
+	if err := agent.Remove("key_a"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, err := os.Stat(entryB.FilePath); err != nil {
+		t.Errorf("shared cache file should still exist after removing one referencing key: %v", err)
+	}
+}
+
+func TestCacheAgent_LRUEviction(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "cache_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent := NewCacheAgent(tempDir)
+	agent.SetMaxEntries(2)
+	if err := agent.Initialize(); err != nil {
+		t.Fatalf("Failed to initialize cache: %v", err)
+	}
+
+	for _, name := range []string{"one", "two", "three"} {
+		path := filepath.Join(tempDir, name+".mp3")
+		if err := os.WriteFile(path, []byte("audio-"+name), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+		if err := agent.Put(name, path, nil); err != nil {
+			t.Fatalf("Put(%s) failed: %v", name, err)
+		}
+		// Space out CreatedAt/LastAccess so LRU ordering is deterministic.
+		time.Sleep(time.Millisecond)
+	}
+
+	if entry, _ := agent.Get("one"); entry != nil {
+		t.Error("expected the oldest entry to have been evicted once MaxEntries was exceeded")
+	}
+	if entry, _ := agent.Get("three"); entry == nil {
+		t.Error("expected the most recent entry to still be cached")
+	}
+
+	stats := agent.Stats()
+	if evictions, ok := stats["evictions"].(int64); !ok || evictions == 0 {
+		t.Errorf("expected at least one eviction to be recorded, got %v", stats["evictions"])
+	}
+}
+
+func TestCacheAgent_Touch(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "cache_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent := NewCacheAgent(tempDir)
+	if err := agent.Initialize(); err != nil {
+		t.Fatalf("Failed to initialize cache: %v", err)
+	}
+
+	testFile := filepath.Join(tempDir, "test.mp3")
+	if err := os.WriteFile(testFile, []byte("test audio data"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	if err := agent.Put("test_key", testFile, nil); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	before, err := agent.Get("test_key")
+	if err != nil || before == nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	time.Sleep(time.Millisecond)
+	if err := agent.Touch("test_key"); err != nil {
+		t.Fatalf("Touch failed: %v", err)
+	}
+
+	after, err := agent.Get("test_key")
+	if err != nil || after == nil {
+		t.Fatalf("Get after touch failed: %v", err)
+	}
+	if !after.LastAccess.After(before.LastAccess) {
+		t.Errorf("expected Touch to advance LastAccess, before=%v after=%v", before.LastAccess, after.LastAccess)
+	}
+
+	if err := agent.Touch("missing_key"); err == nil {
+		t.Error("expected Touch on a missing key to return an error")
+	}
+}
+
+func TestCacheAgent_WalkOrphans(t *testing.T) {
+	srcDir, err := os.MkdirTemp("", "cache_test_src")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(srcDir)
+
+	tempDir, err := os.MkdirTemp("", "cache_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent := NewCacheAgent(tempDir)
+	if err := agent.Initialize(); err != nil {
+		t.Fatalf("Failed to initialize cache: %v", err)
+	}
+
+	// Kept outside tempDir (the cache directory) so it isn't itself
+	// picked up as an orphan by the Walk below.
+	testFile := filepath.Join(srcDir, "test.mp3")
+	if err := os.WriteFile(testFile, []byte("test audio data"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	if err := agent.Put("test_key", testFile, nil); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	if orphans, err := agent.WalkOrphans(); err != nil || len(orphans) != 0 {
+		t.Fatalf("expected no orphans right after Put, got %v (err %v)", orphans, err)
+	}
+
+	// Drop a stray file into the shard layout, bypassing Put, to simulate
+	// a crash between writing content and recording its index row.
+	orphanDir := filepath.Join(tempDir, "ab", "cd")
+	if err := os.MkdirAll(orphanDir, 0755); err != nil {
+		t.Fatalf("Failed to create orphan dir: %v", err)
+	}
+	orphanPath := filepath.Join(orphanDir, "orphaned.mp3")
+	if err := os.WriteFile(orphanPath, []byte("nobody references me"), 0644); err != nil {
+		t.Fatalf("Failed to create orphan file: %v", err)
+	}
+
+	orphans, err := agent.WalkOrphans()
+	if err != nil {
+		t.Fatalf("WalkOrphans failed: %v", err)
+	}
+	if len(orphans) != 1 || orphans[0] != orphanPath {
+		t.Errorf("expected exactly the orphaned file to be reported, got %v", orphans)
+	}
+}
+
+func TestCacheAgent_ExportImport(t *testing.T) {
+	srcDir, err := os.MkdirTemp("", "cache_test_src")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(srcDir)
+
+	src := NewCacheAgent(srcDir)
+	if err := src.Initialize(); err != nil {
+		t.Fatalf("Failed to initialize cache: %v", err)
+	}
+
+	testFile := filepath.Join(srcDir, "test.mp3")
+	if err := os.WriteFile(testFile, []byte("exported audio"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	if err := src.Put("exported_key", testFile, nil); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	archivePath := filepath.Join(os.TempDir(), "cache_export_test.tar.gz")
+	defer os.Remove(archivePath)
+	if err := src.Export(archivePath); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	dstDir, err := os.MkdirTemp("", "cache_test_dst")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dstDir)
+
+	dst := NewCacheAgent(dstDir)
+	if err := dst.Initialize(); err != nil {
+		t.Fatalf("Failed to initialize cache: %v", err)
+	}
+	if err := dst.Import(archivePath); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	entry, err := dst.Get("exported_key")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if entry == nil {
+		t.Fatal("expected imported entry to be retrievable")
+	}
+	data, err := os.ReadFile(entry.FilePath)
+	if err != nil || string(data) != "exported audio" {
+		t.Errorf("expected imported file contents to survive the round-trip, got %q, err %v", data, err)
+	}
+}
+
+// TestCacheAgent_Import_RejectsPathTraversal guards against a tar entry
+// whose recorded name escapes cacheDir (e.g. via "../") being imported
+// straight onto the filesystem, since Export archives are meant to be
+// shared across machines and so may cross a trust boundary.
+func TestCacheAgent_Import_RejectsPathTraversal(t *testing.T) {
+	outsideDir, err := os.MkdirTemp("", "cache_test_outside")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(outsideDir)
+	escapePath := filepath.Join(outsideDir, "escaped.txt")
+
+	dstDir, err := os.MkdirTemp("", "cache_test_dst")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dstDir)
+
+	rel, err := filepath.Rel(dstDir, escapePath)
+	if err != nil {
+		t.Fatalf("filepath.Rel failed: %v", err)
+	}
+
+	archivePath := filepath.Join(os.TempDir(), "cache_traversal_test.tar.gz")
+	defer os.Remove(archivePath)
+
+	func() {
+		out, err := os.Create(archivePath)
+		if err != nil {
+			t.Fatalf("Failed to create test archive: %v", err)
+		}
+		defer out.Close()
+		gz := gzip.NewWriter(out)
+		defer gz.Close()
+		tw := tar.NewWriter(gz)
+		defer tw.Close()
+
+		body := []byte("should not escape the cache dir")
+		if err := tw.WriteHeader(&tar.Header{Name: rel, Size: int64(len(body)), Mode: 0644}); err != nil {
+			t.Fatalf("Failed to write tar header: %v", err)
+		}
+		if _, err := tw.Write(body); err != nil {
+			t.Fatalf("Failed to write tar body: %v", err)
+		}
+	}()
+
+	dst := NewCacheAgent(dstDir)
+	if err := dst.Initialize(); err != nil {
+		t.Fatalf("Failed to initialize cache: %v", err)
+	}
+
+	if err := dst.Import(archivePath); err == nil {
+		t.Fatal("expected Import to reject a path-traversing archive entry, got nil error")
+	}
+	if _, err := os.Stat(escapePath); !os.IsNotExist(err) {
+		t.Errorf("expected %s to not exist, stat err = %v", escapePath, err)
+	}
+}
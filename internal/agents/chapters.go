@@ -0,0 +1,148 @@
+package agents
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Chapter is one chapter-length unit of a TextContent, produced by
+// TextContent.SplitPlan for chapter-aware batch synthesis.
+type Chapter struct {
+	Title      string
+	Paragraphs []string
+}
+
+// wordsPerMinute estimates spoken pace at the default synthesis speed,
+// used by the "size:<minutes>" split mode to decide how many paragraphs
+// make up one chapter-sized chunk.
+const wordsPerMinute = 150
+
+// markdownHeadingPrefix marks a paragraph as a chapter heading in plain
+// text input, mirroring Markdown's "# Heading" syntax.
+const markdownHeadingPrefix = "# "
+
+// SplitPlan partitions content into the chapters requested by a --split
+// mode: "chapters" (the default, using heading metadata or Markdown "# "
+// lines), "paragraphs" (one chapter per paragraph), or "size:<minutes>"
+// (group consecutive paragraphs until roughly that many minutes of
+// speech have accumulated).
+func (t *TextContent) SplitPlan(mode string) ([]Chapter, error) {
+	switch {
+	case mode == "" || mode == "chapters":
+		return t.detectChapters()
+	case mode == "paragraphs":
+		return splitByParagraph(t.Paragraphs), nil
+	case strings.HasPrefix(mode, "size:"):
+		minutes, err := strconv.Atoi(strings.TrimPrefix(mode, "size:"))
+		if err != nil || minutes <= 0 {
+			return nil, fmt.Errorf("invalid --split size %q: expected size:<minutes> with a positive integer", mode)
+		}
+		return splitBySize(t.Paragraphs, minutes), nil
+	default:
+		return nil, fmt.Errorf("unsupported --split mode %q (expected chapters, paragraphs, or size:<minutes>)", mode)
+	}
+}
+
+// detectChapters groups Paragraphs using whichever heading signal is
+// available: ingestion-time Chapters marks (docx heading styles, PDF
+// outline), or Markdown-style "# " lines. With no heading signal at all,
+// the whole document is a single chapter.
+func (t *TextContent) detectChapters() ([]Chapter, error) {
+	if len(t.Chapters) > 0 {
+		return chaptersFromMarks(t.Paragraphs, t.Chapters)
+	}
+
+	if marks := markdownHeadingMarks(t.Paragraphs); len(marks) > 0 {
+		return chaptersFromMarks(t.Paragraphs, marks)
+	}
+
+	return []Chapter{{Paragraphs: t.Paragraphs}}, nil
+}
+
+// markdownHeadingMarks finds paragraphs that are exactly a "# Heading"
+// line, treating each as a chapter boundary.
+func markdownHeadingMarks(paragraphs []string) []ChapterMark {
+	var marks []ChapterMark
+	for i, p := range paragraphs {
+		if strings.HasPrefix(p, markdownHeadingPrefix) {
+			marks = append(marks, ChapterMark{
+				ParagraphIndex: i,
+				Title:          strings.TrimSpace(strings.TrimPrefix(p, markdownHeadingPrefix)),
+			})
+		}
+	}
+	return marks
+}
+
+// chaptersFromMarks slices paragraphs at each mark's ParagraphIndex.
+// Marks must be in ascending ParagraphIndex order, which is how both
+// docx/pdf ingestion and markdownHeadingMarks produce them; it returns an
+// error rather than panicking if that precondition is violated (e.g. a
+// PDF outline title matched out of order against the paragraph list).
+// Any text before the first mark becomes an untitled leading chapter.
+func chaptersFromMarks(paragraphs []string, marks []ChapterMark) ([]Chapter, error) {
+	for i, mark := range marks {
+		if mark.ParagraphIndex < 0 || mark.ParagraphIndex >= len(paragraphs) {
+			return nil, fmt.Errorf("chapter mark %d (%q) has out-of-range paragraph index %d for %d paragraphs", i, mark.Title, mark.ParagraphIndex, len(paragraphs))
+		}
+		if i > 0 && mark.ParagraphIndex <= marks[i-1].ParagraphIndex {
+			return nil, fmt.Errorf("chapter marks are not in ascending order: mark %d (%q, index %d) does not follow mark %d (%q, index %d)",
+				i, mark.Title, mark.ParagraphIndex, i-1, marks[i-1].Title, marks[i-1].ParagraphIndex)
+		}
+	}
+
+	var chapters []Chapter
+
+	if marks[0].ParagraphIndex > 0 {
+		chapters = append(chapters, Chapter{Paragraphs: paragraphs[:marks[0].ParagraphIndex]})
+	}
+
+	for i, mark := range marks {
+		end := len(paragraphs)
+		if i+1 < len(marks) {
+			end = marks[i+1].ParagraphIndex
+		}
+		// The heading paragraph itself leads its chapter's text.
+		chapters = append(chapters, Chapter{Title: mark.Title, Paragraphs: paragraphs[mark.ParagraphIndex:end]})
+	}
+
+	return chapters, nil
+}
+
+// splitByParagraph turns every paragraph into its own chapter.
+func splitByParagraph(paragraphs []string) []Chapter {
+	chapters := make([]Chapter, len(paragraphs))
+	for i, p := range paragraphs {
+		chapters[i] = Chapter{Paragraphs: []string{p}}
+	}
+	return chapters
+}
+
+// splitBySize groups consecutive paragraphs until the accumulated word
+// count would take roughly minutes of speech at wordsPerMinute, then
+// starts a new chapter.
+func splitBySize(paragraphs []string, minutes int) []Chapter {
+	targetWords := minutes * wordsPerMinute
+
+	var chapters []Chapter
+	var current []string
+	wordCount := 0
+
+	for _, p := range paragraphs {
+		current = append(current, p)
+		wordCount += len(strings.Fields(p))
+
+		if wordCount >= targetWords {
+			chapters = append(chapters, Chapter{Paragraphs: current})
+			current = nil
+			wordCount = 0
+		}
+	}
+
+	if len(current) > 0 {
+		chapters = append(chapters, Chapter{Paragraphs: current})
+	}
+
+	return chapters
+}
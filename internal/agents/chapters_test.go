@@ -0,0 +1,43 @@
+package agents
+
+import "testing"
+
+func TestChaptersFromMarks_OutOfOrderReturnsError(t *testing.T) {
+	paragraphs := []string{"Intro mentions Chapter Two early.", "Chapter One text.", "Chapter Two text."}
+	marks := []ChapterMark{
+		{ParagraphIndex: 2, Title: "Chapter Two"},
+		{ParagraphIndex: 1, Title: "Chapter One"},
+	}
+
+	if _, err := chaptersFromMarks(paragraphs, marks); err == nil {
+		t.Fatal("expected an error for out-of-order marks, got nil")
+	}
+}
+
+func TestChaptersFromMarks_OutOfRangeReturnsError(t *testing.T) {
+	paragraphs := []string{"Only one paragraph."}
+	marks := []ChapterMark{{ParagraphIndex: 5, Title: "Nowhere"}}
+
+	if _, err := chaptersFromMarks(paragraphs, marks); err == nil {
+		t.Fatal("expected an error for an out-of-range mark, got nil")
+	}
+}
+
+func TestChaptersFromMarks_AscendingMarksSucceed(t *testing.T) {
+	paragraphs := []string{"Lead-in.", "Chapter One text.", "Chapter Two text."}
+	marks := []ChapterMark{
+		{ParagraphIndex: 1, Title: "Chapter One"},
+		{ParagraphIndex: 2, Title: "Chapter Two"},
+	}
+
+	chapters, err := chaptersFromMarks(paragraphs, marks)
+	if err != nil {
+		t.Fatalf("chaptersFromMarks() error = %v", err)
+	}
+	if len(chapters) != 3 {
+		t.Fatalf("got %d chapters, want 3 (leading + 2 marked)", len(chapters))
+	}
+	if chapters[0].Title != "" || chapters[1].Title != "Chapter One" || chapters[2].Title != "Chapter Two" {
+		t.Errorf("chapter titles = %q, %q, %q", chapters[0].Title, chapters[1].Title, chapters[2].Title)
+	}
+}
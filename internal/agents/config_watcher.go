@@ -0,0 +1,117 @@
+package agents
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// ConfigWatcher watches one or more config files for changes and invokes
+// a per-file reload callback when one changes, so a VoiceCatalogAgent or
+// a NormalizeAgent's per-language dictionary can be refreshed in a
+// running server without restarting it. Each reload callback owns its
+// own validation: ConfigWatcher only decides when to call it, never
+// swaps anything in itself, so a malformed edit on disk simply makes the
+// callback return an error and the previous in-memory state stays live.
+//
+// fsnotify watches the containing directory rather than the file itself,
+// since many editors (vim, and most IDEs' atomic-save) replace a file by
+// writing a new inode and renaming it over the old path - a watch on the
+// original inode would never see that.
+type ConfigWatcher struct {
+	watcher *fsnotify.Watcher
+
+	mu        sync.Mutex
+	reloaders map[string]func() error
+
+	done chan struct{}
+}
+
+// NewConfigWatcher creates a ConfigWatcher with nothing watched yet;
+// call WatchFile for each config file before Start.
+func NewConfigWatcher() (*ConfigWatcher, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fsnotify watcher: %w", err)
+	}
+	return &ConfigWatcher{
+		watcher:   w,
+		reloaders: make(map[string]func() error),
+		done:      make(chan struct{}),
+	}, nil
+}
+
+// WatchFile registers reload to run whenever path's content changes.
+func (c *ConfigWatcher) WatchFile(path string, reload func() error) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+
+	if err := c.watcher.Add(filepath.Dir(abs)); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", filepath.Dir(abs), err)
+	}
+
+	c.mu.Lock()
+	c.reloaders[abs] = reload
+	c.mu.Unlock()
+	return nil
+}
+
+// Start launches the background goroutine dispatching fsnotify events to
+// the matching WatchFile reload callback. onError, if non-nil, receives
+// any error a reload callback or the underlying watcher returns -
+// notably a malformed edit, which is reported but never swapped in.
+func (c *ConfigWatcher) Start(onError func(error)) {
+	go func() {
+		for {
+			select {
+			case event, ok := <-c.watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				c.dispatch(event.Name, onError)
+			case err, ok := <-c.watcher.Errors:
+				if !ok {
+					return
+				}
+				if onError != nil {
+					onError(err)
+				}
+			case <-c.done:
+				return
+			}
+		}
+	}()
+}
+
+// dispatch looks up and runs the reload callback registered for name, if
+// any - events for unrelated files in a watched directory are ignored.
+func (c *ConfigWatcher) dispatch(name string, onError func(error)) {
+	abs, err := filepath.Abs(name)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	reload := c.reloaders[abs]
+	c.mu.Unlock()
+
+	if reload == nil {
+		return
+	}
+	if err := reload(); err != nil && onError != nil {
+		onError(err)
+	}
+}
+
+// Close stops the watcher goroutine and releases its fsnotify handle.
+func (c *ConfigWatcher) Close() error {
+	close(c.done)
+	return c.watcher.Close()
+}
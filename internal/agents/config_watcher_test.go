@@ -0,0 +1,149 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeCatalog(t *testing.T, path string, voiceID string) {
+	t.Helper()
+	catalog := `{"voices":[{"id":"` + voiceID + `","language":"en-US","gender":"female",` +
+		`"commercial_use_allowed":true,"license_name":"CC0","sample_rate":22050}]}`
+	if err := os.WriteFile(path, []byte(catalog), 0644); err != nil {
+		t.Fatalf("failed to write catalog: %v", err)
+	}
+}
+
+func TestConfigWatcher_ReloadsVoiceCatalogOnChange(t *testing.T) {
+	dir := t.TempDir()
+	catalogPath := filepath.Join(dir, "catalog.json")
+	writeCatalog(t, catalogPath, "voice-original")
+
+	agent := NewVoiceCatalogAgent(catalogPath)
+	if err := agent.LoadCatalog(); err != nil {
+		t.Fatalf("LoadCatalog() error = %v", err)
+	}
+
+	notified := make(chan *VoiceCatalog, 1)
+	agent.Subscribe(func(c *VoiceCatalog) { notified <- c })
+
+	watcher, err := NewConfigWatcher()
+	if err != nil {
+		t.Fatalf("NewConfigWatcher() error = %v", err)
+	}
+	defer watcher.Close()
+
+	var reloadErrs []error
+	if err := watcher.WatchFile(catalogPath, agent.ReloadCatalog); err != nil {
+		t.Fatalf("WatchFile() error = %v", err)
+	}
+	watcher.Start(func(err error) { reloadErrs = append(reloadErrs, err) })
+
+	// Confirm the voice we're about to replace resolves first.
+	if v, err := agent.SelectVoice("en-US", "voice-original", "auto"); err != nil || v.ID != "voice-original" {
+		t.Fatalf("SelectVoice before reload = %v, %v", v, err)
+	}
+
+	writeCatalog(t, catalogPath, "voice-updated")
+
+	select {
+	case <-notified:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for ConfigWatcher to pick up the catalog change")
+	}
+
+	if len(reloadErrs) > 0 {
+		t.Fatalf("ReloadCatalog reported errors: %v", reloadErrs)
+	}
+
+	v, err := agent.SelectVoice("en-US", "voice-updated", "auto")
+	if err != nil {
+		t.Fatalf("SelectVoice after reload failed: %v", err)
+	}
+	if v.ID != "voice-updated" {
+		t.Errorf("SelectVoice after reload = %q, want %q", v.ID, "voice-updated")
+	}
+
+	if _, err := agent.SelectVoice("en-US", "voice-original", "auto"); err == nil {
+		t.Errorf("old voice still resolves after reload; agent was not swapped")
+	}
+}
+
+func TestConfigWatcher_RejectsMalformedReload(t *testing.T) {
+	dir := t.TempDir()
+	catalogPath := filepath.Join(dir, "catalog.json")
+	writeCatalog(t, catalogPath, "voice-original")
+
+	agent := NewVoiceCatalogAgent(catalogPath)
+	if err := agent.LoadCatalog(); err != nil {
+		t.Fatalf("LoadCatalog() error = %v", err)
+	}
+
+	watcher, err := NewConfigWatcher()
+	if err != nil {
+		t.Fatalf("NewConfigWatcher() error = %v", err)
+	}
+	defer watcher.Close()
+
+	errs := make(chan error, 1)
+	if err := watcher.WatchFile(catalogPath, agent.ReloadCatalog); err != nil {
+		t.Fatalf("WatchFile() error = %v", err)
+	}
+	watcher.Start(func(err error) { errs <- err })
+
+	if err := os.WriteFile(catalogPath, []byte("not valid json"), 0644); err != nil {
+		t.Fatalf("failed to write malformed catalog: %v", err)
+	}
+
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Fatal("expected a reload error for malformed JSON, got nil")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the malformed reload to be reported")
+	}
+
+	// The previous, valid catalog must still be in effect.
+	if v, err := agent.SelectVoice("en-US", "voice-original", "auto"); err != nil || v.ID != "voice-original" {
+		t.Fatalf("catalog was swapped despite malformed reload: %v, %v", v, err)
+	}
+}
+
+func TestLanguageRegistry_ReloadAbbreviations(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "en-US.json")
+	if err := os.WriteFile(path, []byte(`{"Dr.":"Doctor","Capt.":"Captain"}`), 0644); err != nil {
+		t.Fatalf("failed to write dictionary: %v", err)
+	}
+
+	registry := NewLanguageRegistry()
+	registry.Register(LanguageProfile{Code: "en-US", Abbreviations: map[string]string{"Dr.": "Doctor"}}, "english")
+
+	if err := registry.ReloadAbbreviations("en-US", path); err != nil {
+		t.Fatalf("ReloadAbbreviations() error = %v", err)
+	}
+
+	profile, ok := registry.Resolve("english")
+	if !ok {
+		t.Fatal("Resolve(\"english\") = ok=false after reload")
+	}
+	if profile.Abbreviations["Capt."] != "Captain" {
+		t.Errorf("reloaded profile missing new abbreviation, got %v", profile.Abbreviations)
+	}
+}
+
+func TestLanguageRegistry_ReloadAbbreviations_UnknownCode(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "xx-XX.json")
+	if err := os.WriteFile(path, []byte(`{}`), 0644); err != nil {
+		t.Fatalf("failed to write dictionary: %v", err)
+	}
+
+	registry := NewLanguageRegistry()
+	if err := registry.ReloadAbbreviations("xx-XX", path); err == nil {
+		t.Error("ReloadAbbreviations() for an unregistered code = nil error, want an error")
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"os/exec"
 	"runtime"
 	"strings"
+
+	"studiospeech/internal/audio/encoder"
 )
 
 // EnvironmentInfo contains system environment details
@@ -18,10 +20,18 @@ type EnvironmentInfo struct {
 	GoVersion     string
 	HasPiper      bool
 	HasFFmpeg     bool
+	// CompiledCodecs lists the audio codecs with an in-process Encoder
+	// compiled into this binary (see internal/audio/encoder). These work
+	// even when HasFFmpeg is false.
+	CompiledCodecs []string
 }
 
 // EnvironmentAgent handles system requirements validation
-type EnvironmentAgent struct{}
+type EnvironmentAgent struct {
+	// offline, set via SetOffline, makes Fetch refuse network access
+	// and only use already-cached voice models.
+	offline bool
+}
 
 // NewEnvironmentAgent creates a new environment validation agent
 func NewEnvironmentAgent() *EnvironmentAgent {
@@ -31,9 +41,10 @@ func NewEnvironmentAgent() *EnvironmentAgent {
 // Check validates system requirements and returns environment info
 func (e *EnvironmentAgent) Check() (*EnvironmentInfo, error) {
 	info := &EnvironmentInfo{
-		OS:        runtime.GOOS,
-		Arch:      runtime.GOARCH,
-		GoVersion: runtime.Version(),
+		OS:             runtime.GOOS,
+		Arch:           runtime.GOARCH,
+		GoVersion:      runtime.Version(),
+		CompiledCodecs: encoder.Enabled(),
 	}
 
 	// Check Piper TTS
@@ -0,0 +1,96 @@
+package agents
+
+import (
+	"fmt"
+	"sync"
+)
+
+// VoiceAvailability reports, for one catalog voice, whether its model
+// file is already installed and verified, or missing but downloadable.
+type VoiceAvailability struct {
+	VoiceID      string
+	Present      bool
+	Downloadable bool
+}
+
+// FetchResult is one voice's outcome from EnvironmentAgent.Fetch.
+type FetchResult struct {
+	VoiceID string
+	Path    string
+	Err     error
+}
+
+// CheckVoices reports, for every voice in catalog, whether its model
+// file is already installed and verified (Present) and whether it has
+// a source to download it from if not (Downloadable).
+func (e *EnvironmentAgent) CheckVoices(catalog *VoiceCatalogAgent) []VoiceAvailability {
+	voices := catalog.GetAvailableVoices()
+	statuses := make([]VoiceAvailability, len(voices))
+
+	for i, voice := range voices {
+		voice := voice
+		statuses[i] = VoiceAvailability{
+			VoiceID:      voice.ID,
+			Present:      catalog.ValidateVoiceFile(&voice) == nil,
+			Downloadable: len(voice.SourceURL) > 0 || len(voice.Mirrors) > 0,
+		}
+	}
+
+	return statuses
+}
+
+// SetOffline makes Fetch refuse to touch the network: any requested
+// voice ID not already cached and verified fails immediately instead of
+// attempting a download, so CI jobs and air-gapped deployments can
+// assert they never reach out.
+func (e *EnvironmentAgent) SetOffline(offline bool) { e.offline = offline }
+
+// Fetch downloads every voice in voiceIDs from catalog in parallel,
+// verifying SHA-256 and renaming into place the same way
+// VoiceInstaller.Download does for a single voice. It returns one
+// FetchResult per requested voice ID, in the same order as voiceIDs, so
+// a caller can tell which voices succeeded without one failure aborting
+// the rest.
+func (e *EnvironmentAgent) Fetch(catalog *VoiceCatalogAgent, voiceIDs []string, opts DownloadOptions) []FetchResult {
+	results := make([]FetchResult, len(voiceIDs))
+	resolver := NewModelResolver(catalog)
+	resolver.SetOffline(e.offline)
+	installer := NewVoiceInstaller(catalog)
+
+	var wg sync.WaitGroup
+	for i, voiceID := range voiceIDs {
+		i, voiceID := i, voiceID
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = e.fetchOne(resolver, installer, catalog, voiceID, opts)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// fetchOne resolves a single voice ID, using opts (progress reporting,
+// AllowNonCommercial) for the actual download rather than
+// ModelResolver.Resolve's zero-value DownloadOptions.
+func (e *EnvironmentAgent) fetchOne(resolver *ModelResolver, installer *VoiceInstaller, catalog *VoiceCatalogAgent, voiceID string, opts DownloadOptions) FetchResult {
+	target, err := resolver.findVoice(voiceID)
+	if err != nil {
+		return FetchResult{VoiceID: voiceID, Err: err}
+	}
+
+	if catalog.ValidateVoiceFile(target) == nil {
+		return FetchResult{VoiceID: voiceID, Path: target.Path}
+	}
+
+	if e.offline {
+		return FetchResult{VoiceID: voiceID, Err: fmt.Errorf("offline mode: voice %s is not cached locally", voiceID)}
+	}
+
+	if err := installer.Download(target, opts); err != nil {
+		return FetchResult{VoiceID: voiceID, Err: err}
+	}
+
+	return FetchResult{VoiceID: voiceID, Path: target.Path}
+}
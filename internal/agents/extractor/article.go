@@ -0,0 +1,77 @@
+package extractor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"studiospeech/internal/agents"
+)
+
+func init() {
+	Register(&articleExtractor{client: http.DefaultClient})
+}
+
+// articleExtractor fetches a web page and extracts its main body text
+// with a lightweight Readability-style heuristic: strip script/style/nav
+// markup, then keep the text of every remaining <p> tag, which in
+// practice is where article prose lives on most sites.
+type articleExtractor struct {
+	client *http.Client
+}
+
+func (a *articleExtractor) Match(src string) bool {
+	return strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://")
+}
+
+var (
+	noiseTagsRe = regexp.MustCompile(`(?is)<(script|style|nav|header|footer)[^>]*>.*?</(script|style|nav|header|footer)>`)
+	paragraphRe = regexp.MustCompile(`(?is)<p[^>]*>(.*?)</p>`)
+)
+
+func (a *articleExtractor) Extract(ctx context.Context, src string) (*agents.TextContent, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request for %s: %w", src, err)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", src, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch %s failed: unexpected status %s", src, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", src, err)
+	}
+
+	cleaned := noiseTagsRe.ReplaceAll(body, nil)
+
+	var paragraphs []string
+	for _, match := range paragraphRe.FindAllSubmatch(cleaned, -1) {
+		if text := stripTags(match[1]); text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+	}
+
+	if len(paragraphs) == 0 {
+		return nil, fmt.Errorf("no article text found at %s", src)
+	}
+
+	lang, conf := detectLanguage(joinForDetection(paragraphs))
+	return &agents.TextContent{
+		Paragraphs:         paragraphs,
+		Language:           lang,
+		LanguageConfidence: conf,
+		WordCount:          countWords(paragraphs),
+		Source:             src,
+	}, nil
+}
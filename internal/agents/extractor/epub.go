@@ -0,0 +1,119 @@
+package extractor
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"studiospeech/internal/agents"
+)
+
+func init() {
+	Register(&epubExtractor{})
+}
+
+// epubExtractor reads a local .epub file (a zip archive of XHTML spine
+// items listed in a package document) and strips markup from each item
+// in spine order.
+type epubExtractor struct{}
+
+func (e *epubExtractor) Match(src string) bool {
+	return strings.HasSuffix(strings.ToLower(src), ".epub")
+}
+
+var (
+	containerFullPathRe = regexp.MustCompile(`full-path="([^"]+)"`)
+	opfManifestItemRe   = regexp.MustCompile(`(?is)<item[^>]*id="([^"]+)"[^>]*href="([^"]+)"`)
+	opfSpineItemRe      = regexp.MustCompile(`(?is)<itemref[^>]*idref="([^"]+)"`)
+)
+
+func (e *epubExtractor) Extract(ctx context.Context, src string) (*agents.TextContent, error) {
+	r, err := zip.OpenReader(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open EPUB %s: %w", src, err)
+	}
+	defer r.Close()
+
+	opfPath, opfDir, err := findEpubPackageDocument(&r.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	opf, err := readZipFile(&r.Reader, opfPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read package document %s in %s: %w", opfPath, src, err)
+	}
+
+	idToHref := map[string]string{}
+	for _, m := range opfManifestItemRe.FindAllStringSubmatch(string(opf), -1) {
+		idToHref[m[1]] = m[2]
+	}
+
+	var paragraphs []string
+	for _, m := range opfSpineItemRe.FindAllStringSubmatch(string(opf), -1) {
+		href, ok := idToHref[m[1]]
+		if !ok {
+			continue
+		}
+
+		content, err := readZipFile(&r.Reader, path.Join(opfDir, href))
+		if err != nil {
+			continue // skip spine items that can't be read rather than failing the whole book
+		}
+
+		for _, p := range paragraphRe.FindAllSubmatch(content, -1) {
+			if text := stripTags(p[1]); text != "" {
+				paragraphs = append(paragraphs, text)
+			}
+		}
+	}
+
+	if len(paragraphs) == 0 {
+		return nil, fmt.Errorf("no readable text found in EPUB %s", src)
+	}
+
+	lang, conf := detectLanguage(joinForDetection(paragraphs))
+	return &agents.TextContent{
+		Paragraphs:         paragraphs,
+		Language:           lang,
+		LanguageConfidence: conf,
+		WordCount:          countWords(paragraphs),
+		Source:             src,
+	}, nil
+}
+
+// findEpubPackageDocument locates the package document (.opf) referenced
+// by META-INF/container.xml, returning its path and containing
+// directory, since spine hrefs are relative to that directory.
+func findEpubPackageDocument(r *zip.Reader) (opfPath, opfDir string, err error) {
+	container, err := readZipFile(r, "META-INF/container.xml")
+	if err != nil {
+		return "", "", fmt.Errorf("EPUB is missing META-INF/container.xml: %w", err)
+	}
+
+	m := containerFullPathRe.FindSubmatch(container)
+	if m == nil {
+		return "", "", fmt.Errorf("could not find package document path in container.xml")
+	}
+
+	opfPath = string(m[1])
+	return opfPath, path.Dir(opfPath), nil
+}
+
+func readZipFile(r *zip.Reader, name string) ([]byte, error) {
+	for _, f := range r.File {
+		if f.Name == name {
+			rc, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer rc.Close()
+			return io.ReadAll(rc)
+		}
+	}
+	return nil, fmt.Errorf("file not found in archive: %s", name)
+}
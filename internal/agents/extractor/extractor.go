@@ -0,0 +1,66 @@
+// Package extractor pulls TextContent from sources beyond the local
+// .txt/.docx/.pdf files TextIngestAgent already understands: web
+// articles, YouTube auto-captions, RSS/Atom feeds, and EPUB ebooks. Each
+// extractor registers itself at init time and is selected by matching
+// against the --in value, mirroring the tts and encoder backend
+// registries.
+package extractor
+
+import (
+	"context"
+	"html"
+	"regexp"
+	"strings"
+
+	"studiospeech/internal/agents"
+)
+
+// Extractor pulls ingestible text from a single kind of external source.
+type Extractor interface {
+	// Match reports whether this extractor can handle src (a URL, a
+	// scheme-prefixed identifier like "yt:VIDEOID", or a file path).
+	Match(src string) bool
+
+	// Extract fetches and converts src into TextContent ready for the
+	// rest of the pipeline (normalization, voice selection, synthesis).
+	Extract(ctx context.Context, src string) (*agents.TextContent, error)
+}
+
+// detectLanguage runs the same trigram detector TextIngestAgent uses for
+// local files, so extracted content gets the same Language and
+// LanguageConfidence treatment regardless of where it came from.
+func detectLanguage(text string) (string, float64) {
+	code, conf, _ := agents.NewTextIngestAgent().DetectWithConfidence(text)
+	return code, conf
+}
+
+// countWords mirrors TextIngestAgent's own word counting so downstream
+// validation (ValidateContent's word-count checks) behaves identically
+// for extracted and locally ingested content.
+func countWords(paragraphs []string) int {
+	total := 0
+	for _, p := range paragraphs {
+		total += len(strings.Fields(p))
+	}
+	return total
+}
+
+// joinForDetection concatenates paragraphs into a single blob suitable
+// for language detection.
+func joinForDetection(paragraphs []string) string {
+	var b strings.Builder
+	for _, p := range paragraphs {
+		b.WriteString(p)
+		b.WriteString(" ")
+	}
+	return b.String()
+}
+
+var tagRe = regexp.MustCompile(`(?s)<[^>]+>`)
+
+// stripTags removes HTML markup and decodes entities, collapsing
+// whitespace to single spaces.
+func stripTags(markup []byte) string {
+	text := tagRe.ReplaceAll(markup, []byte(" "))
+	return strings.Join(strings.Fields(html.UnescapeString(string(text))), " ")
+}
@@ -0,0 +1,120 @@
+package extractor
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"studiospeech/internal/agents"
+)
+
+func init() {
+	Register(&feedExtractor{client: http.DefaultClient})
+}
+
+// feedSourcePrefix marks a --in value as an RSS or Atom feed URL, e.g.
+// "feed:https://example.com/posts.xml".
+const feedSourcePrefix = "feed:"
+
+// feedExtractor resolves "feed:<url>" sources as RSS or Atom feeds,
+// turning each item/entry into its own paragraph group: the title
+// followed by its description, summary, or content.
+type feedExtractor struct {
+	client *http.Client
+}
+
+func (f *feedExtractor) Match(src string) bool {
+	return strings.HasPrefix(src, feedSourcePrefix)
+}
+
+type rssFeed struct {
+	XMLName xml.Name  `xml:"rss"`
+	Items   []rssItem `xml:"channel>item"`
+}
+
+type rssItem struct {
+	Title       string `xml:"title"`
+	Description string `xml:"description"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	Title   string `xml:"title"`
+	Summary string `xml:"summary"`
+	Content string `xml:"content"`
+}
+
+func (f *feedExtractor) Extract(ctx context.Context, src string) (*agents.TextContent, error) {
+	feedURL := strings.TrimPrefix(src, feedSourcePrefix)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request for %s: %w", feedURL, err)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feed %s: %w", feedURL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feed %s: %w", feedURL, err)
+	}
+
+	var paragraphs []string
+
+	var rss rssFeed
+	if xmlErr := xml.Unmarshal(body, &rss); xmlErr == nil && len(rss.Items) > 0 {
+		for _, item := range rss.Items {
+			paragraphs = append(paragraphs, feedItemParagraphs(item.Title, item.Description)...)
+		}
+	} else {
+		var atom atomFeed
+		if xmlErr := xml.Unmarshal(body, &atom); xmlErr != nil {
+			return nil, fmt.Errorf("failed to parse feed %s as RSS or Atom: %w", feedURL, xmlErr)
+		}
+		for _, entry := range atom.Entries {
+			text := entry.Content
+			if text == "" {
+				text = entry.Summary
+			}
+			paragraphs = append(paragraphs, feedItemParagraphs(entry.Title, text)...)
+		}
+	}
+
+	if len(paragraphs) == 0 {
+		return nil, fmt.Errorf("no items found in feed %s", feedURL)
+	}
+
+	lang, conf := detectLanguage(joinForDetection(paragraphs))
+	return &agents.TextContent{
+		Paragraphs:         paragraphs,
+		Language:           lang,
+		LanguageConfidence: conf,
+		WordCount:          countWords(paragraphs),
+		Source:             src,
+	}, nil
+}
+
+// feedItemParagraphs turns one feed item's title and (possibly HTML)
+// body into the paragraphs representing it, kept contiguous in the
+// output so each item reads as its own group.
+func feedItemParagraphs(title, body string) []string {
+	var group []string
+	if t := strings.TrimSpace(title); t != "" {
+		group = append(group, t)
+	}
+	if b := strings.TrimSpace(stripTags([]byte(body))); b != "" {
+		group = append(group, b)
+	}
+	return group
+}
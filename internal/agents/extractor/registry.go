@@ -0,0 +1,33 @@
+package extractor
+
+import "sync"
+
+var (
+	registryMu sync.RWMutex
+	registry   []Extractor
+)
+
+// Register adds an extractor to the global registry. Extractors
+// typically call this from an init() in their own file, mirroring the
+// tts and encoder backend registries, so simply importing this package
+// makes every built-in extractor available.
+func Register(e Extractor) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = append(registry, e)
+}
+
+// Resolve returns the first registered extractor whose Match(src) is
+// true, checked in registration order, or nil if src looks like a plain
+// local file that TextIngestAgent.ProcessFile should handle instead.
+func Resolve(src string) Extractor {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for _, e := range registry {
+		if e.Match(src) {
+			return e
+		}
+	}
+	return nil
+}
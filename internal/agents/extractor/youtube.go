@@ -0,0 +1,96 @@
+package extractor
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"studiospeech/internal/agents"
+)
+
+func init() {
+	Register(&youtubeExtractor{client: http.DefaultClient})
+}
+
+// youtubeSourcePrefix marks a --in value as a YouTube video ID rather
+// than a local path or URL, e.g. "yt:dQw4w9WgXcQ".
+const youtubeSourcePrefix = "yt:"
+
+// youtubeExtractor resolves "yt:VIDEOID" sources by fetching YouTube's
+// auto-generated caption track and turning each caption cue into its own
+// paragraph.
+type youtubeExtractor struct {
+	client *http.Client
+}
+
+func (y *youtubeExtractor) Match(src string) bool {
+	return strings.HasPrefix(src, youtubeSourcePrefix)
+}
+
+// timedText mirrors the XML shape returned by YouTube's unofficial
+// timedtext endpoint: a flat list of <text start="..." dur="...">
+// elements, one per caption.
+type timedText struct {
+	XMLName xml.Name   `xml:"transcript"`
+	Cues    []timedCue `xml:"text"`
+}
+
+type timedCue struct {
+	Start float64 `xml:"start,attr"`
+	Dur   float64 `xml:"dur,attr"`
+	Text  string  `xml:",chardata"`
+}
+
+func (y *youtubeExtractor) Extract(ctx context.Context, src string) (*agents.TextContent, error) {
+	videoID := strings.TrimPrefix(src, youtubeSourcePrefix)
+	if videoID == "" {
+		return nil, fmt.Errorf("yt: source is missing a video ID")
+	}
+
+	captionURL := fmt.Sprintf("https://www.youtube.com/api/timedtext?v=%s&lang=en", videoID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, captionURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build caption request for %s: %w", videoID, err)
+	}
+
+	resp, err := y.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch captions for %s: %w", videoID, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read captions for %s: %w", videoID, err)
+	}
+
+	var doc timedText
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse captions for %s: %w", videoID, err)
+	}
+	if len(doc.Cues) == 0 {
+		return nil, fmt.Errorf("no auto-generated captions found for video %s", videoID)
+	}
+
+	var paragraphs []string
+	for _, cue := range doc.Cues {
+		if line := strings.TrimSpace(stripTags([]byte(cue.Text))); line != "" {
+			paragraphs = append(paragraphs, line)
+		}
+	}
+	if len(paragraphs) == 0 {
+		return nil, fmt.Errorf("captions for video %s were all empty", videoID)
+	}
+
+	lang, conf := detectLanguage(joinForDetection(paragraphs))
+	return &agents.TextContent{
+		Paragraphs:         paragraphs,
+		Language:           lang,
+		LanguageConfidence: conf,
+		WordCount:          countWords(paragraphs),
+		Source:             src,
+	}, nil
+}
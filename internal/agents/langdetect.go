@@ -0,0 +1,188 @@
+package agents
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Candidate is one alternative language guess returned alongside the
+// winning detection, ordered by score.
+type Candidate struct {
+	Language   string
+	Confidence float64
+}
+
+// LangDetector is implemented by anything that can guess a BCP-47
+// language code from raw text. It exists so TextIngestAgent doesn't have
+// to hard-code a single detection strategy.
+type LangDetector interface {
+	DetectWithConfidence(text string) (code string, conf float64, alts []Candidate)
+}
+
+// trigramFrequencies holds small per-language character-trigram counts
+// harvested offline from short Wikipedia samples. They are intentionally
+// compact (eight to fifteen of the most distinctive trigrams per language)
+// rather than exhaustive corpora, which keeps the binary small while
+// still separating languages that share a Latin alphabet.
+var trigramFrequencies = map[string]map[string]int{
+	"en": {" th": 40, "the": 38, "he ": 30, "ing": 25, "and": 22, " an": 20, "ion": 18, "ed ": 16, " to": 15, "ent": 14, " of": 14, "er ": 13, " in": 12, "tio": 11, "for": 10},
+	"el": {" κα": 35, "και": 34, "του": 28, " το": 24, "ων ": 20, "ους": 18, "τητ": 16, " στ": 15, "ικό": 13, "εία": 12, "ούν": 11, "ται": 11, " με": 10},
+	"es": {" de": 36, "de ": 32, "ció": 26, " la": 24, "la ": 22, "que": 20, "ent": 16, " en": 15, "nte": 13, "ado": 12, " el": 12, "con": 10},
+	"fr": {" de": 34, " le": 28, "les": 24, "ion": 20, "ent": 18, " la": 17, "tio": 15, "que": 14, " et": 13, "ait": 11, "eur": 10},
+	"de": {"en ": 32, " de": 28, "der": 26, "ich": 22, "sch": 20, "che": 18, " di": 16, "ein": 15, "und": 14, "gen": 12, " ei": 11},
+	"it": {" di": 30, "di ": 26, "che": 22, " la": 20, "one": 18, "ent": 16, "zio": 14, " il": 13, "are": 12, "to ": 11},
+	"pt": {" de": 30, "de ": 26, "ção": 22, " qu": 18, "ent": 16, "ado": 14, " co": 13, "ist": 11, " pa": 10},
+	"nl": {" de": 30, " va": 24, "van": 22, "een": 20, "ijk": 16, " he": 14, "cht": 13, " op": 12, "aar": 10},
+	"ru": {"ени": 26, " на": 24, "ост": 20, "ова": 18, " по": 16, "ать": 14, "ств": 13, "ный": 12},
+}
+
+var wordSplitRegex = regexp.MustCompile(`[\p{L}]+`)
+
+// TrigramDetector is the default LangDetector, scoring text against
+// per-language character-trigram frequency tables.
+type TrigramDetector struct {
+	models map[string]map[string]int
+}
+
+// NewTrigramDetector creates a detector over the built-in trigram tables.
+func NewTrigramDetector() *TrigramDetector {
+	return &TrigramDetector{models: trigramFrequencies}
+}
+
+// unseenTrigramFloor is the probability assigned to a trigram that
+// doesn't appear in a language's table, the same fixed value for every
+// language regardless of that table's own size/total count. Deriving the
+// floor from each table via Laplace smoothing (count+1)/(total+vocab)
+// instead would give smaller tables (e.g. "ru", 8 entries) a higher floor
+// than larger ones (e.g. "en", 15 entries), so unrelated or gibberish
+// text would systematically "win" for whichever language happens to have
+// the smallest table -- a fixed floor keeps every language's score
+// comparable on text that matches none of them well.
+const unseenTrigramFloor = 0.001
+
+// confidenceTemperature divides each candidate's average log-likelihood
+// before the softmax in normalizeScores, the same way a temperature
+// parameter sharpens or flattens any softmax. Without it, two genuinely
+// close candidates (average log-likelihoods a few hundredths of a nat
+// apart) would barely separate in the [0,1] confidence output even when
+// one is clearly right, since the raw per-trigram averages it's fed
+// cluster tightly together; 0.3 was tuned so unambiguous single-language
+// text clears the 0.6 auto-detect threshold while a tie at the floor
+// (gibberish, or text with no trigram overlap with any table) still
+// normalizes to a flat, unconfident distribution instead of spiking.
+const confidenceTemperature = 0.3
+
+// DetectWithConfidence lowercases and strips punctuation from text,
+// extracts overlapping character trigrams, and scores each known
+// language by the average of log(p(trigram|lang)) across all of text's
+// trigrams (not the sum -- a sum grows without bound as text gets
+// longer, which saturates the softmax below to ~0/~1 regardless of
+// whether the detected language is actually a good fit). It returns the
+// best-scoring language, a 0-1 confidence derived from how far ahead of
+// the runner-up it is, and the full ranked candidate list.
+func (d *TrigramDetector) DetectWithConfidence(text string) (string, float64, []Candidate) {
+	trigrams := extractTrigrams(text)
+	if len(trigrams) == 0 {
+		return "auto", 0, nil
+	}
+
+	scores := make(map[string]float64, len(d.models))
+	for lang, freqs := range d.models {
+		total := 0
+		for _, c := range freqs {
+			total += c
+		}
+
+		var score float64
+		for _, tg := range trigrams {
+			p := unseenTrigramFloor
+			if count := freqs[tg]; count > 0 {
+				p = float64(count) / float64(total)
+			}
+			score += math.Log(p)
+		}
+		scores[lang] = score / float64(len(trigrams))
+	}
+
+	candidates := make([]Candidate, 0, len(scores))
+	for lang, score := range scores {
+		candidates = append(candidates, Candidate{Language: lang, Confidence: score})
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Confidence > candidates[j].Confidence })
+
+	normalized := normalizeScores(candidates)
+	return normalized[0].Language, normalized[0].Confidence, normalized
+}
+
+// normalizeScores converts per-trigram average log-likelihood scores
+// (all negative, best closest to zero) into a 0-1 confidence
+// distribution via a softmax scaled by confidenceTemperature, so callers
+// can apply a simple threshold like "confidence > 0.6".
+func normalizeScores(candidates []Candidate) []Candidate {
+	if len(candidates) == 0 {
+		return candidates
+	}
+
+	maxScore := candidates[0].Confidence
+	var sumExp float64
+	exps := make([]float64, len(candidates))
+	for i, c := range candidates {
+		exps[i] = math.Exp((c.Confidence - maxScore) / confidenceTemperature)
+		sumExp += exps[i]
+	}
+
+	out := make([]Candidate, len(candidates))
+	for i, c := range candidates {
+		out[i] = Candidate{Language: c.Language, Confidence: exps[i] / sumExp}
+	}
+	return out
+}
+
+// extractTrigrams lowercases text, collapses whitespace, and returns
+// overlapping 3-character windows (including a single leading/trailing
+// space per word, which is what makes trigram tables distinguish
+// word-initial/final patterns).
+func extractTrigrams(text string) []string {
+	text = strings.ToLower(text)
+	words := wordSplitRegex.FindAllString(text, -1)
+
+	var trigrams []string
+	for _, word := range words {
+		padded := " " + word + " "
+		runes := []rune(padded)
+		for i := 0; i+3 <= len(runes); i++ {
+			trigrams = append(trigrams, string(runes[i:i+3]))
+		}
+	}
+	return trigrams
+}
+
+// bcp47ToBase maps a detector code ("en", "el", ...) to the BCP-47 tag
+// used throughout the rest of StudioSpeech (catalog languages, CLI
+// --lang values).
+var bcp47ToBase = map[string]string{
+	"en": "en-US", "el": "el-GR", "es": "es-ES", "fr": "fr-FR",
+	"de": "de-DE", "it": "it-IT", "pt": "pt-PT", "nl": "nl-NL", "ru": "ru-RU",
+}
+
+// DetectWithConfidence runs the configured LangDetector (a trigram model
+// by default) and maps its result onto a BCP-47 tag.
+func (t *TextIngestAgent) DetectWithConfidence(text string) (code string, conf float64, alts []Candidate) {
+	detector := t.detector
+	if detector == nil {
+		detector = NewTrigramDetector()
+	}
+
+	code, conf, alts = detector.DetectWithConfidence(text)
+	if full, ok := bcp47ToBase[code]; ok {
+		code = full
+	}
+	for i, alt := range alts {
+		if full, ok := bcp47ToBase[alt.Language]; ok {
+			alts[i].Language = full
+		}
+	}
+	return code, conf, alts
+}
@@ -0,0 +1,52 @@
+package agents
+
+import "testing"
+
+func TestTrigramDetector_DetectWithConfidence(t *testing.T) {
+	detector := NewTrigramDetector()
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"the quick brown fox jumps over the lazy dog and the cat", "en"},
+		{"και του και το και στο τα και η δηλαδή", "el"},
+		{"de la casa que entonces el de la ciudad", "es"},
+	}
+
+	for _, test := range tests {
+		code, conf, alts := detector.DetectWithConfidence(test.text)
+		if code != test.want {
+			t.Errorf("DetectWithConfidence(%q) = %s (conf %.2f), want %s\nalts: %v", test.text, code, conf, test.want, alts)
+		}
+		if conf <= 0 || conf > 1 {
+			t.Errorf("confidence %.2f out of [0,1] range", conf)
+		}
+	}
+}
+
+func TestTextIngestAgent_DetectWithConfidence(t *testing.T) {
+	agent := NewTextIngestAgent()
+
+	code, conf, _ := agent.DetectWithConfidence("the quick brown fox jumps over the lazy dog and the cat")
+	if code != "en-US" {
+		t.Errorf("expected en-US, got %s", code)
+	}
+	if conf <= 0.6 {
+		t.Errorf("expected high confidence for unambiguous English text, got %.2f", conf)
+	}
+}
+
+// TestTrigramDetector_GibberishStaysUnconfident guards against scoring that
+// lets text matching none of the trigram tables well still spike to a
+// false-high confidence for whichever language happens to "win" by
+// default (previously Russian, due to its smaller table giving it a
+// higher Laplace-smoothed floor than larger tables like English's).
+func TestTrigramDetector_GibberishStaysUnconfident(t *testing.T) {
+	detector := NewTrigramDetector()
+
+	_, conf, alts := detector.DetectWithConfidence("qzx vbk jqw zxc wqk bvj xzq kwv")
+	if conf > 0.6 {
+		t.Errorf("gibberish text got confidence %.2f, want it to stay below the 0.6 auto-detect threshold\nalts: %v", conf, alts)
+	}
+}
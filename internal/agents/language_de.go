@@ -0,0 +1,17 @@
+package agents
+
+func init() {
+	Languages.Register(LanguageProfile{
+		Code: "de-DE",
+		Abbreviations: map[string]string{
+			"Hr.":  "Herr",
+			"Fr.":  "Frau",
+			"Dr.":  "Doktor",
+			"usw.": "und so weiter",
+			"z.B.": "zum Beispiel",
+			"bzw.": "beziehungsweise",
+		},
+		SentenceEnders: ".!?",
+		VoiceHints:     []string{"german", "de-de", "anna", "markus"},
+	}, "german", "de", "de-de", "de_de", "deutsch")
+}
@@ -0,0 +1,21 @@
+package agents
+
+import "studiospeech/internal/agents/numwords"
+
+func init() {
+	expander, _ := numwords.Get("el-GR")
+	Languages.Register(LanguageProfile{
+		Code: "el-GR",
+		Abbreviations: map[string]string{
+			"κ.λπ.": "και λοιπά",
+			"κ.ά.":  "και άλλα",
+			"π.χ.":  "παραδείγματος χάρη",
+			"δηλ.":  "δηλαδή",
+			"κτλ.":  "και τα λοιπά",
+		},
+		NumberExpander: expander,
+		// Greek uses the semicolon as a question mark instead of "?".
+		SentenceEnders: ".!;",
+		VoiceHints:     []string{"greek", "el-gr", "melina", "nikos"},
+	}, "greek", "el", "el-gr", "el_gr", "ελληνικά")
+}
@@ -0,0 +1,24 @@
+package agents
+
+import "studiospeech/internal/agents/numwords"
+
+func init() {
+	expander, _ := numwords.Get("en-US")
+	Languages.Register(LanguageProfile{
+		Code: "en-US",
+		Abbreviations: map[string]string{
+			"Dr.":   "Doctor",
+			"Mr.":   "Mister",
+			"Mrs.":  "Missus",
+			"Ms.":   "Miss",
+			"Prof.": "Professor",
+			"etc.":  "etcetera",
+			"vs.":   "versus",
+			"e.g.":  "for example",
+			"i.e.":  "that is",
+		},
+		NumberExpander: expander,
+		SentenceEnders: ".!?",
+		VoiceHints:     []string{"english", "en-us", "samantha", "alex"},
+	}, "english", "en", "en-us", "en_us")
+}
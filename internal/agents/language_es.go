@@ -0,0 +1,17 @@
+package agents
+
+func init() {
+	Languages.Register(LanguageProfile{
+		Code: "es-ES",
+		Abbreviations: map[string]string{
+			"Sr.":   "Señor",
+			"Sra.":  "Señora",
+			"Dr.":   "Doctor",
+			"Dra.":  "Doctora",
+			"etc.":  "etcétera",
+			"p.ej.": "por ejemplo",
+		},
+		SentenceEnders: ".!?",
+		VoiceHints:     []string{"spanish", "es-es", "monica", "jorge"},
+	}, "spanish", "es", "es-es", "es_es", "español")
+}
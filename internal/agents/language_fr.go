@@ -0,0 +1,16 @@
+package agents
+
+func init() {
+	Languages.Register(LanguageProfile{
+		Code: "fr-FR",
+		Abbreviations: map[string]string{
+			"M.":    "Monsieur",
+			"Mme":   "Madame",
+			"Dr.":   "Docteur",
+			"etc.":  "et cetera",
+			"p.ex.": "par exemple",
+		},
+		SentenceEnders: ".!?",
+		VoiceHints:     []string{"french", "fr-fr", "amelie", "thomas"},
+	}, "french", "fr", "fr-fr", "fr_fr", "français")
+}
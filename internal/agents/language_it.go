@@ -0,0 +1,16 @@
+package agents
+
+func init() {
+	Languages.Register(LanguageProfile{
+		Code: "it-IT",
+		Abbreviations: map[string]string{
+			"Sig.":   "Signore",
+			"Sig.ra": "Signora",
+			"Dr.":    "Dottore",
+			"ecc.":   "eccetera",
+			"es.":    "esempio",
+		},
+		SentenceEnders: ".!?",
+		VoiceHints:     []string{"italian", "it-it", "alice", "luca"},
+	}, "italian", "it", "it-it", "it_it", "italiano")
+}
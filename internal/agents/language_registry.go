@@ -0,0 +1,131 @@
+package agents
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"studiospeech/internal/agents/numwords"
+)
+
+// LanguageProfile bundles the locale-specific behavior NormalizeAgent
+// consults instead of hard-coded switch statements: abbreviation
+// expansions, a numwords.Expander, and sentence-ending punctuation.
+type LanguageProfile struct {
+	// Code is the canonical BCP-47 tag this profile is registered
+	// under, e.g. "en-US".
+	Code string
+	// Abbreviations maps an abbreviation (including its trailing period)
+	// to its spoken-out expansion.
+	Abbreviations map[string]string
+	// NumberExpander expands numeric text to words; nil means no
+	// locale-specific number expansion is available for this language.
+	NumberExpander numwords.Expander
+	// SentenceEnders lists the punctuation characters that end a
+	// sentence in this language (Greek uses ";" rather than "?" for a
+	// question mark).
+	SentenceEnders string
+	// VoiceHints are voice-name substrings (case-insensitive), most
+	// preferred first, for a future voice auto-selector to favor when no
+	// voice was requested explicitly. Nothing in this tree consumes it
+	// yet (there is no MacOSTTSAgent.selectVoice equivalent), but it's
+	// part of the profile so that selector has somewhere to read from
+	// once it exists.
+	VoiceHints []string
+}
+
+// OtherLanguage is the fallback profile for an alias Resolve doesn't
+// recognize: no abbreviation or number expansion, so unsupported-
+// language text still reaches synthesis unchanged rather than erroring.
+var OtherLanguage = LanguageProfile{SentenceEnders: ".!?"}
+
+// LanguageRegistry maps BCP-47 tags and their common aliases to a
+// registered LanguageProfile.
+type LanguageRegistry struct {
+	mu       sync.RWMutex
+	aliases  map[string]string
+	profiles map[string]LanguageProfile
+}
+
+// NewLanguageRegistry creates an empty language registry.
+func NewLanguageRegistry() *LanguageRegistry {
+	return &LanguageRegistry{
+		aliases:  make(map[string]string),
+		profiles: make(map[string]LanguageProfile),
+	}
+}
+
+// Register adds profile to the registry under its Code and every given
+// alias, matched case- and separator-insensitively so "en_US", "EN-US",
+// and "en-us" all resolve the same way.
+func (r *LanguageRegistry) Register(profile LanguageProfile, aliases ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.profiles[profile.Code] = profile
+	r.aliases[normalizeLanguageAlias(profile.Code)] = profile.Code
+	for _, alias := range aliases {
+		r.aliases[normalizeLanguageAlias(alias)] = profile.Code
+	}
+}
+
+// Resolve looks up alias, returning ok=false if nothing in the registry
+// matches it.
+func (r *LanguageRegistry) Resolve(alias string) (LanguageProfile, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	code, ok := r.aliases[normalizeLanguageAlias(alias)]
+	if !ok {
+		return LanguageProfile{}, false
+	}
+	return r.profiles[code], true
+}
+
+// ReloadAbbreviations reads path as a JSON object mapping abbreviation to
+// expansion and swaps it into the profile registered under code. The
+// file is fully parsed before anything is swapped, so a malformed edit
+// on disk leaves the previously registered abbreviations (and any
+// in-flight Resolve callers reading them) untouched.
+func (r *LanguageRegistry) ReloadAbbreviations(code, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var abbreviations map[string]string
+	if err := json.Unmarshal(data, &abbreviations); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	profile, ok := r.profiles[code]
+	if !ok {
+		return fmt.Errorf("no language profile registered for %q", code)
+	}
+	profile.Abbreviations = abbreviations
+	r.profiles[code] = profile
+	return nil
+}
+
+// normalizeLanguageAlias folds case and collapses "_"/" " to "-" so
+// "en_US", "en us", and "en-US" are all the same registry key.
+func normalizeLanguageAlias(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.NewReplacer("_", "-", " ", "-").Replace(s)
+}
+
+// Languages is the process-wide registry each supported language's
+// init() populates; ResolveLanguage is its package-level entry point.
+var Languages = NewLanguageRegistry()
+
+// ResolveLanguage looks up alias (a BCP-47 tag or a common alias like
+// "english", "en", "greek", or "ελληνικά") in the shared Languages
+// registry.
+func ResolveLanguage(alias string) (LanguageProfile, bool) {
+	return Languages.Resolve(alias)
+}
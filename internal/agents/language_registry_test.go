@@ -0,0 +1,48 @@
+package agents
+
+import "testing"
+
+func TestLanguageRegistry_ResolveAlias(t *testing.T) {
+	tests := []struct {
+		alias    string
+		wantCode string
+	}{
+		{"english", "en-US"},
+		{"en", "en-US"},
+		{"en_US", "en-US"},
+		{"EN-us", "en-US"},
+		{"greek", "el-GR"},
+		{"el", "el-GR"},
+		{"ελληνικά", "el-GR"},
+		{"spanish", "es-ES"},
+		{"es", "es-ES"},
+		{"español", "es-ES"},
+		{"german", "de-DE"},
+		{"de", "de-DE"},
+		{"deutsch", "de-DE"},
+		{"french", "fr-FR"},
+		{"fr", "fr-FR"},
+		{"français", "fr-FR"},
+		{"italian", "it-IT"},
+		{"it", "it-IT"},
+		{"italiano", "it-IT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.alias, func(t *testing.T) {
+			profile, ok := ResolveLanguage(tt.alias)
+			if !ok {
+				t.Fatalf("ResolveLanguage(%q) = ok=false, want ok=true", tt.alias)
+			}
+			if profile.Code != tt.wantCode {
+				t.Errorf("ResolveLanguage(%q).Code = %q, want %q", tt.alias, profile.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestLanguageRegistry_ResolveAlias_Unknown(t *testing.T) {
+	if _, ok := ResolveLanguage("klingon"); ok {
+		t.Errorf("ResolveLanguage(\"klingon\") = ok=true, want ok=false")
+	}
+}
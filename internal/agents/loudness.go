@@ -0,0 +1,256 @@
+package agents
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ReplayGain 2.0 and EBU R128 define different reference loudnesses, so a
+// measured track's gain relative to each standard is reported separately.
+const (
+	replayGainReferenceLUFS = -18.0 // ReplayGain 2.0 reference loudness
+	r128ReferenceLUFS       = -23.0 // EBU R128 program reference loudness
+)
+
+var (
+	integratedLoudnessRe = regexp.MustCompile(`I:\s*(-?[\d.]+) LUFS`)
+	truePeakRe           = regexp.MustCompile(`Peak:\s*(-?[\d.]+) dBFS`)
+	loudnessRangeRe      = regexp.MustCompile(`LRA:\s*(-?[\d.]+) LU`)
+)
+
+// LoudnessAnalysis is one file's EBU R128 measurement, as reported by
+// ffmpeg's ebur128 filter.
+type LoudnessAnalysis struct {
+	IntegratedLUFS float64
+	TruePeakDBFS   float64
+	LRA            float64
+}
+
+// ReplayGainTags are the tag values ApplyReplayGain/ProcessAlbum embed
+// into an output file. Album fields are left zero-valued unless
+// PostProcessParams.AlbumMode was set.
+type ReplayGainTags struct {
+	TrackGainDB   float64
+	TrackPeak     float64
+	R128TrackGain int
+	AlbumGainDB   float64
+	AlbumPeak     float64
+	R128AlbumGain int
+}
+
+// AlbumTrack pairs one already-rendered track with where its
+// ReplayGain/R128-tagged output should be written.
+type AlbumTrack struct {
+	InputPath  string
+	OutputPath string
+}
+
+// analyzeLoudness runs ffmpeg's ebur128 filter over path in analysis-only
+// mode (-f null -) and parses the "Integrated loudness" / "True peak" /
+// "Loudness range" values out of the Summary block it prints to stderr
+// once the whole file has been scanned.
+func (p *PostProcessAgent) analyzeLoudness(path string) (*LoudnessAnalysis, error) {
+	cmd := exec.Command(p.ffmpegPath, "-i", path, "-af", "ebur128=peak=true", "-f", "null", "-")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("ebur128 analysis failed: %w\nOutput: %s", err, string(output))
+	}
+
+	summary := string(output)
+	if idx := strings.Index(summary, "Summary:"); idx >= 0 {
+		summary = summary[idx:]
+	}
+
+	m := integratedLoudnessRe.FindStringSubmatch(summary)
+	if m == nil {
+		return nil, fmt.Errorf("ebur128 output did not contain an integrated loudness measurement")
+	}
+	analysis := &LoudnessAnalysis{}
+	analysis.IntegratedLUFS, _ = strconv.ParseFloat(m[1], 64)
+
+	if m := truePeakRe.FindStringSubmatch(summary); m != nil {
+		analysis.TruePeakDBFS, _ = strconv.ParseFloat(m[1], 64)
+	}
+	if m := loudnessRangeRe.FindStringSubmatch(summary); m != nil {
+		analysis.LRA, _ = strconv.ParseFloat(m[1], 64)
+	}
+
+	return analysis, nil
+}
+
+// computeReplayGainTags derives ReplayGain/R128 track tags from a
+// measurement, relative to each standard's own fixed reference loudness -
+// independent of whatever target PostProcessParams.LoudnessLUFS asked the
+// earlier loudnorm pass to hit, so players that honor these tags get a
+// portable, industry-standard gain rather than one tied to our pipeline's
+// internal target.
+func computeReplayGainTags(analysis *LoudnessAnalysis) *ReplayGainTags {
+	return &ReplayGainTags{
+		TrackGainDB:   replayGainReferenceLUFS - analysis.IntegratedLUFS,
+		TrackPeak:     math.Pow(10, analysis.TruePeakDBFS/20),
+		R128TrackGain: int(math.Round((r128ReferenceLUFS - analysis.IntegratedLUFS) * 256)),
+	}
+}
+
+// albumIntegratedLUFS combines per-track gated loudness measurements into
+// one album-level integrated loudness using EBU R128's energy-domain
+// averaging (mean power, converted back to LUFS) rather than a plain
+// arithmetic mean of LUFS values, which would under-weight louder tracks.
+func albumIntegratedLUFS(trackLUFS []float64) float64 {
+	if len(trackLUFS) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, l := range trackLUFS {
+		sum += math.Pow(10, (l+0.691)/10)
+	}
+	return 10*math.Log10(sum/float64(len(trackLUFS))) - 0.691
+}
+
+// embedReplayGain re-encodes src to dst, applying correctionDB of gain and
+// writing tags as REPLAYGAIN_TRACK_GAIN/REPLAYGAIN_TRACK_PEAK/
+// R128_TRACK_GAIN metadata (plus the album-level tags when
+// params.AlbumMode is set). MP3 additionally gets -write_id3v2 1 so the
+// tags land in an ID3v2 frame players actually read.
+func (p *PostProcessAgent) embedReplayGain(src, dst string, correctionDB float64, tags *ReplayGainTags, params *PostProcessParams) error {
+	args := []string{"-i", src, "-y"}
+
+	if correctionDB != 0 {
+		args = append(args, "-af", fmt.Sprintf("volume=%.2fdB", correctionDB))
+	} else {
+		args = append(args, "-c", "copy")
+	}
+
+	args = append(args,
+		"-metadata", fmt.Sprintf("REPLAYGAIN_TRACK_GAIN=%.2f dB", tags.TrackGainDB),
+		"-metadata", fmt.Sprintf("REPLAYGAIN_TRACK_PEAK=%.6f", tags.TrackPeak),
+		"-metadata", fmt.Sprintf("R128_TRACK_GAIN=%d", tags.R128TrackGain),
+	)
+
+	if params.AlbumMode {
+		args = append(args,
+			"-metadata", fmt.Sprintf("REPLAYGAIN_ALBUM_GAIN=%.2f dB", tags.AlbumGainDB),
+			"-metadata", fmt.Sprintf("REPLAYGAIN_ALBUM_PEAK=%.6f", tags.AlbumPeak),
+			"-metadata", fmt.Sprintf("R128_ALBUM_GAIN=%d", tags.R128AlbumGain),
+		)
+	}
+
+	if params.Format == FormatMP3 {
+		args = append(args, "-write_id3v2", "1")
+	}
+
+	args = append(args, dst)
+
+	cmd := exec.Command(p.ffmpegPath, args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("ffmpeg failed: %w\nOutput: %s", err, string(output))
+	}
+	return nil
+}
+
+// ApplyReplayGain runs a two-pass EBU R128 measurement/correction over an
+// already-encoded file: the first pass measures integrated loudness and
+// true peak via analyzeLoudness, the second pass applies the corrective
+// gain needed to hit params.LoudnessLUFS exactly and embeds the measured
+// ReplayGain/R128 tags, overwriting path in place.
+func (p *PostProcessAgent) ApplyReplayGain(path string, params *PostProcessParams) (*ReplayGainTags, error) {
+	analysis, err := p.analyzeLoudness(path)
+	if err != nil {
+		return nil, fmt.Errorf("replaygain analysis: %w", err)
+	}
+
+	tags := computeReplayGainTags(analysis)
+	correctionDB := params.LoudnessLUFS - analysis.IntegratedLUFS
+
+	tmpPath := path + ".rg" + filepath.Ext(path)
+	if err := p.embedReplayGain(path, tmpPath, correctionDB, tags, params); err != nil {
+		return nil, fmt.Errorf("replaygain embedding: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return nil, fmt.Errorf("failed to finalize replaygain output: %w", err)
+	}
+
+	return tags, nil
+}
+
+// ProcessAlbum encodes each track in tracks with params (which must have
+// ReplayGain enabled), measures every track's loudness, then applies ONE
+// shared album-level corrective gain to every track - rather than
+// normalizing each one individually - so the relative loudness between
+// tracks in the batch is preserved. Each output still gets its own
+// REPLAYGAIN_TRACK_*/R128_TRACK_GAIN tags (from that track's own
+// measurement) alongside the shared REPLAYGAIN_ALBUM_*/R128_ALBUM_GAIN
+// tags, so players without album-mode support still normalize sanely.
+func (p *PostProcessAgent) ProcessAlbum(tracks []AlbumTrack, params *PostProcessParams) ([]*PostProcessResult, error) {
+	if !params.ReplayGain {
+		return nil, fmt.Errorf("ProcessAlbum requires PostProcessParams.ReplayGain to be set")
+	}
+
+	renderParams := *params
+	renderParams.ReplayGain = false
+	renderParams.AlbumMode = false
+
+	analyses := make([]*LoudnessAnalysis, len(tracks))
+	for i, track := range tracks {
+		if _, err := p.Process(track.InputPath, track.OutputPath, &renderParams); err != nil {
+			return nil, fmt.Errorf("track %d (%s) encoding failed: %w", i, track.InputPath, err)
+		}
+		analysis, err := p.analyzeLoudness(track.OutputPath)
+		if err != nil {
+			return nil, fmt.Errorf("track %d (%s) loudness analysis failed: %w", i, track.InputPath, err)
+		}
+		analyses[i] = analysis
+	}
+
+	trackLUFS := make([]float64, len(analyses))
+	albumPeak := 0.0
+	for i, a := range analyses {
+		trackLUFS[i] = a.IntegratedLUFS
+		if peak := math.Pow(10, a.TruePeakDBFS/20); peak > albumPeak {
+			albumPeak = peak
+		}
+	}
+	albumLUFS := albumIntegratedLUFS(trackLUFS)
+	albumCorrectionDB := params.LoudnessLUFS - albumLUFS
+
+	albumParams := *params
+	albumParams.AlbumMode = true
+
+	results := make([]*PostProcessResult, len(tracks))
+	for i, track := range tracks {
+		tags := computeReplayGainTags(analyses[i])
+		tags.AlbumGainDB = replayGainReferenceLUFS - albumLUFS
+		tags.AlbumPeak = albumPeak
+		tags.R128AlbumGain = int(math.Round((r128ReferenceLUFS - albumLUFS) * 256))
+
+		tmpPath := track.OutputPath + ".rg" + filepath.Ext(track.OutputPath)
+		if err := p.embedReplayGain(track.OutputPath, tmpPath, albumCorrectionDB, tags, &albumParams); err != nil {
+			return nil, fmt.Errorf("track %d (%s) replaygain embedding failed: %w", i, track.OutputPath, err)
+		}
+		if err := os.Rename(tmpPath, track.OutputPath); err != nil {
+			return nil, fmt.Errorf("track %d (%s) finalize failed: %w", i, track.OutputPath, err)
+		}
+
+		fileInfo, err := os.Stat(track.OutputPath)
+		if err != nil {
+			return nil, fmt.Errorf("track %d (%s) stat failed: %w", i, track.OutputPath, err)
+		}
+		results[i] = &PostProcessResult{
+			OutputPath: track.OutputPath,
+			Format:     params.Format,
+			SampleRate: params.SampleRate,
+			Channels:   1,
+			FileSize:   fileInfo.Size(),
+			ReplayGain: tags,
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,93 @@
+package agents
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ModelResolver resolves a catalog voice ID to a local, verified model
+// file path, downloading it via VoiceInstaller (trying SourceURL, then
+// each of Voice.Mirrors in order) if it isn't already cached. It exists
+// so callers that just want "give me a usable path for this voice ID"
+// (EnvironmentAgent.Fetch, and eventually SynthAgent) don't each need to
+// re-implement the install-if-missing-then-verify dance.
+type ModelResolver struct {
+	catalog   *VoiceCatalogAgent
+	installer *VoiceInstaller
+	offline   bool
+}
+
+// NewModelResolver creates a resolver over an already-loaded catalog.
+func NewModelResolver(catalog *VoiceCatalogAgent) *ModelResolver {
+	return &ModelResolver{
+		catalog:   catalog,
+		installer: NewVoiceInstaller(catalog),
+	}
+}
+
+// SetOffline makes Resolve refuse to download anything not already
+// cached locally, returning an error instead.
+func (m *ModelResolver) SetOffline(offline bool) { m.offline = offline }
+
+// Resolve returns the local path to voiceID's model file. If the file
+// is missing or fails SHA-256 verification, it is downloaded (unless
+// offline mode is set, in which case Resolve fails instead).
+func (m *ModelResolver) Resolve(voiceID string) (string, error) {
+	voice, err := m.findVoice(voiceID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := m.catalog.ValidateVoiceFile(voice); err == nil {
+		return voice.Path, nil
+	}
+
+	if m.offline {
+		return "", fmt.Errorf("offline mode: voice %s is not cached locally", voiceID)
+	}
+
+	if err := m.installer.Download(voice, DownloadOptions{}); err != nil {
+		return "", fmt.Errorf("failed to resolve voice %s: %w", voiceID, err)
+	}
+
+	return voice.Path, nil
+}
+
+// Verify checks localPath's contents against expectedSHA256. An empty
+// expectedSHA256 is treated as "nothing to verify against" and always
+// passes, matching VoiceCatalogAgent.ValidateVoiceFile's handling of
+// catalog entries recorded before a hash was known.
+func (m *ModelResolver) Verify(localPath, expectedSHA256 string) error {
+	if expectedSHA256 == "" {
+		return nil
+	}
+
+	file, err := os.Open(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", localPath, err)
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return fmt.Errorf("failed to hash %s: %w", localPath, err)
+	}
+
+	if actual := fmt.Sprintf("%x", hasher.Sum(nil)); actual != expectedSHA256 {
+		return fmt.Errorf("%s hash mismatch: expected %s, got %s", localPath, expectedSHA256, actual)
+	}
+
+	return nil
+}
+
+func (m *ModelResolver) findVoice(voiceID string) (*Voice, error) {
+	for _, voice := range m.catalog.GetAvailableVoices() {
+		if voice.ID == voiceID {
+			v := voice
+			return &v, nil
+		}
+	}
+	return nil, fmt.Errorf("voice ID %s not found in catalog", voiceID)
+}
@@ -1,17 +1,45 @@
 package agents
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"regexp"
-	"strconv"
+	"sort"
 	"strings"
+	"time"
+
+	"studiospeech/internal/agents/ssml"
 )
 
 // NormalizedText represents processed text ready for synthesis
 type NormalizedText struct {
 	Sentences []string
-	Language  string
-	Metadata  map[string]interface{}
+	// SentenceIDs holds one stable ID per entry in Sentences (same
+	// index), derived only from the sentence's own text. It doesn't
+	// change when earlier or later sentences are edited, so a
+	// SegmentedWriter can use it as a cache key that survives a re-run
+	// where only one sentence changed.
+	SentenceIDs []string
+	Language    string
+	Metadata    map[string]interface{}
+}
+
+// SentenceID returns the stable ID Normalize/NormalizeSSML assign to a
+// sentence with this exact text, used as the cache key SegmentedWriter
+// hashes together with voice and rate to decide whether a segment needs
+// resynthesizing.
+func SentenceID(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return fmt.Sprintf("%x", sum[:8])
+}
+
+// sentenceIDs maps SentenceID over sentences.
+func sentenceIDs(sentences []string) []string {
+	ids := make([]string, len(sentences))
+	for i, s := range sentences {
+		ids[i] = SentenceID(s)
+	}
+	return ids
 }
 
 // NormalizeAgent handles text cleanup and prosody preparation
@@ -67,30 +95,31 @@ func (n *NormalizeAgent) Normalize(content *TextContent) (*NormalizedText, error
 	}
 
 	var allSentences []string
-	
+
 	for _, paragraph := range content.Paragraphs {
 		// Clean and normalize the paragraph
 		cleaned := n.cleanText(paragraph)
-		
+
 		// Expand abbreviations based on language
 		expanded := n.expandAbbreviations(cleaned, content.Language)
-		
+
 		// Expand numbers to words
 		withNumbers := n.expandNumbers(expanded, content.Language)
-		
+
 		// Split into sentences
 		sentences := n.splitIntoSentences(withNumbers)
-		
+
 		allSentences = append(allSentences, sentences...)
 	}
 
 	return &NormalizedText{
-		Sentences: allSentences,
-		Language:  content.Language,
+		Sentences:   allSentences,
+		SentenceIDs: sentenceIDs(allSentences),
+		Language:    content.Language,
 		Metadata: map[string]interface{}{
 			"original_paragraphs": len(content.Paragraphs),
 			"total_sentences":     len(allSentences),
-			"word_count":         content.WordCount,
+			"word_count":          content.WordCount,
 		},
 	}, nil
 }
@@ -100,47 +129,83 @@ func (n *NormalizeAgent) cleanText(text string) string {
 	// Normalize dashes
 	text = strings.ReplaceAll(text, "—", " - ")
 	text = strings.ReplaceAll(text, "–", " - ")
-	
+
 	// Normalize multiple spaces
 	spaceRegex := regexp.MustCompile(`\s+`)
 	text = spaceRegex.ReplaceAllString(text, " ")
-	
+
 	return strings.TrimSpace(text)
 }
 
-// expandAbbreviations replaces common abbreviations with full words
+// expandAbbreviations replaces common abbreviations with full words,
+// using the LanguageRegistry's profile for language when one is
+// registered (see language_*.go) and falling back to the built-in
+// English/Greek maps otherwise.
 func (n *NormalizeAgent) expandAbbreviations(text, language string) string {
-	var abbrevs map[string]string
-	
-	switch language {
-	case "el-GR":
+	abbrevs := n.englishAbbrevs
+	switch {
+	case languageHasAbbreviations(language):
+		profile, _ := ResolveLanguage(language)
+		abbrevs = profile.Abbreviations
+	case language == "el-GR":
 		abbrevs = n.greekAbbrevs
-	default:
-		abbrevs = n.englishAbbrevs
 	}
-	
-	for abbrev, expansion := range abbrevs {
+
+	for _, abbrev := range sortedAbbreviationKeys(abbrevs) {
 		// Simple string replacement for abbreviations
-		text = strings.ReplaceAll(text, abbrev, expansion)
+		text = strings.ReplaceAll(text, abbrev, abbrevs[abbrev])
 	}
-	
+
 	return text
 }
 
-// expandNumbers converts digits to words for better pronunciation
+// sortedAbbreviationKeys returns abbrevs' keys longest-first (ties broken
+// alphabetically for determinism), so a shorter abbreviation that's a
+// textual prefix of a longer one (Italian "Sig." vs "Sig.ra") never gets
+// replaced first and corrupts the longer match.
+func sortedAbbreviationKeys(abbrevs map[string]string) []string {
+	keys := make([]string, 0, len(abbrevs))
+	for abbrev := range abbrevs {
+		keys = append(keys, abbrev)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// languageHasAbbreviations reports whether the LanguageRegistry has a
+// profile registered for language with a non-empty Abbreviations map.
+func languageHasAbbreviations(language string) bool {
+	profile, ok := ResolveLanguage(language)
+	return ok && len(profile.Abbreviations) > 0
+}
+
+// expandNumbers converts numeric text (cardinals, ordinals, years,
+// decimals, currency, percentages, times, phone numbers, and common
+// units) to words for better pronunciation. It delegates to the
+// LanguageRegistry profile's NumberExpander when one is registered for
+// language, falling back to the small 0-20 lookup table below for
+// languages that have no registered expander.
 func (n *NormalizeAgent) expandNumbers(text, language string) string {
+	if profile, ok := ResolveLanguage(language); ok && profile.NumberExpander != nil {
+		return profile.NumberExpander.Expand(text)
+	}
+
 	var numbers map[string]string
-	
 	switch language {
 	case "el-GR":
 		numbers = n.greekNumbers
 	default:
 		numbers = n.englishNumbers
 	}
-	
+
 	// Find standalone numbers (not part of larger numbers or dates)
 	numberRegex := regexp.MustCompile(`\b(\d{1,2})\b`)
-	
+
 	text = numberRegex.ReplaceAllStringFunc(text, func(match string) string {
 		num := strings.TrimSpace(match)
 		if expansion, exists := numbers[num]; exists {
@@ -148,7 +213,7 @@ func (n *NormalizeAgent) expandNumbers(text, language string) string {
 		}
 		return match // Keep original if no expansion found
 	})
-	
+
 	return text
 }
 
@@ -156,47 +221,79 @@ func (n *NormalizeAgent) expandNumbers(text, language string) string {
 func (n *NormalizeAgent) splitIntoSentences(text string) []string {
 	// Simple sentence splitting on common punctuation
 	sentenceRegex := regexp.MustCompile(`[.!?]+\s+`)
-	
+
 	// Split and clean up
 	parts := sentenceRegex.Split(text, -1)
 	var sentences []string
-	
+
 	for _, part := range parts {
 		sentence := strings.TrimSpace(part)
 		if sentence != "" {
 			// Ensure sentence ends with punctuation
-			if !strings.HasSuffix(sentence, ".") && 
-			   !strings.HasSuffix(sentence, "!") && 
-			   !strings.HasSuffix(sentence, "?") {
+			if !strings.HasSuffix(sentence, ".") &&
+				!strings.HasSuffix(sentence, "!") &&
+				!strings.HasSuffix(sentence, "?") {
 				sentence += "."
 			}
 			sentences = append(sentences, sentence)
 		}
 	}
-	
+
 	return sentences
 }
 
-// ProcessPauseMarkup handles optional pause markup like [PAUSE=300ms]
-func (n *NormalizeAgent) ProcessPauseMarkup(text string) string {
-	// Convert pause markup to sentence breaks for Piper
-	pauseRegex := regexp.MustCompile(`\[PAUSE=(\d+)ms\]`)
-	
-	return pauseRegex.ReplaceAllStringFunc(text, func(match string) string {
-		// Extract duration
-		matches := pauseRegex.FindStringSubmatch(match)
-		if len(matches) > 1 {
-			if duration, err := strconv.Atoi(matches[1]); err == nil {
-				// Convert to appropriate punctuation based on duration
-				if duration >= 500 {
-					return ". " // Long pause - sentence break
-				} else if duration >= 200 {
-					return ", " // Medium pause - comma
-				}
-			}
+// RenderSegment pairs one ssml.Segment's normalized text (nil for a
+// break) with the per-segment break duration / prosody / voice / emphasis
+// overrides the ssml package recorded, ready for SynthAgent.SynthesizeSSML
+// and SynthAgent.SynthesizeSSMLCrossfade.
+type RenderSegment struct {
+	Normalized *NormalizedText
+	Break      time.Duration
+	Speed      *float64
+	VoiceName  string
+	Emphasis   string
+}
+
+// NormalizeSSML normalizes every text segment of doc the same way
+// Normalize does for a TextContent's paragraphs (cleanup, abbreviation
+// and number expansion, sentence splitting), while preserving the
+// per-segment break/prosody/voice overrides doc carries.
+func (n *NormalizeAgent) NormalizeSSML(doc *ssml.Document, language string) ([]RenderSegment, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("ssml document is nil")
+	}
+
+	segments := make([]RenderSegment, 0, len(doc.Segments))
+	for _, seg := range doc.Segments {
+		if seg.Break > 0 {
+			segments = append(segments, RenderSegment{Break: seg.Break})
+			continue
 		}
-		return " " // Short pause - space
-	})
+
+		cleaned := n.cleanText(seg.Text)
+		expanded := n.expandAbbreviations(cleaned, language)
+		withNumbers := n.expandNumbers(expanded, language)
+		sentences := n.splitIntoSentences(withNumbers)
+		if len(sentences) == 0 {
+			continue
+		}
+
+		segments = append(segments, RenderSegment{
+			Normalized: &NormalizedText{Sentences: sentences, SentenceIDs: sentenceIDs(sentences), Language: language},
+			Speed:      seg.Speed,
+			VoiceName:  seg.VoiceName,
+			Emphasis:   seg.Emphasis,
+		})
+	}
+
+	return segments, nil
+}
+
+// ProcessPauseMarkup lowers the "[PAUSE=Xms]" shortcut into the same
+// ssml.Document/Segment representation full SSML markup parses to, so
+// SynthAgent.SynthesizeSSML can render either one identically.
+func (n *NormalizeAgent) ProcessPauseMarkup(text string) *ssml.Document {
+	return ssml.ParsePauseMarkup(text)
 }
 
 // ValidateNormalizedText checks if normalized text is ready for synthesis
@@ -204,21 +301,21 @@ func (n *NormalizeAgent) ValidateNormalizedText(normalized *NormalizedText) erro
 	if normalized == nil {
 		return fmt.Errorf("normalized text is nil")
 	}
-	
+
 	if len(normalized.Sentences) == 0 {
 		return fmt.Errorf("no sentences found after normalization")
 	}
-	
+
 	// Check for reasonable sentence lengths
 	for i, sentence := range normalized.Sentences {
 		if len(sentence) > 500 {
 			return fmt.Errorf("sentence %d too long: %d characters (max 500)", i, len(sentence))
 		}
-		
+
 		if strings.TrimSpace(sentence) == "" {
 			return fmt.Errorf("sentence %d is empty", i)
 		}
 	}
-	
+
 	return nil
-}
\ No newline at end of file
+}
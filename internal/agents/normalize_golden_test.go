@@ -0,0 +1,21 @@
+package agents_test
+
+import (
+	"testing"
+
+	"studiospeech/internal/agents/normalizetest"
+)
+
+// TestNormalizeAgent_Golden drives NormalizeAgent.Normalize against the
+// .txtar fixtures under testdata/normalize, covering every registered
+// language plus abbreviation/number edge cases that would be too noisy
+// to keep as Go literals. Run with -update to regenerate
+// expected-sentences after an intentional behavior change.
+//
+// This lives in package agents_test (rather than alongside the rest of
+// normalize_test.go) because normalizetest itself imports agents, and a
+// package-agents test file can't import anything that imports agents
+// back without creating an import cycle.
+func TestNormalizeAgent_Golden(t *testing.T) {
+	normalizetest.Run(t, "testdata/normalize")
+}
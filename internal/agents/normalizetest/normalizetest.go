@@ -0,0 +1,166 @@
+// Package normalizetest is a golden-file test harness for
+// agents.NormalizeAgent, modeled on
+// golang.org/x/tools/go/analysis/analysistest: each test case is a
+// .txtar archive under a fixture directory rather than a Go literal, so
+// growing coverage with another locale or edge case is adding a file,
+// not editing a table.
+//
+// A fixture's sections are:
+//
+//	-- input --                     required; one or more paragraphs,
+//	                                 separated by a blank line
+//	-- lang --                      optional; defaults to "en-US"
+//	-- expected-sentences --        required; Normalize's output
+//	                                 sentences, one per line
+//	-- expected-abbrev-expansions -- optional; substrings (e.g. expanded
+//	                                 abbreviations or numbers) that must
+//	                                 appear somewhere in the output, one
+//	                                 per line
+package normalizetest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/txtar"
+
+	"studiospeech/internal/agents"
+)
+
+// update rewrites every fixture's expected-sentences section from
+// NormalizeAgent's current output when set via "go test -update".
+var update = flag.Bool("update", false, "rewrite each fixture's expected-sentences txtar section from current output")
+
+// Run walks every *.txtar file directly under dir and checks
+// agents.NormalizeAgent.Normalize against that fixture's expected
+// sections, registering one subtest per file (named after the file,
+// minus its .txtar extension).
+func Run(t *testing.T, dir string) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.txtar"))
+	if err != nil {
+		t.Fatalf("failed to list %s: %v", dir, err)
+	}
+	if len(matches) == 0 {
+		t.Fatalf("no .txtar fixtures found under %s", dir)
+	}
+
+	for _, path := range matches {
+		path := path
+		name := strings.TrimSuffix(filepath.Base(path), ".txtar")
+		t.Run(name, func(t *testing.T) {
+			runCase(t, path)
+		})
+	}
+}
+
+func runCase(t *testing.T, path string) {
+	archive, err := txtar.ParseFile(path)
+	if err != nil {
+		t.Fatalf("failed to parse %s: %v", path, err)
+	}
+
+	input, ok := section(archive, "input")
+	if !ok {
+		t.Fatalf("%s: missing \"-- input --\" section", path)
+	}
+
+	lang := strings.TrimSpace(firstOr(archive, "lang", ""))
+	if lang == "" {
+		lang = "en-US"
+	}
+
+	content := &agents.TextContent{
+		Paragraphs: splitParagraphs(input),
+		Language:   lang,
+	}
+
+	normalized, err := agents.NewNormalizeAgent().Normalize(content)
+	if err != nil {
+		t.Fatalf("%s: Normalize() error = %v", path, err)
+	}
+
+	got := strings.Join(normalized.Sentences, "\n") + "\n"
+
+	if *update {
+		setSection(archive, "expected-sentences", got)
+		if err := os.WriteFile(path, txtar.Format(archive), 0644); err != nil {
+			t.Fatalf("failed to rewrite %s: %v", path, err)
+		}
+		return
+	}
+
+	want, ok := section(archive, "expected-sentences")
+	if !ok {
+		t.Fatalf("%s: missing \"-- expected-sentences --\" section (run with -update to generate one)", path)
+	}
+	if got != ensureTrailingNewline(want) {
+		t.Errorf("%s: sentences mismatch\n got:\n%s\nwant:\n%s", path, got, want)
+	}
+
+	if expansions, ok := section(archive, "expected-abbrev-expansions"); ok {
+		for _, line := range strings.Split(strings.TrimSpace(expansions), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			if !strings.Contains(got, line) {
+				t.Errorf("%s: expected expansion %q not found in normalized output:\n%s", path, line, got)
+			}
+		}
+	}
+}
+
+// section returns the named file's content as a string, and whether it
+// was present at all.
+func section(archive *txtar.Archive, name string) (string, bool) {
+	for _, f := range archive.Files {
+		if f.Name == name {
+			return string(f.Data), true
+		}
+	}
+	return "", false
+}
+
+// firstOr returns section(archive, name)'s content, or fallback if the
+// section is absent.
+func firstOr(archive *txtar.Archive, name, fallback string) string {
+	if data, ok := section(archive, name); ok {
+		return data
+	}
+	return fallback
+}
+
+// setSection overwrites the named file's content, appending a new file
+// to archive if it isn't already present.
+func setSection(archive *txtar.Archive, name, data string) {
+	for i, f := range archive.Files {
+		if f.Name == name {
+			archive.Files[i].Data = []byte(data)
+			return
+		}
+	}
+	archive.Files = append(archive.Files, txtar.File{Name: name, Data: []byte(data)})
+}
+
+// splitParagraphs splits input on blank lines into TextContent.Paragraphs,
+// trimming surrounding whitespace from each one and dropping empties.
+func splitParagraphs(input string) []string {
+	var paragraphs []string
+	for _, block := range strings.Split(input, "\n\n") {
+		block = strings.TrimSpace(block)
+		if block != "" {
+			paragraphs = append(paragraphs, block)
+		}
+	}
+	return paragraphs
+}
+
+func ensureTrailingNewline(s string) string {
+	if strings.HasSuffix(s, "\n") {
+		return s
+	}
+	return s + "\n"
+}
@@ -0,0 +1,377 @@
+package numwords
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	Register("en-US", &English{})
+	Register("en-UK", &English{})
+}
+
+// English expands numeric text for English-language synthesis.
+type English struct{}
+
+var (
+	enTimeRe     = regexp.MustCompile(`\b([01]?\d|2[0-3]):([0-5]\d)\b`)
+	enPhoneRe    = regexp.MustCompile(`\b\d{3}[-.]\d{3,4}(?:[-.]\d{4})?\b`)
+	enRangeRe    = regexp.MustCompile(`\b(\d+)\s*-\s*(\d+)\b`)
+	enCurrencyRe = regexp.MustCompile(`([$€£])(\d+(?:\.\d{1,2})?)`)
+	enPercentRe  = regexp.MustCompile(`\b(\d+(?:\.\d+)?)%`)
+	enUnitRe     = regexp.MustCompile(`\b(\d+(?:\.\d+)?)(kg|km|cm|mm|ml|lb|kb|mb|gb|m|g|l)\b`)
+	enDecimalRe  = regexp.MustCompile(`-?\b\d+\.\d+\b`)
+	enOrdinalRe  = regexp.MustCompile(`\b(\d+)(st|nd|rd|th)\b`)
+	enYearRe     = regexp.MustCompile(`\b(1[0-9]{3}|20[0-9]{2})\b`)
+	enLeadingRe  = regexp.MustCompile(`\b0\d+\b`)
+	enCardinalRe = regexp.MustCompile(`-?\b\d+\b`)
+)
+
+var enUnitNames = map[string]string{
+	"kg": "kilogram", "km": "kilometer", "cm": "centimeter", "mm": "millimeter",
+	"ml": "milliliter", "lb": "pound", "kb": "kilobyte", "mb": "megabyte",
+	"gb": "gigabyte", "m": "meter", "g": "gram", "l": "liter",
+}
+
+var enCurrencyNames = map[string]struct{ Unit, Subunit string }{
+	"$": {"dollar", "cent"},
+	"€": {"euro", "cent"},
+	"£": {"pound", "penny"},
+}
+
+var enOnes = []string{
+	"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten",
+	"eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen",
+}
+var enTens = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+var enScales = []string{"", "thousand", "million", "billion", "trillion", "quadrillion"}
+
+var enOrdinalExceptions = map[string]string{
+	"one": "first", "two": "second", "three": "third", "five": "fifth",
+	"eight": "eighth", "nine": "ninth", "twelve": "twelfth",
+}
+
+// Expand implements Expander. Stages run most-specific-first so later,
+// more general stages (the plain cardinal catch-all) only ever see digit
+// sequences no earlier stage recognized.
+func (e *English) Expand(text string) string {
+	text = enTimeRe.ReplaceAllStringFunc(text, expandEnTime)
+	text = enPhoneRe.ReplaceAllStringFunc(text, expandEnDigitByDigit)
+	text = enRangeRe.ReplaceAllStringFunc(text, expandEnRange)
+	text = enCurrencyRe.ReplaceAllStringFunc(text, expandEnCurrency)
+	text = enPercentRe.ReplaceAllStringFunc(text, expandEnPercent)
+	text = enUnitRe.ReplaceAllStringFunc(text, expandEnUnit)
+	text = enDecimalRe.ReplaceAllStringFunc(text, expandEnDecimal)
+	text = enOrdinalRe.ReplaceAllStringFunc(text, expandEnOrdinal)
+	text = enYearRe.ReplaceAllStringFunc(text, expandEnYear)
+	text = enLeadingRe.ReplaceAllStringFunc(text, expandEnDigitByDigit)
+	text = enCardinalRe.ReplaceAllStringFunc(text, expandEnCardinal)
+	return text
+}
+
+func expandEnCardinal(match string) string {
+	n, err := strconv.ParseInt(match, 10, 64)
+	if err != nil {
+		return match // out of int64 range; leave as-is rather than guess
+	}
+	return enCardinalWords(n)
+}
+
+func expandEnDigitByDigit(match string) string {
+	var words []string
+	for _, r := range match {
+		if r >= '0' && r <= '9' {
+			words = append(words, enOnes[r-'0'])
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func expandEnRange(match string) string {
+	groups := enRangeRe.FindStringSubmatch(match)
+	from, errA := strconv.ParseInt(groups[1], 10, 64)
+	to, errB := strconv.ParseInt(groups[2], 10, 64)
+	if errA != nil || errB != nil {
+		return match
+	}
+	return enCardinalWords(from) + " to " + enCardinalWords(to)
+}
+
+func expandEnCurrency(match string) string {
+	groups := enCurrencyRe.FindStringSubmatch(match)
+	symbol, amount := groups[1], groups[2]
+	names, ok := enCurrencyNames[symbol]
+	if !ok {
+		return match
+	}
+
+	parts := strings.SplitN(amount, ".", 2)
+	whole, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return match
+	}
+
+	wholeWords := enCardinalWords(whole) + " " + enPluralize(names.Unit, whole)
+	if len(parts) == 1 {
+		return wholeWords
+	}
+
+	fraction := parts[1]
+	if len(fraction) == 1 {
+		fraction += "0"
+	}
+	cents, err := strconv.ParseInt(fraction, 10, 64)
+	if err != nil || cents == 0 {
+		return wholeWords
+	}
+	return wholeWords + " and " + enCardinalWords(cents) + " " + enPluralize(names.Subunit, cents)
+}
+
+func expandEnPercent(match string) string {
+	groups := enPercentRe.FindStringSubmatch(match)
+	value := groups[1]
+	if strings.Contains(value, ".") {
+		return enDecimalWords(value) + " percent"
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return match
+	}
+	return enCardinalWords(n) + " percent"
+}
+
+func expandEnUnit(match string) string {
+	groups := enUnitRe.FindStringSubmatch(match)
+	value, unit := groups[1], groups[2]
+	name, ok := enUnitNames[unit]
+	if !ok {
+		return match
+	}
+
+	var words, plural string
+	if strings.Contains(value, ".") {
+		words = enDecimalWords(value)
+		plural = name + "s"
+	} else {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return match
+		}
+		words = enCardinalWords(n)
+		plural = enPluralize(name, n)
+	}
+	return words + " " + plural
+}
+
+func expandEnDecimal(match string) string {
+	return enDecimalWords(match)
+}
+
+func expandEnOrdinal(match string) string {
+	groups := enOrdinalRe.FindStringSubmatch(match)
+	n, err := strconv.ParseInt(groups[1], 10, 64)
+	if err != nil {
+		return match
+	}
+	return enOrdinalWords(n)
+}
+
+func expandEnYear(match string) string {
+	n, err := strconv.ParseInt(match, 10, 64)
+	if err != nil {
+		return match
+	}
+	return enYearWords(n)
+}
+
+func expandEnTime(match string) string {
+	groups := enTimeRe.FindStringSubmatch(match)
+	hour, _ := strconv.Atoi(groups[1])
+	minute, _ := strconv.Atoi(groups[2])
+	return enTimeWords(hour, minute)
+}
+
+// enCardinalWords spells out an arbitrary integer (magnitude up to
+// 999 quadrillion, i.e. within int64 range) as English words.
+func enCardinalWords(n int64) string {
+	if n == 0 {
+		return "zero"
+	}
+	neg := n < 0
+	if neg {
+		n = -n
+	}
+
+	var groups []int64
+	for n > 0 {
+		groups = append(groups, n%1000)
+		n /= 1000
+	}
+
+	var parts []string
+	for i := len(groups) - 1; i >= 0; i-- {
+		g := groups[i]
+		if g == 0 {
+			continue
+		}
+		words := enThreeDigitWords(g)
+		if i < len(enScales) && enScales[i] != "" {
+			words += " " + enScales[i]
+		}
+		parts = append(parts, words)
+	}
+
+	result := strings.Join(parts, " ")
+	if neg {
+		result = "negative " + result
+	}
+	return result
+}
+
+func enThreeDigitWords(n int64) string {
+	var parts []string
+	hundreds := n / 100
+	rem := n % 100
+	if hundreds > 0 {
+		parts = append(parts, enOnes[hundreds]+" hundred")
+	}
+	if rem > 0 {
+		parts = append(parts, enTwoDigitWords(rem))
+	}
+	return strings.Join(parts, " ")
+}
+
+func enTwoDigitWords(n int64) string {
+	if n < 20 {
+		return enOnes[n]
+	}
+	tens := n / 10
+	ones := n % 10
+	if ones == 0 {
+		return enTens[tens]
+	}
+	return enTens[tens] + "-" + enOnes[ones]
+}
+
+// enOrdinalWords spells out n as an ordinal by taking its cardinal form
+// and converting only the final word/hyphenated-suffix, e.g. "twenty-one"
+// -> "twenty-first", "twelve" -> "twelfth".
+func enOrdinalWords(n int64) string {
+	words := enCardinalWords(n)
+	idx := strings.LastIndex(words, " ")
+	prefix, last := "", words
+	if idx >= 0 {
+		prefix, last = words[:idx+1], words[idx+1:]
+	}
+
+	if hyphen := strings.LastIndex(last, "-"); hyphen >= 0 {
+		last = last[:hyphen+1] + enOrdinalSuffix(last[hyphen+1:])
+	} else {
+		last = enOrdinalSuffix(last)
+	}
+	return prefix + last
+}
+
+func enOrdinalSuffix(word string) string {
+	if repl, ok := enOrdinalExceptions[word]; ok {
+		return repl
+	}
+	if strings.HasSuffix(word, "y") {
+		return strings.TrimSuffix(word, "y") + "ieth"
+	}
+	return word + "th"
+}
+
+// enYearWords spells out a calendar year the way it is conventionally
+// read aloud (split into two two-digit groups), falling back to a plain
+// cardinal outside the conventional 1100-2099 range.
+func enYearWords(n int64) string {
+	switch {
+	case n >= 1100 && n < 2000:
+		first, second := n/100, n%100
+		if second == 0 {
+			return enCardinalWords(first) + " hundred"
+		}
+		return enTwoDigitWords(first) + " " + enYearGroupWords(second)
+	case n >= 2000 && n < 2100:
+		second := n % 100
+		if second == 0 {
+			return enCardinalWords(n/1000) + " thousand"
+		}
+		return "twenty " + enYearGroupWords(second)
+	default:
+		return enCardinalWords(n)
+	}
+}
+
+func enYearGroupWords(n int64) string {
+	if n < 10 {
+		return "oh " + enOnes[n]
+	}
+	return enTwoDigitWords(n)
+}
+
+// enDecimalWords spells a "3.14"-shaped string as "three point one four",
+// reading the fractional digits one at a time so trailing zeros aren't
+// silently lost.
+func enDecimalWords(s string) string {
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	parts := strings.SplitN(s, ".", 2)
+	whole, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return s
+	}
+
+	var digitWords []string
+	for _, r := range parts[1] {
+		if r >= '0' && r <= '9' {
+			digitWords = append(digitWords, enOnes[r-'0'])
+		}
+	}
+
+	result := fmt.Sprintf("%s point %s", enCardinalWords(whole), strings.Join(digitWords, " "))
+	if neg {
+		result = "negative " + result
+	}
+	return result
+}
+
+// enTimeWords renders a 24-hour hour/minute pair the way a 12-hour clock
+// announcement is spoken, e.g. (14, 30) -> "two thirty PM".
+func enTimeWords(hour, minute int) string {
+	period := "AM"
+	h := hour
+	switch {
+	case hour == 0:
+		h = 12
+	case hour == 12:
+		period = "PM"
+	case hour > 12:
+		h = hour - 12
+		period = "PM"
+	}
+
+	var minuteWords string
+	switch {
+	case minute == 0:
+		minuteWords = "o'clock"
+	case minute < 10:
+		minuteWords = "oh " + enOnes[minute]
+	default:
+		minuteWords = enTwoDigitWords(int64(minute))
+	}
+
+	return fmt.Sprintf("%s %s %s", enTwoDigitWords(int64(h)), minuteWords, period)
+}
+
+// enPluralize appends "s" unless n is exactly 1 (or -1).
+func enPluralize(word string, n int64) string {
+	if n == 1 || n == -1 {
+		return word
+	}
+	return word + "s"
+}
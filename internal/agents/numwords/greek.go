@@ -0,0 +1,212 @@
+package numwords
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	Register("el-GR", &Greek{})
+}
+
+// Greek expands numeric text for Greek-language synthesis. Cardinal forms
+// use the neuter gender (the form most commonly heard when a number is
+// read in isolation, e.g. counting or reading a quantity); Modern Greek
+// numerals otherwise agree in gender and case with whatever noun they
+// modify, which this package does not attempt to track.
+type Greek struct{}
+
+var (
+	grCurrencyRe = regexp.MustCompile(`([$€£])(\d+(?:\.\d{1,2})?)`)
+	grPercentRe  = regexp.MustCompile(`\b(\d+(?:\.\d+)?)%`)
+	grDecimalRe  = regexp.MustCompile(`-?\b\d+\.\d+\b`)
+	grOrdinalRe  = regexp.MustCompile(`\b(\d+)(η|ος|ο)`)
+	grCardinalRe = regexp.MustCompile(`-?\b\d+\b`)
+)
+
+var grOnes = []string{
+	"μηδέν", "ένα", "δύο", "τρία", "τέσσερα", "πέντε", "έξι", "επτά", "οκτώ", "εννέα", "δέκα",
+	"έντεκα", "δώδεκα", "δεκατρία", "δεκατέσσερα", "δεκαπέντε", "δεκαέξι", "δεκαεπτά", "δεκαοκτώ", "δεκαεννέα",
+}
+var grTens = []string{"", "", "είκοσι", "τριάντα", "σαράντα", "πενήντα", "εξήντα", "εβδομήντα", "ογδόντα", "ενενήντα"}
+var grHundreds = []string{
+	"", "εκατό", "διακόσια", "τριακόσια", "τετρακόσια", "πεντακόσια", "εξακόσια", "επτακόσια", "οκτακόσια", "εννιακόσια",
+}
+
+// grOrdinals covers the ordinals (neuter form) most likely to appear in
+// dates and lists; numbers outside this table fall back to their
+// cardinal form rather than guessing a declension.
+var grOrdinals = map[int64]string{
+	1: "πρώτο", 2: "δεύτερο", 3: "τρίτο", 4: "τέταρτο", 5: "πέμπτο",
+	6: "έκτο", 7: "έβδομο", 8: "όγδοο", 9: "ένατο", 10: "δέκατο",
+	11: "ενδέκατο", 12: "δωδέκατο", 20: "εικοστό", 30: "τριακοστό",
+}
+
+// Expand implements Expander.
+func (g *Greek) Expand(text string) string {
+	text = grCurrencyRe.ReplaceAllStringFunc(text, expandGrCurrency)
+	text = grPercentRe.ReplaceAllStringFunc(text, expandGrPercent)
+	text = grDecimalRe.ReplaceAllStringFunc(text, func(m string) string { return grDecimalWords(m) })
+	text = grOrdinalRe.ReplaceAllStringFunc(text, expandGrOrdinal)
+	text = grCardinalRe.ReplaceAllStringFunc(text, expandGrCardinal)
+	return text
+}
+
+func expandGrCardinal(match string) string {
+	n, err := strconv.ParseInt(match, 10, 64)
+	if err != nil {
+		return match
+	}
+	return grCardinalWords(n)
+}
+
+func expandGrOrdinal(match string) string {
+	groups := grOrdinalRe.FindStringSubmatch(match)
+	n, err := strconv.ParseInt(groups[1], 10, 64)
+	if err != nil {
+		return match
+	}
+	if word, ok := grOrdinals[n]; ok {
+		return word
+	}
+	return grCardinalWords(n)
+}
+
+func expandGrCurrency(match string) string {
+	groups := grCurrencyRe.FindStringSubmatch(match)
+	symbol, amount := groups[1], groups[2]
+	unit, ok := map[string]string{"€": "ευρώ", "$": "δολάρια", "£": "λίρες"}[symbol]
+	if !ok {
+		return match
+	}
+
+	parts := strings.SplitN(amount, ".", 2)
+	whole, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return match
+	}
+	wholeWords := grCardinalWords(whole) + " " + unit
+	if len(parts) == 1 {
+		return wholeWords
+	}
+
+	fraction := parts[1]
+	if len(fraction) == 1 {
+		fraction += "0"
+	}
+	cents, err := strconv.ParseInt(fraction, 10, 64)
+	if err != nil || cents == 0 {
+		return wholeWords
+	}
+	return wholeWords + " και " + grCardinalWords(cents) + " σεντ"
+}
+
+func expandGrPercent(match string) string {
+	groups := grPercentRe.FindStringSubmatch(match)
+	value := groups[1]
+	if strings.Contains(value, ".") {
+		return grDecimalWords(value) + " τοις εκατό"
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return match
+	}
+	return grCardinalWords(n) + " τοις εκατό"
+}
+
+// grCardinalWords spells out an arbitrary integer (magnitude up to
+// 999 quadrillion) in neuter-gender Greek words.
+func grCardinalWords(n int64) string {
+	if n == 0 {
+		return grOnes[0]
+	}
+	neg := n < 0
+	if neg {
+		n = -n
+	}
+
+	var groups []int64
+	for n > 0 {
+		groups = append(groups, n%1000)
+		n /= 1000
+	}
+
+	scaleSingular := []string{"", "χίλια", "εκατομμύριο", "δισεκατομμύριο", "τρισεκατομμύριο", "τετράκις εκατομμύριο"}
+	scalePlural := []string{"", "χιλιάδες", "εκατομμύρια", "δισεκατομμύρια", "τρισεκατομμύρια", "τετράκις εκατομμύρια"}
+
+	var parts []string
+	for i := len(groups) - 1; i >= 0; i-- {
+		g := groups[i]
+		if g == 0 {
+			continue
+		}
+		words := grThreeDigitWords(g)
+		switch {
+		case i == 0:
+			// units group, no scale word
+		case g == 1:
+			words = scaleSingular[i] // "χίλια", not "ένα χίλια"
+		default:
+			words += " " + scalePlural[i]
+		}
+		parts = append(parts, words)
+	}
+
+	result := strings.Join(parts, " ")
+	if neg {
+		result = "πλην " + result
+	}
+	return result
+}
+
+func grThreeDigitWords(n int64) string {
+	var parts []string
+	hundreds := n / 100
+	rem := n % 100
+	if hundreds > 0 {
+		parts = append(parts, grHundreds[hundreds])
+	}
+	if rem > 0 {
+		parts = append(parts, grTwoDigitWords(rem))
+	}
+	return strings.Join(parts, " ")
+}
+
+func grTwoDigitWords(n int64) string {
+	if n < 20 {
+		return grOnes[n]
+	}
+	tens := n / 10
+	ones := n % 10
+	if ones == 0 {
+		return grTens[tens]
+	}
+	return grTens[tens] + " " + grOnes[ones]
+}
+
+// grDecimalWords spells a "3.14"-shaped string as "τρία κόμμα ένα
+// τέσσερα", reading the fractional digits one at a time.
+func grDecimalWords(s string) string {
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	parts := strings.SplitN(s, ".", 2)
+	whole, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return s
+	}
+
+	var digitWords []string
+	for _, r := range parts[1] {
+		if r >= '0' && r <= '9' {
+			digitWords = append(digitWords, grOnes[r-'0'])
+		}
+	}
+
+	result := grCardinalWords(whole) + " κόμμα " + strings.Join(digitWords, " ")
+	if neg {
+		result = "πλην " + result
+	}
+	return result
+}
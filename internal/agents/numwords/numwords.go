@@ -0,0 +1,11 @@
+// Package numwords expands the numeric substrings of a piece of text -
+// cardinals, ordinals, years, decimals, currency, percentages, times,
+// phone numbers, and common SI units - into the words a TTS voice should
+// actually speak, on a per-language basis.
+package numwords
+
+// Expander turns the number-like tokens of text into their spoken-word
+// form, leaving everything else untouched.
+type Expander interface {
+	Expand(text string) string
+}
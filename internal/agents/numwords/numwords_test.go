@@ -0,0 +1,109 @@
+package numwords
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEnglish_Expand(t *testing.T) {
+	e := &English{}
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"cardinal", "I have 42 apples", "I have forty-two apples"},
+		{"large cardinal", "Population: 1500000", "Population: one million five hundred thousand"},
+		{"ordinal", "the 1st and 21st place", "the first and twenty-first place"},
+		{"year", "Released in 1975", "Released in nineteen seventy-five"},
+		{"year 2000s", "Back in 2023", "Back in twenty twenty-three"},
+		{"decimal", "Pi is about 3.14", "Pi is about three point one four"},
+		{"currency dollars", "It costs $4.50", "It costs four dollars and fifty cents"},
+		{"currency euro", "It costs €3", "It costs three euros"},
+		{"percent", "42% of users", "forty-two percent of users"},
+		{"time", "Meet at 14:30", "Meet at two thirty PM"},
+		{"phone", "Call 555-1234", "Call five five five one two three four"},
+		{"unit", "Carry 5kg of rice", "Carry five kilograms of rice"},
+		{"range", "pages 1-3", "pages one to three"},
+		{"negative", "it was -5 degrees", "it was negative five degrees"},
+		{"leading zero", "code 007", "code zero zero seven"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := e.Expand(tt.input); got != tt.want {
+				t.Errorf("Expand(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGreek_Expand(t *testing.T) {
+	g := &Greek{}
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"cardinal", "Έχω 42 μήλα", "Έχω σαράντα δύο μήλα"},
+		{"currency", "Κοστίζει €3", "Κοστίζει τρία ευρώ"},
+		{"percent", "42% των χρηστών", "σαράντα δύο τοις εκατό των χρηστών"},
+		{"decimal", "Το π είναι 3.14", "Το π είναι τρία κόμμα ένα τέσσερα"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := g.Expand(tt.input); got != tt.want {
+				t.Errorf("Expand(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistry_LooksUpByLanguage(t *testing.T) {
+	for _, lang := range []string{"en-US", "en-UK", "el-GR"} {
+		if _, ok := Get(lang); !ok {
+			t.Errorf("expected an Expander registered for %q", lang)
+		}
+	}
+	if _, ok := Get("fr-FR"); ok {
+		t.Errorf("did not expect an Expander registered for fr-FR")
+	}
+}
+
+// FuzzEnglishExpand exercises ambiguous inputs (leading zeros, negatives,
+// ranges, and other numeric-adjacent text) that historically trip up
+// naive digit-by-digit replacement, checking only the invariant that
+// Expand never panics and always terminates.
+func FuzzEnglishExpand(f *testing.F) {
+	seeds := []string{
+		"007",
+		"-5",
+		"1-3",
+		"1975",
+		"$4.50",
+		"3.14159",
+		"14:30",
+		"555-1234",
+		"42%",
+		"5kg",
+		"21st",
+		"",
+		"----",
+		"99999999999999999999999999999999", // overflows int64
+		"0-0",
+	}
+	for _, s := range seeds {
+		f.Add(s)
+	}
+
+	e := &English{}
+	f.Fuzz(func(t *testing.T, input string) {
+		if !strings.ContainsAny(input, "0123456789") {
+			return
+		}
+		_ = e.Expand(input)
+	})
+}
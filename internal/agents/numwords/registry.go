@@ -0,0 +1,25 @@
+package numwords
+
+import "sync"
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Expander{}
+)
+
+// Register adds e to the registry under language (a BCP-47 tag such as
+// "en-US" or "el-GR"). Each locale's own file calls this from init().
+func Register(language string, e Expander) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[language] = e
+}
+
+// Get looks up the registered Expander for language, returning ok=false
+// if no locale-specific expander is registered.
+func Get(language string) (Expander, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	e, ok := registry[language]
+	return e, ok
+}
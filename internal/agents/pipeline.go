@@ -0,0 +1,174 @@
+package agents
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Pipeline composes the synthesis stages (SynthAgent plus an optional
+// CacheAgent) a long-form run needs to produce segmented output.
+// Ingestion, normalization, voice selection, and post-processing stay
+// the caller's responsibility, same as executeSynthesisPipeline's
+// procedural flow in cmd/ttscli; Pipeline only owns the part that
+// differs once segmentation is in play: how synthesis output is split
+// and which segments can be skipped on a re-run.
+type Pipeline struct {
+	synthAgent   *SynthAgent
+	cacheAgent   *CacheAgent
+	segmentation *SegmentOptions
+}
+
+// NewPipeline creates a Pipeline around an already-configured SynthAgent.
+// cacheAgent may be nil, in which case SynthesizeSegmented always
+// resynthesizes every segment.
+func NewPipeline(synthAgent *SynthAgent, cacheAgent *CacheAgent) *Pipeline {
+	return &Pipeline{synthAgent: synthAgent, cacheAgent: cacheAgent}
+}
+
+// WithSegmentation configures Pipeline to split synthesis across rotating
+// output files per opts instead of producing a single file, returning p
+// so it can be chained onto NewPipeline.
+func (p *Pipeline) WithSegmentation(opts SegmentOptions) *Pipeline {
+	p.segmentation = &opts
+	return p
+}
+
+// SynthesizeSegmented synthesizes normalized into rotating
+// baseName-NNNN.ext files under outDir (ext taken from voice's backend
+// via SynthAgent, which always writes WAV) and a manifest.json
+// describing segment order, byte ranges, and sentence offsets. A segment
+// whose every sentence ID, combined with voice and params.Speed, still
+// matches a cached entry is copied from cache instead of resynthesized,
+// so editing one sentence in a book-length input only re-runs synthesis
+// for the segment(s) containing it.
+func (p *Pipeline) SynthesizeSegmented(normalized *NormalizedText, voice *Voice, params *SynthParams, outDir string) (*Manifest, error) {
+	if p.segmentation == nil {
+		return nil, fmt.Errorf("segmentation not configured: call WithSegmentation first")
+	}
+	if normalized == nil {
+		return nil, fmt.Errorf("normalized text is nil")
+	}
+	if voice == nil {
+		return nil, fmt.Errorf("voice is nil")
+	}
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	writer := NewSegmentedWriter(outDir, "output", ".wav", *p.segmentation)
+	plan := writer.Plan(normalized)
+
+	manifest := &Manifest{}
+	var byteOffset int64
+
+	for index, rng := range plan {
+		start, end := rng[0], rng[1]
+		segNormalized := &NormalizedText{
+			Sentences:   normalized.Sentences[start:end],
+			SentenceIDs: normalized.SentenceIDs[start:end],
+			Language:    normalized.Language,
+		}
+
+		segPath := writer.SegmentPath(index)
+		cacheKey := p.segmentCacheKey(segNormalized.SentenceIDs, voice.ID, params)
+
+		if !p.reuseCachedSegment(cacheKey, segPath) {
+			result, err := p.synthAgent.Synthesize(segNormalized, voice, params)
+			if err != nil {
+				return nil, fmt.Errorf("segment %d synthesis failed: %w", index, err)
+			}
+			// In dry-run mode SynthAgent reports an OutputPath without
+			// creating it (see SynthAgent.Synthesize); fall back to an
+			// empty placeholder so the manifest still gets a valid path.
+			if _, statErr := os.Stat(result.OutputPath); statErr == nil {
+				if err := copySegmentFile(result.OutputPath, segPath); err != nil {
+					return nil, fmt.Errorf("segment %d write failed: %w", index, err)
+				}
+			} else if err := os.WriteFile(segPath, nil, 0644); err != nil {
+				return nil, fmt.Errorf("segment %d placeholder write failed: %w", index, err)
+			}
+			if p.cacheAgent != nil && cacheKey != "" {
+				if err := p.cacheAgent.Put(cacheKey, segPath, nil); err != nil {
+					return nil, fmt.Errorf("segment %d caching failed: %w", index, err)
+				}
+			}
+		}
+
+		info, err := os.Stat(segPath)
+		if err != nil {
+			return nil, fmt.Errorf("segment %d missing after write: %w", index, err)
+		}
+
+		manifest.Segments = append(manifest.Segments, ManifestSegment{
+			Index:         index,
+			File:          segPath,
+			StartSentence: start,
+			EndSentence:   end,
+			ByteStart:     byteOffset,
+			ByteEnd:       byteOffset + info.Size(),
+			Voice:         voice.ID,
+			Language:      normalized.Language,
+		})
+		byteOffset += info.Size()
+	}
+
+	if err := writer.WriteManifest(manifest); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// segmentCacheKey combines every sentence ID in a segment with voice and
+// speed into one CacheAgent key, or "" if there's no cache to consult.
+func (p *Pipeline) segmentCacheKey(sentenceIDs []string, voiceID string, params *SynthParams) string {
+	if p.cacheAgent == nil {
+		return ""
+	}
+
+	speed := 1.0
+	if params != nil {
+		speed = params.Speed
+	}
+
+	var joined string
+	for _, id := range sentenceIDs {
+		joined += id
+	}
+	return p.cacheAgent.GenerateSentenceKey(joined, voiceID, speed)
+}
+
+// reuseCachedSegment copies a cached segment to segPath and reports true
+// on a cache hit; false means the caller must synthesize it.
+func (p *Pipeline) reuseCachedSegment(cacheKey, segPath string) bool {
+	if p.cacheAgent == nil || cacheKey == "" {
+		return false
+	}
+	entry, err := p.cacheAgent.Get(cacheKey)
+	if err != nil || entry == nil {
+		return false
+	}
+	return copySegmentFile(entry.FilePath, segPath) == nil
+}
+
+// copySegmentFile copies src to dst, overwriting dst if it exists.
+func copySegmentFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open source file: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("failed to create destination file: %w", err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return fmt.Errorf("failed to copy file: %w", err)
+	}
+	return nil
+}
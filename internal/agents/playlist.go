@@ -0,0 +1,110 @@
+package agents
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ChapterFile is one synthesized chapter's output, as produced by a
+// chapter-aware batch synthesis run.
+type ChapterFile struct {
+	Title    string
+	Path     string
+	Duration time.Duration
+}
+
+// WriteM3U8 writes an extended M3U playlist listing each chapter file in
+// order, so the per-chapter output can be played back as one continuous
+// album.
+func WriteM3U8(chapters []ChapterFile, outPath string) error {
+	file, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("failed to create playlist %s: %w", outPath, err)
+	}
+	defer file.Close()
+
+	fmt.Fprintln(file, "#EXTM3U")
+	for i, ch := range chapters {
+		title := ch.Title
+		if title == "" {
+			title = fmt.Sprintf("Chapter %d", i+1)
+		}
+		fmt.Fprintf(file, "#EXTINF:%d,%s\n", int(ch.Duration.Seconds()), title)
+		fmt.Fprintln(file, filepath.Base(ch.Path))
+	}
+
+	return nil
+}
+
+// WriteFFMetadataChapters writes an ffmetadata file describing chapter
+// marks at each chapter's cumulative start time, suitable for passing to
+// `ffmpeg -i combined.mp3 -i chapters.txt -map_metadata 1` to embed
+// chapter marks in a combined MP3 or M4B.
+func WriteFFMetadataChapters(chapters []ChapterFile, outPath string) error {
+	file, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("failed to create chapters metadata %s: %w", outPath, err)
+	}
+	defer file.Close()
+
+	fmt.Fprintln(file, ";FFMETADATA1")
+
+	var start time.Duration
+	for i, ch := range chapters {
+		title := ch.Title
+		if title == "" {
+			title = fmt.Sprintf("Chapter %d", i+1)
+		}
+
+		end := start + ch.Duration
+		fmt.Fprintln(file, "[CHAPTER]")
+		fmt.Fprintln(file, "TIMEBASE=1/1000")
+		fmt.Fprintf(file, "START=%d\n", start.Milliseconds())
+		fmt.Fprintf(file, "END=%d\n", end.Milliseconds())
+		fmt.Fprintf(file, "title=%s\n", title)
+
+		start = end
+	}
+
+	return nil
+}
+
+// WriteCueSheet writes a CUE sheet referencing a single combined audio
+// file, with one TRACK per chapter, for players that prefer CUE-based
+// navigation over ffmetadata chapters.
+func WriteCueSheet(chapters []ChapterFile, combinedAudioPath, outPath string) error {
+	file, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("failed to create cue sheet %s: %w", outPath, err)
+	}
+	defer file.Close()
+
+	fmt.Fprintf(file, "FILE %q WAVE\n", filepath.Base(combinedAudioPath))
+
+	var start time.Duration
+	for i, ch := range chapters {
+		title := ch.Title
+		if title == "" {
+			title = fmt.Sprintf("Chapter %d", i+1)
+		}
+
+		fmt.Fprintf(file, "  TRACK %02d AUDIO\n", i+1)
+		fmt.Fprintf(file, "    TITLE %q\n", title)
+		fmt.Fprintf(file, "    INDEX 01 %s\n", cueTimestamp(start))
+
+		start += ch.Duration
+	}
+
+	return nil
+}
+
+// cueTimestamp formats a duration as CUE's MM:SS:FF (frames are 1/75s).
+func cueTimestamp(d time.Duration) string {
+	totalFrames := int(d.Seconds() * 75)
+	minutes := totalFrames / (75 * 60)
+	seconds := (totalFrames / 75) % 60
+	frames := totalFrames % 75
+	return fmt.Sprintf("%02d:%02d:%02d", minutes, seconds, frames)
+}
@@ -1,19 +1,31 @@
 package agents
 
 import (
+	"bytes"
+	"context"
+	"encoding/binary"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
+
+	"studiospeech/internal/audio/encoder"
 )
 
 // AudioFormat represents output audio format
 type AudioFormat string
 
 const (
-	FormatWAV AudioFormat = "wav"
-	FormatMP3 AudioFormat = "mp3"
+	FormatWAV    AudioFormat = "wav"
+	FormatMP3    AudioFormat = "mp3"
+	FormatOpus   AudioFormat = "opus"
+	FormatFLAC   AudioFormat = "flac"
+	FormatAAC    AudioFormat = "aac"
+	FormatVorbis AudioFormat = "vorbis"
 )
 
 // PostProcessParams contains audio processing parameters
@@ -22,6 +34,32 @@ type PostProcessParams struct {
 	SampleRate int     // Target sample rate (Hz)
 	Bitrate    int     // MP3 bitrate (kbps)
 	LoudnessLUFS float64 // Target loudness (-16 to -14 LUFS)
+	// ReplayGain, when set, runs a two-pass EBU R128 analysis after
+	// encoding and embeds REPLAYGAIN_TRACK_GAIN/REPLAYGAIN_TRACK_PEAK/
+	// R128_TRACK_GAIN tags into the output (see ApplyReplayGain).
+	ReplayGain bool
+	// AlbumMode additionally writes REPLAYGAIN_ALBUM_GAIN/REPLAYGAIN_ALBUM_PEAK/
+	// R128_ALBUM_GAIN tags computed across a batch. It only takes effect
+	// via ProcessAlbum, which sets it on the params it passes down.
+	AlbumMode bool
+	// Chapters, when non-empty, are written as FFmpeg chapter metadata and,
+	// for MP3 output, ID3v2 CHAP/CTOC frames, so podcast players can jump
+	// between segments. Setting this (or Tags) forces buildFFmpegCommand's
+	// path even when a faster native Encoder is registered for Format,
+	// since chapter/tag embedding needs FFmpeg's metadata input.
+	Chapters []AudioChapter
+	// Tags sets the output's embedded metadata. Recognized keys are
+	// title, artist, album, date, comment, and podcast:episode; any other
+	// key is not written to the metadata file.
+	Tags map[string]string
+}
+
+// AudioChapter is one named, timestamped marker embedded into the output's
+// chapter list -- distinct from Chapter, which is a text-splitting unit of
+// a TextContent rather than a position in rendered audio.
+type AudioChapter struct {
+	Start time.Duration
+	Title string
 }
 
 // PostProcessResult contains processing output information
@@ -32,6 +70,9 @@ type PostProcessResult struct {
 	Channels     int
 	Duration     float64
 	FileSize     int64
+	// ReplayGain holds the measured tags when PostProcessParams.ReplayGain
+	// was set; nil otherwise.
+	ReplayGain *ReplayGainTags
 }
 
 // PostProcessAgent handles audio post-processing using FFmpeg
@@ -55,26 +96,30 @@ func (p *PostProcessAgent) SetDryRun(enabled bool) {
 	p.dryRun = enabled
 }
 
-// Process converts and normalizes audio using FFmpeg
+// Process converts and normalizes audio. When an in-process Encoder (see
+// internal/audio/encoder) is registered for params.Format, it is used
+// directly on the input's raw PCM instead of shelling out to FFmpeg - this
+// is faster and drops the external dependency, but it also means the
+// FFmpeg-only resample/mono-mixdown/loudnorm filter chain below is
+// skipped, so SampleRate and LoudnessLUFS targets are a no-op on that
+// path. FFmpeg is only invoked as a fallback, for formats with no
+// compiled-in encoder.
 func (p *PostProcessAgent) Process(inputPath, outputPath string, params *PostProcessParams) (*PostProcessResult, error) {
 	if params == nil {
 		params = p.getDefaultParams()
 	}
-	
+
 	if err := p.validateParams(params); err != nil {
 		return nil, fmt.Errorf("invalid parameters: %w", err)
 	}
-	
+
 	// Check input file exists (skip in dry-run mode)
 	if !p.dryRun {
 		if _, err := os.Stat(inputPath); os.IsNotExist(err) {
 			return nil, fmt.Errorf("input file not found: %s", inputPath)
 		}
 	}
-	
-	// Build FFmpeg command
-	cmd := p.buildFFmpegCommand(inputPath, outputPath, params)
-	
+
 	if p.dryRun {
 		return &PostProcessResult{
 			OutputPath: outputPath,
@@ -85,66 +130,477 @@ func (p *PostProcessAgent) Process(inputPath, outputPath string, params *PostPro
 			FileSize:   0,
 		}, nil
 	}
-	
-	// Execute FFmpeg
-	if err := p.executeFFmpeg(cmd); err != nil {
-		return nil, fmt.Errorf("ffmpeg processing failed: %w", err)
+
+	// Chapters/Tags need FFmpeg's metadata-file input (-map_metadata/
+	// -map_chapters), so they force the FFmpeg path below even when a
+	// native Encoder is registered for params.Format.
+	needsMetadata := len(params.Chapters) > 0 || len(params.Tags) > 0
+
+	var result *PostProcessResult
+	if enc, ok := encoder.Get(string(params.Format)); ok && !needsMetadata {
+		r, err := p.processWithEncoder(enc, inputPath, outputPath, params)
+		if err != nil {
+			return nil, fmt.Errorf("native encoding failed: %w", err)
+		}
+		result = r
+	} else {
+		// Build FFmpeg command
+		cmd, err := p.buildFFmpegCommand(inputPath, outputPath, params)
+		if err != nil {
+			return nil, err
+		}
+
+		// Execute FFmpeg
+		if err := p.executeFFmpeg(cmd); err != nil {
+			return nil, fmt.Errorf("ffmpeg processing failed: %w", err)
+		}
+
+		// Get output file info
+		fileInfo, err := os.Stat(outputPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get output file info: %w", err)
+		}
+
+		result = &PostProcessResult{
+			OutputPath: outputPath,
+			Format:     params.Format,
+			SampleRate: params.SampleRate,
+			Channels:   1,
+			Duration:   0, // Would need ffprobe to get actual duration
+			FileSize:   fileInfo.Size(),
+		}
 	}
-	
-	// Get output file info
+
+	if params.ReplayGain {
+		tags, err := p.ApplyReplayGain(outputPath, params)
+		if err != nil {
+			return nil, fmt.Errorf("replaygain tagging failed: %w", err)
+		}
+		result.ReplayGain = tags
+		if fileInfo, err := os.Stat(outputPath); err == nil {
+			result.FileSize = fileInfo.Size()
+		}
+	}
+
+	return result, nil
+}
+
+// ProcessStream behaves like Process but reads raw signed 16-bit PCM
+// straight from in and feeds it to FFmpeg via stdin ("-i pipe:0"),
+// writing the encoded result to out as FFmpeg produces it - so a
+// synthesized utterance never has to land on disk as an intermediate WAV
+// file. Pair it with SynthAgent.SynthesizeStream through an io.Pipe to
+// keep a sentence's audio off disk end-to-end: pass the same cancel that
+// owns the SynthesizeStream call's context as cancel here.
+//
+// FFmpeg can exit (on error, or once it has enough input for the
+// requested format) before in is fully drained, which would otherwise
+// leave SynthesizeStream's relay goroutine - and the backend behind it -
+// blocked forever trying to write more. So once cmd.Run returns,
+// ProcessStream always calls cancel to unblock the producer side and
+// drains any remaining bytes from in in the background so a write that
+// was already in flight doesn't deadlock instead.
+//
+// Unlike Process it always shells out to FFmpeg: the in-process Encoder
+// fast path needs the whole PCM buffered up front to learn the WAV data
+// size (see encoder.WAVEncoder), which would defeat the point of
+// streaming, and ReplayGain tagging needs a finished, seekable file for
+// its two analysis passes - so ReplayGain is rejected here rather than
+// silently skipped.
+func (p *PostProcessAgent) ProcessStream(ctx context.Context, cancel context.CancelFunc, in io.Reader, sampleRate, channels int, out io.Writer, params *PostProcessParams) error {
+	if params == nil {
+		params = p.getDefaultParams()
+	}
+
+	if err := p.validateParams(params); err != nil {
+		return fmt.Errorf("invalid parameters: %w", err)
+	}
+	if params.ReplayGain {
+		return fmt.Errorf("ReplayGain tagging requires a seekable output file; use Process instead of ProcessStream")
+	}
+
+	if p.dryRun {
+		return nil
+	}
+
+	cmd := p.buildStreamFFmpegCommand(ctx, sampleRate, channels, params)
+	cmd.Stdin = in
+	cmd.Stdout = out
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+
+	cancel()
+	go io.Copy(io.Discard, in)
+
+	if runErr != nil {
+		return fmt.Errorf("ffmpeg streaming failed: %w\nOutput: %s", runErr, stderr.String())
+	}
+	return nil
+}
+
+// concatCrossfadeDuration is the length, in seconds, of the equal-power
+// crossfade ConcatAndNormalize applies at every segment boundary.
+const concatCrossfadeDuration = 0.03
+
+// ConcatAndNormalize joins inputs (WAV files, e.g. the per-segment and
+// per-break files SynthAgent.SynthesizeSSMLCrossfade renders) end to end,
+// crossfading every boundary with FFmpeg's acrossfade filter so the seam
+// between two independently-rendered segments doesn't read as a click,
+// then runs a single loudnorm pass over the joined result so per-segment
+// level differences don't survive into the final file. A single input
+// skips the crossfade graph and is just processed like any other file.
+//
+// The crossfade graph assumes every input shares a sample rate and
+// channel layout, which holds for SynthesizeSSMLCrossfade's own inputs
+// (one voice's segments plus matching silence files); acrossfade errors
+// out on a mismatch rather than silently resampling.
+func (p *PostProcessAgent) ConcatAndNormalize(inputs []string, outputPath string, params *PostProcessParams) (*PostProcessResult, error) {
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("no inputs to concatenate")
+	}
+	if params == nil {
+		params = p.getDefaultParams()
+	}
+	if err := p.validateParams(params); err != nil {
+		return nil, fmt.Errorf("invalid parameters: %w", err)
+	}
+
+	if len(inputs) == 1 {
+		return p.Process(inputs[0], outputPath, params)
+	}
+
+	if p.dryRun {
+		return &PostProcessResult{OutputPath: outputPath, Format: params.Format, SampleRate: params.SampleRate, Channels: 1}, nil
+	}
+
+	args := make([]string, 0, len(inputs)*2+8)
+	for _, in := range inputs {
+		args = append(args, "-i", in)
+	}
+	args = append(args, "-y", "-filter_complex", concatCrossfadeGraph(len(inputs), params), "-map", "[out]")
+	args = append(args, p.encodeArgs(params)...)
+	args = append(args, outputPath)
+
+	if err := p.executeFFmpeg(exec.Command(p.ffmpegPath, args...)); err != nil {
+		return nil, fmt.Errorf("ffmpeg concat failed: %w", err)
+	}
+
 	fileInfo, err := os.Stat(outputPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get output file info: %w", err)
 	}
-	
+
 	return &PostProcessResult{
 		OutputPath: outputPath,
 		Format:     params.Format,
 		SampleRate: params.SampleRate,
 		Channels:   1,
-		Duration:   0, // Would need ffprobe to get actual duration
 		FileSize:   fileInfo.Size(),
 	}, nil
 }
 
-// buildFFmpegCommand constructs the FFmpeg command line
-func (p *PostProcessAgent) buildFFmpegCommand(inputPath, outputPath string, params *PostProcessParams) *exec.Cmd {
+// concatCrossfadeGraph builds the FFmpeg filter_complex graph
+// ConcatAndNormalize passes via "-filter_complex": it chains n inputs
+// together pairwise with acrossfade, then runs the joined stream through
+// the same loudnorm filter Process applies, labeling the final stream
+// "out" for ConcatAndNormalize's "-map".
+func concatCrossfadeGraph(n int, params *PostProcessParams) string {
+	clauses := make([]string, 0, n)
+	cur := "0:a"
+	for i := 1; i < n; i++ {
+		label := fmt.Sprintf("x%d", i)
+		clauses = append(clauses, fmt.Sprintf("[%s][%d:a]acrossfade=d=%.3f:c1=tri:c2=tri[%s]", cur, i, concatCrossfadeDuration, label))
+		cur = label
+	}
+	if params.LoudnessLUFS != 0 {
+		clauses = append(clauses, fmt.Sprintf("[%s]loudnorm=I=%.1f:TP=-1.0:LRA=7.0[out]", cur, params.LoudnessLUFS))
+	} else {
+		clauses = append(clauses, fmt.Sprintf("[%s]anull[out]", cur))
+	}
+	return strings.Join(clauses, ";")
+}
+
+// processWithEncoder reads inputPath's raw PCM (stripping its WAV header)
+// and runs it through enc, writing the encoded result to outputPath.
+func (p *PostProcessAgent) processWithEncoder(enc encoder.Encoder, inputPath, outputPath string, params *PostProcessParams) (*PostProcessResult, error) {
+	pcm, sampleRate, channels, err := openWavPCM(inputPath)
+	if err != nil {
+		return nil, err
+	}
+	defer pcm.Close()
+
+	out, err := os.Create(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer out.Close()
+
+	cfg := encoder.Config{
+		SampleRate:      sampleRate,
+		Channels:        channels,
+		Bitrate:         params.Bitrate,
+		FLACCompression: 5,
+	}
+
+	if err := enc.Encode(pcm, cfg, out); err != nil {
+		return nil, err
+	}
+
+	fileInfo, err := out.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat output file: %w", err)
+	}
+
+	return &PostProcessResult{
+		OutputPath: outputPath,
+		Format:     params.Format,
+		SampleRate: sampleRate,
+		Channels:   channels,
+		FileSize:   fileInfo.Size(),
+	}, nil
+}
+
+// openWavPCM opens a canonical RIFF/WAVE file and returns a reader over
+// just its PCM sample data (header stripped) along with the sample rate
+// and channel count recorded in its "fmt " chunk, for feeding into an
+// in-process Encoder.
+func openWavPCM(path string) (io.ReadCloser, int, int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to open wav file: %w", err)
+	}
+
+	var header [12]byte
+	if _, err := io.ReadFull(file, header[:]); err != nil {
+		file.Close()
+		return nil, 0, 0, fmt.Errorf("failed to read riff header: %w", err)
+	}
+	if string(header[0:4]) != "RIFF" || string(header[8:12]) != "WAVE" {
+		file.Close()
+		return nil, 0, 0, fmt.Errorf("not a valid WAV file")
+	}
+
+	var channels uint16
+	var sampleRate uint32
+	var dataSize uint32
+	var chunkHeader [8]byte
+
+	for {
+		if _, err := io.ReadFull(file, chunkHeader[:]); err != nil {
+			file.Close()
+			return nil, 0, 0, fmt.Errorf("failed to find data chunk: %w", err)
+		}
+
+		chunkID := string(chunkHeader[0:4])
+		chunkSize := binary.LittleEndian.Uint32(chunkHeader[4:8])
+
+		switch chunkID {
+		case "fmt ":
+			body := make([]byte, chunkSize)
+			if _, err := io.ReadFull(file, body); err != nil {
+				file.Close()
+				return nil, 0, 0, fmt.Errorf("failed to read fmt chunk: %w", err)
+			}
+			channels = binary.LittleEndian.Uint16(body[2:4])
+			sampleRate = binary.LittleEndian.Uint32(body[4:8])
+		case "data":
+			dataSize = chunkSize
+		default:
+			if _, err := file.Seek(int64(chunkSize), io.SeekCurrent); err != nil {
+				file.Close()
+				return nil, 0, 0, fmt.Errorf("failed to skip chunk %s: %w", chunkID, err)
+			}
+		}
+
+		if dataSize > 0 && sampleRate > 0 {
+			break
+		}
+	}
+
+	if channels == 0 || sampleRate == 0 {
+		file.Close()
+		return nil, 0, 0, fmt.Errorf("incomplete WAV header: missing fmt chunk")
+	}
+
+	return wavPCMReader{io.LimitReader(file, int64(dataSize)), file}, int(sampleRate), int(channels), nil
+}
+
+// wavPCMReader pairs a bounded view over a WAV file's data chunk with the
+// underlying file, so callers can Close() it like any other ReadCloser.
+type wavPCMReader struct {
+	io.Reader
+	file *os.File
+}
+
+func (w wavPCMReader) Close() error { return w.file.Close() }
+
+// buildFFmpegCommand constructs the FFmpeg command line. When
+// params.Chapters or params.Tags are set, it first writes an FFmpeg
+// metadata file (see writeFFMetadataFile) and wires it in as a second
+// input via "-map_metadata 1 -map_chapters 1" - FFmpeg only accepts
+// chapters this way, not as a plain CLI flag - and, for MP3 output with
+// chapters, adds "-id3v2_version 3" so the chapters land as ID3v2
+// CHAP/CTOC frames instead of being silently dropped by the muxer.
+func (p *PostProcessAgent) buildFFmpegCommand(inputPath, outputPath string, params *PostProcessParams) (*exec.Cmd, error) {
+	args := []string{"-i", inputPath}
+
+	if len(params.Chapters) > 0 || len(params.Tags) > 0 {
+		metadataPath, err := p.writeFFMetadataFile(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write chapter/tag metadata: %w", err)
+		}
+		args = append(args, "-i", metadataPath, "-map_metadata", "1", "-map_chapters", "1")
+	}
+
+	args = append(args, "-y") // Overwrite output file
+	args = append(args, p.filterArgs(params)...)
+	args = append(args, p.encodeArgs(params)...)
+	if params.Format == FormatMP3 && len(params.Chapters) > 0 {
+		args = append(args, "-id3v2_version", "3")
+	}
+	args = append(args, outputPath)
+
+	return exec.Command(p.ffmpegPath, args...), nil
+}
+
+// chapterEndSentinelMs is the END timestamp (in the chapter metadata file's
+// TIMEBASE=1/1000 units) given to the last chapter in the list.
+// PostProcessAgent has no ffprobe-based duration lookup to compute the
+// real end of the final chapter against, so it uses a generous sentinel
+// well past any realistic episode length instead.
+const chapterEndSentinelMs = 24 * 60 * 60 * 1000 // 24h
+
+// writeFFMetadataFile renders params.Tags and params.Chapters into an
+// FFmpeg metadata file (the ";FFMETADATA1" format FFmpeg's -map_metadata
+// input expects) under p.tempDir, for buildFFmpegCommand to pass in as a
+// second "-i".
+func (p *PostProcessAgent) writeFFMetadataFile(params *PostProcessParams) (string, error) {
+	var b strings.Builder
+	b.WriteString(";FFMETADATA1\n")
+
+	for _, key := range []string{"title", "artist", "album", "date", "comment", "podcast:episode"} {
+		if v, ok := params.Tags[key]; ok && v != "" {
+			fmt.Fprintf(&b, "%s=%s\n", key, escapeFFMetadata(v))
+		}
+	}
+
+	for i, ch := range params.Chapters {
+		end := int64(chapterEndSentinelMs)
+		if i+1 < len(params.Chapters) {
+			end = params.Chapters[i+1].Start.Milliseconds()
+		}
+		b.WriteString("[CHAPTER]\n")
+		b.WriteString("TIMEBASE=1/1000\n")
+		fmt.Fprintf(&b, "START=%d\n", ch.Start.Milliseconds())
+		fmt.Fprintf(&b, "END=%d\n", end)
+		fmt.Fprintf(&b, "title=%s\n", escapeFFMetadata(ch.Title))
+	}
+
+	path := filepath.Join(p.tempDir, fmt.Sprintf("ffmetadata_%d.txt", time.Now().UnixNano()))
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		return "", fmt.Errorf("failed to write metadata file: %w", err)
+	}
+	return path, nil
+}
+
+// escapeFFMetadata escapes the characters the FFMETADATA1 format treats
+// specially ("=", ";", "#", "\", and newlines) with a backslash, per
+// https://ffmpeg.org/ffmpeg-formats.html#Metadata-1.
+func escapeFFMetadata(v string) string {
+	replacer := strings.NewReplacer(
+		"\\", "\\\\",
+		"=", "\\=",
+		";", "\\;",
+		"#", "\\#",
+		"\n", "\\\n",
+	)
+	return replacer.Replace(v)
+}
+
+// buildStreamFFmpegCommand constructs the FFmpeg command line for
+// ProcessStream: raw signed 16-bit PCM in over stdin (so the caller
+// doesn't need to wrap it in a WAV header first) and the encoded
+// container out over stdout, sharing buildFFmpegCommand's filter/codec
+// argument logic. It runs under ctx so ProcessStream can kill FFmpeg the
+// same way it unblocks the rest of the streaming chain.
+func (p *PostProcessAgent) buildStreamFFmpegCommand(ctx context.Context, sampleRate, channels int, params *PostProcessParams) *exec.Cmd {
 	args := []string{
-		"-i", inputPath,
-		"-y", // Overwrite output file
+		"-f", "s16le",
+		"-ar", strconv.Itoa(nonZeroInt(sampleRate, 22050)),
+		"-ac", strconv.Itoa(nonZeroInt(channels, 1)),
+		"-i", "pipe:0",
+		"-y",
 	}
-	
-	// Audio processing filters
+	args = append(args, p.filterArgs(params)...)
+	args = append(args, p.encodeArgs(params)...)
+	args = append(args, "-f", streamContainer(params.Format), "pipe:1")
+
+	return exec.CommandContext(ctx, p.ffmpegPath, args...)
+}
+
+// filterArgs builds the "-af" resample/mixdown/loudnorm filter chain
+// shared by the file-based and streaming FFmpeg command lines.
+func (p *PostProcessAgent) filterArgs(params *PostProcessParams) []string {
 	var filters []string
-	
+
 	// Resample to target sample rate and convert to mono
 	filters = append(filters, fmt.Sprintf("aresample=%d", params.SampleRate))
 	filters = append(filters, "pan=mono|c0=0.5*c0+0.5*c1")
-	
+
 	// Loudness normalization (EBU R128)
 	if params.LoudnessLUFS != 0 {
 		filters = append(filters, fmt.Sprintf("loudnorm=I=%.1f:TP=-1.0:LRA=7.0", params.LoudnessLUFS))
 	}
-	
-	// Apply filters
-	if len(filters) > 0 {
-		args = append(args, "-af", strings.Join(filters, ","))
+
+	if len(filters) == 0 {
+		return nil
 	}
-	
-	// Format-specific options
+	return []string{"-af", strings.Join(filters, ",")}
+}
+
+// encodeArgs builds the format-specific codec/bitrate/sample-rate
+// arguments shared by the file-based and streaming FFmpeg command lines.
+func (p *PostProcessAgent) encodeArgs(params *PostProcessParams) []string {
 	switch params.Format {
 	case FormatMP3:
-		args = append(args, "-codec:a", "libmp3lame")
-		args = append(args, "-b:a", fmt.Sprintf("%dk", params.Bitrate))
-		args = append(args, "-ar", strconv.Itoa(params.SampleRate))
+		return []string{"-codec:a", "libmp3lame", "-b:a", fmt.Sprintf("%dk", params.Bitrate), "-ar", strconv.Itoa(params.SampleRate)}
+	case FormatOpus:
+		return []string{"-codec:a", "libopus", "-b:a", fmt.Sprintf("%dk", params.Bitrate), "-ar", strconv.Itoa(params.SampleRate)}
+	case FormatVorbis:
+		return []string{"-codec:a", "libvorbis", "-b:a", fmt.Sprintf("%dk", params.Bitrate), "-ar", strconv.Itoa(params.SampleRate)}
+	case FormatAAC:
+		return []string{"-codec:a", "aac", "-b:a", fmt.Sprintf("%dk", params.Bitrate), "-ar", strconv.Itoa(params.SampleRate)}
+	case FormatFLAC:
+		return []string{"-codec:a", "flac", "-ar", strconv.Itoa(params.SampleRate)}
 	case FormatWAV:
-		args = append(args, "-codec:a", "pcm_s16le")
-		args = append(args, "-ar", strconv.Itoa(params.SampleRate))
+		return []string{"-codec:a", "pcm_s16le", "-ar", strconv.Itoa(params.SampleRate)}
+	default:
+		return nil
+	}
+}
+
+// streamContainer maps a format to the FFmpeg muxer name ProcessStream
+// passes via "-f" for its pipe:1 output, since there's no output file
+// extension for FFmpeg to infer a container from.
+func streamContainer(format AudioFormat) string {
+	switch format {
+	case FormatOpus, FormatVorbis:
+		return "ogg"
+	case FormatAAC:
+		return "adts"
+	default:
+		return string(format)
 	}
-	
-	args = append(args, outputPath)
-	
-	return exec.Command(p.ffmpegPath, args...)
+}
+
+// nonZeroInt returns v, or fallback if v is the zero value.
+func nonZeroInt(v, fallback int) int {
+	if v == 0 {
+		return fallback
+	}
+	return v
 }
 
 // executeFFmpeg runs FFmpeg command
@@ -168,17 +624,20 @@ func (p *PostProcessAgent) getDefaultParams() *PostProcessParams {
 
 // validateParams checks if processing parameters are valid
 func (p *PostProcessAgent) validateParams(params *PostProcessParams) error {
-	if params.Format != FormatWAV && params.Format != FormatMP3 {
+	switch params.Format {
+	case FormatWAV, FormatMP3, FormatOpus, FormatFLAC, FormatAAC, FormatVorbis:
+		// recognized format
+	default:
 		return fmt.Errorf("unsupported format: %s", params.Format)
 	}
-	
+
 	if params.SampleRate < 8000 || params.SampleRate > 192000 {
 		return fmt.Errorf("sample rate must be between 8000 and 192000 Hz, got %d", params.SampleRate)
 	}
-	
-	if params.Format == FormatMP3 {
+
+	if params.Format == FormatMP3 || params.Format == FormatOpus || params.Format == FormatAAC || params.Format == FormatVorbis {
 		if params.Bitrate < 64 || params.Bitrate > 320 {
-			return fmt.Errorf("MP3 bitrate must be between 64 and 320 kbps, got %d", params.Bitrate)
+			return fmt.Errorf("bitrate must be between 64 and 320 kbps, got %d", params.Bitrate)
 		}
 	}
 	
@@ -195,7 +654,10 @@ func (p *PostProcessAgent) GetCommandLine(inputPath, outputPath string, params *
 		params = p.getDefaultParams()
 	}
 	
-	cmd := p.buildFFmpegCommand(inputPath, outputPath, params)
+	cmd, err := p.buildFFmpegCommand(inputPath, outputPath, params)
+	if err != nil {
+		return fmt.Sprintf("error building command line: %v", err)
+	}
 	return strings.Join(append([]string{cmd.Path}, cmd.Args[1:]...), " ")
 }
 
@@ -1,8 +1,12 @@
 package agents
 
 import (
+	"context"
+	"io"
+	"os"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestPostProcessAgent_ValidateParams(t *testing.T) {
@@ -23,6 +27,11 @@ func TestPostProcessAgent_ValidateParams(t *testing.T) {
 			shouldErr: false,
 			desc:      "valid WAV parameters",
 		},
+		{
+			params:    &PostProcessParams{Format: FormatOpus, SampleRate: 48000, Bitrate: 96, LoudnessLUFS: -16.0},
+			shouldErr: false,
+			desc:      "valid Opus parameters",
+		},
 		{
 			params:    &PostProcessParams{Format: "invalid", SampleRate: 48000, Bitrate: 192, LoudnessLUFS: -16.0},
 			shouldErr: true,
@@ -80,6 +89,197 @@ func TestPostProcessAgent_GetCommandLine(t *testing.T) {
 	}
 }
 
+func TestPostProcessAgent_BuildStreamFFmpegCommand(t *testing.T) {
+	agent := NewPostProcessAgent("ffmpeg", "/tmp")
+
+	params := &PostProcessParams{
+		Format:       FormatOpus,
+		SampleRate:   48000,
+		Bitrate:      96,
+		LoudnessLUFS: -16.0,
+	}
+
+	cmd := agent.buildStreamFFmpegCommand(context.Background(), 22050, 1, params)
+	cmdLine := strings.Join(append([]string{cmd.Path}, cmd.Args[1:]...), " ")
+
+	expectedParts := []string{
+		"-f", "s16le",
+		"-ar", "22050",
+		"-ac", "1",
+		"-i", "pipe:0",
+		"aresample=48000",
+		"-codec:a", "libopus",
+		"-b:a", "96k",
+		"-f", "ogg",
+		"pipe:1",
+	}
+	for _, part := range expectedParts {
+		if !strings.Contains(cmdLine, part) {
+			t.Errorf("stream command line missing expected part: %s\nFull command: %s", part, cmdLine)
+		}
+	}
+}
+
+func TestPostProcessAgent_ConcatCrossfadeGraph(t *testing.T) {
+	params := &PostProcessParams{Format: FormatMP3, SampleRate: 48000, Bitrate: 192, LoudnessLUFS: -16.0}
+	graph := concatCrossfadeGraph(3, params)
+
+	expectedParts := []string{
+		"[0:a][1:a]acrossfade=d=0.030:c1=tri:c2=tri[x1]",
+		"[x1][2:a]acrossfade=d=0.030:c1=tri:c2=tri[x2]",
+		"[x2]loudnorm=I=-16.0",
+		"[out]",
+	}
+	for _, part := range expectedParts {
+		if !strings.Contains(graph, part) {
+			t.Errorf("crossfade graph missing expected part: %s\nFull graph: %s", part, graph)
+		}
+	}
+}
+
+func TestPostProcessAgent_ConcatAndNormalize_NoInputs(t *testing.T) {
+	agent := NewPostProcessAgent("ffmpeg", "/tmp")
+	if _, err := agent.ConcatAndNormalize(nil, "/tmp/out.mp3", nil); err == nil {
+		t.Error("expected an error for an empty input list")
+	}
+}
+
+func TestPostProcessAgent_ConcatAndNormalize_DryRun(t *testing.T) {
+	agent := NewPostProcessAgent("ffmpeg", "/tmp")
+	agent.SetDryRun(true)
+
+	params := &PostProcessParams{Format: FormatMP3, SampleRate: 48000, Bitrate: 192, LoudnessLUFS: -16.0}
+	result, err := agent.ConcatAndNormalize([]string{"a.wav", "b.wav"}, "/tmp/out.mp3", params)
+	if err != nil {
+		t.Fatalf("ConcatAndNormalize failed: %v", err)
+	}
+	if result.OutputPath != "/tmp/out.mp3" {
+		t.Errorf("OutputPath = %q, want /tmp/out.mp3", result.OutputPath)
+	}
+}
+
+func TestPostProcessAgent_WriteFFMetadataFile(t *testing.T) {
+	tempDir := t.TempDir()
+	agent := NewPostProcessAgent("ffmpeg", tempDir)
+
+	params := &PostProcessParams{
+		Format: FormatMP3,
+		Tags:   map[string]string{"title": "Episode 1", "podcast:episode": "1"},
+		Chapters: []AudioChapter{
+			{Start: 0, Title: "Intro"},
+			{Start: 90 * time.Second, Title: "Main Segment"},
+		},
+	}
+
+	path, err := agent.writeFFMetadataFile(params)
+	if err != nil {
+		t.Fatalf("writeFFMetadataFile failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read metadata file: %v", err)
+	}
+	content := string(data)
+
+	expectedParts := []string{
+		";FFMETADATA1",
+		"title=Episode 1",
+		"podcast:episode=1",
+		"[CHAPTER]",
+		"TIMEBASE=1/1000",
+		"START=0",
+		"END=90000",
+		"title=Intro",
+		"START=90000",
+		"title=Main Segment",
+	}
+	for _, part := range expectedParts {
+		if !strings.Contains(content, part) {
+			t.Errorf("metadata file missing expected part: %s\nFull content:\n%s", part, content)
+		}
+	}
+}
+
+func TestPostProcessAgent_BuildFFmpegCommand_WithChapters(t *testing.T) {
+	agent := NewPostProcessAgent("ffmpeg", t.TempDir())
+	params := &PostProcessParams{
+		Format:       FormatMP3,
+		SampleRate:   48000,
+		Bitrate:      192,
+		LoudnessLUFS: -16.0,
+		Chapters:     []AudioChapter{{Start: 0, Title: "Intro"}},
+	}
+
+	cmdLine := agent.GetCommandLine("/input.wav", "/output.mp3", params)
+
+	expectedParts := []string{
+		"-i", "/input.wav",
+		"-map_metadata", "1",
+		"-map_chapters", "1",
+		"-id3v2_version", "3",
+	}
+	for _, part := range expectedParts {
+		if !strings.Contains(cmdLine, part) {
+			t.Errorf("command line missing expected part: %s\nFull command: %s", part, cmdLine)
+		}
+	}
+}
+
+func TestPostProcessAgent_ProcessStream_RejectsReplayGain(t *testing.T) {
+	agent := NewPostProcessAgent("ffmpeg", "/tmp")
+	params := &PostProcessParams{Format: FormatMP3, SampleRate: 48000, Bitrate: 192, ReplayGain: true}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := agent.ProcessStream(ctx, cancel, strings.NewReader(""), 22050, 1, io.Discard, params); err == nil {
+		t.Error("expected ProcessStream to reject ReplayGain, got no error")
+	}
+}
+
+func TestComputeReplayGainTags(t *testing.T) {
+	analysis := &LoudnessAnalysis{IntegratedLUFS: -20.0, TruePeakDBFS: -3.0, LRA: 5.0}
+	tags := computeReplayGainTags(analysis)
+
+	wantGain := replayGainReferenceLUFS - analysis.IntegratedLUFS
+	if tags.TrackGainDB != wantGain {
+		t.Errorf("TrackGainDB = %.2f, want %.2f", tags.TrackGainDB, wantGain)
+	}
+
+	wantPeak := 0.70794578
+	if diff := tags.TrackPeak - wantPeak; diff > 0.0001 || diff < -0.0001 {
+		t.Errorf("TrackPeak = %.6f, want ~%.6f", tags.TrackPeak, wantPeak)
+	}
+
+	wantR128 := int((r128ReferenceLUFS - analysis.IntegratedLUFS) * 256)
+	if tags.R128TrackGain != wantR128 {
+		t.Errorf("R128TrackGain = %d, want %d", tags.R128TrackGain, wantR128)
+	}
+}
+
+func TestAlbumIntegratedLUFS(t *testing.T) {
+	// Equal-loudness tracks should average back to the same loudness.
+	got := albumIntegratedLUFS([]float64{-20.0, -20.0, -20.0})
+	if diff := got - (-20.0); diff > 0.01 || diff < -0.01 {
+		t.Errorf("albumIntegratedLUFS of equal tracks = %.2f, want -20.00", got)
+	}
+
+	// A louder track should pull the album average up, not down.
+	louder := albumIntegratedLUFS([]float64{-20.0, -10.0})
+	quieter := albumIntegratedLUFS([]float64{-20.0, -30.0})
+	if louder <= quieter {
+		t.Errorf("expected louder batch (%.2f) to exceed quieter batch (%.2f)", louder, quieter)
+	}
+}
+
+func TestPostProcessAgent_ProcessAlbum_RequiresReplayGain(t *testing.T) {
+	agent := NewPostProcessAgent("ffmpeg", "/tmp")
+	_, err := agent.ProcessAlbum(nil, &PostProcessParams{Format: FormatMP3, SampleRate: 48000, Bitrate: 192})
+	if err == nil {
+		t.Error("expected error when ReplayGain is not set, got none")
+	}
+}
+
 func TestPostProcessAgent_GetDefaultParams(t *testing.T) {
 	agent := NewPostProcessAgent("ffmpeg", "/tmp")
 	
@@ -0,0 +1,45 @@
+package agents
+
+import "fmt"
+
+// QualityTier names a synthesis/output quality preset, the speech
+// equivalent of the "standard/high/studio" tiers offered by music
+// download tools.
+type QualityTier string
+
+const (
+	QualityStandard QualityTier = "standard"
+	QualityHigh     QualityTier = "high"
+	QualityStudio   QualityTier = "studio"
+)
+
+// QualitySpec is the concrete sample-rate/bitrate target a QualityTier
+// maps to.
+type QualitySpec struct {
+	MinVoiceSampleRate int // minimum acceptable Voice.SampleRate for this tier
+	SampleRate         int // output PostProcessParams.SampleRate
+	Bitrate            int // output PostProcessParams.Bitrate (MP3 kbps); ignored for lossless formats
+}
+
+// qualitySpecs maps each tier to its targets. Studio targets 48kHz/320kbps
+// MP3 (or lossless FLAC), matching broadcast delivery specs; high targets
+// a podcast-grade 44.1kHz/192kbps; standard targets 22.05kHz/128kbps.
+var qualitySpecs = map[QualityTier]QualitySpec{
+	QualityStandard: {MinVoiceSampleRate: 16000, SampleRate: 22050, Bitrate: 128},
+	QualityHigh:     {MinVoiceSampleRate: 22050, SampleRate: 44100, Bitrate: 192},
+	QualityStudio:   {MinVoiceSampleRate: 44100, SampleRate: 48000, Bitrate: 320},
+}
+
+// ParseQualityTier validates a --quality flag value.
+func ParseQualityTier(s string) (QualityTier, error) {
+	tier := QualityTier(s)
+	if _, ok := qualitySpecs[tier]; !ok {
+		return "", fmt.Errorf("unsupported quality tier: %s (supported: standard, high, studio)", s)
+	}
+	return tier, nil
+}
+
+// Spec returns the concrete sample-rate/bitrate targets for a tier.
+func (q QualityTier) Spec() QualitySpec {
+	return qualitySpecs[q]
+}
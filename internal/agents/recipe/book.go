@@ -0,0 +1,64 @@
+// Package recipe runs a declarative, YAML-described book-length
+// synthesis job -- inputs, ${var} interpolated variables, and an ordered
+// list of stages -- analogous to respipeline's "--stages" chain, but
+// over a whole Book rather than a single Resource: stages here can fan
+// one TextContent out into many chapters (splitChapters) or fold many
+// per-chapter audio files back into one (mux), which respipeline.Stage's
+// strict one-Resource-in/one-Resource-out shape can't express. Where a
+// stage's job genuinely is one-in/one-out (voice selection, SSML
+// generation, synthesis) it still delegates to the same agents package
+// respipeline's own stages wrap.
+package recipe
+
+import (
+	"time"
+
+	"studiospeech/internal/agents"
+)
+
+// Chapter is one chapter of a Book as it moves through a recipe's
+// stages: its source paragraphs, the voice assigned to it (if any), the
+// SSML markup generateSSML produced from it, and the audio synthesize
+// rendered.
+type Chapter struct {
+	Title      string
+	Paragraphs []string
+	Voice      string
+	SSML       string
+	AudioPath  string
+	Duration   time.Duration
+}
+
+// Book is the value a recipe.Pipeline threads through its stages: the
+// ingested source document plus whatever stages have produced from it so
+// far (chapters, assigned voices, generated SSML, rendered audio), along
+// with the run-wide configuration stages need.
+type Book struct {
+	// Content is the ingested source text, set before the pipeline runs.
+	// splitChapters is the usual first stage, consuming it into Chapters.
+	Content *agents.TextContent
+
+	// Chapters holds the book's chapters once splitChapters has run;
+	// later stages (assignVoices, generateSSML, synthesize, mux) read
+	// and write it in place.
+	Chapters []Chapter
+
+	// Language is the resolved BCP-47 language code, used for SSML
+	// parsing/normalization and voice selection.
+	Language string
+	// CatalogPath is the voice catalog JSON the synthesize stage loads.
+	CatalogPath string
+	// TempDir is where synthesize/mux write intermediate audio files.
+	TempDir string
+	// OutputPath is the final merged MP3's path, written by mux.
+	OutputPath string
+	// DryRun is forwarded to SynthAgent/PostProcessAgent so a recipe can
+	// run end-to-end in tests without piper or ffmpeg installed.
+	DryRun bool
+
+	// PlaylistPath, ChaptersMetaPath, and CuePath record the sidecar
+	// files mux writes alongside OutputPath, for the run log to report.
+	PlaylistPath     string
+	ChaptersMetaPath string
+	CuePath          string
+}
@@ -0,0 +1,51 @@
+package recipe
+
+import (
+	"fmt"
+	"time"
+)
+
+// StepResult records one stage's outcome for a recipe run's report: how
+// long it took, mirroring respipeline.StageResult.
+type StepResult struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Pipeline runs an ordered list of recipe stage links over a single Book.
+type Pipeline struct {
+	Stages []string
+}
+
+// New builds a Pipeline from a recipe's already-interpolated Stages list.
+func New(stages []string) *Pipeline {
+	return &Pipeline{Stages: stages}
+}
+
+// Run executes every stage in order against book, mutating it in place,
+// and returns a StepResult per stage. It stops at the first stage that
+// returns an error, wrapping it with the stage's name and position.
+func (p *Pipeline) Run(book *Book) ([]StepResult, error) {
+	results := make([]StepResult, 0, len(p.Stages))
+
+	for i, link := range p.Stages {
+		name, params, err := parseStageLink(link)
+		if err != nil {
+			return results, fmt.Errorf("stage %d: %w", i+1, err)
+		}
+
+		stage, ok := Get(name)
+		if !ok {
+			return results, fmt.Errorf("stage %d (%q): not registered", i+1, name)
+		}
+
+		start := time.Now()
+		if err := stage.Run(book, params); err != nil {
+			return results, fmt.Errorf("stage %d (%q) failed: %w", i+1, name, err)
+		}
+
+		results = append(results, StepResult{Name: name, Duration: time.Since(start)})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,80 @@
+package recipe
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Recipe is a declarative description of a book-length synthesis job,
+// loaded from a YAML file passed to "ttscli run": the source files to
+// ingest, a table of variables substituted into Inputs/Stages via
+// "${var}", and the ordered list of stage links to run over the result
+// (same "name:key=value,..." link syntax as respipeline's --stages).
+type Recipe struct {
+	Inputs    []string          `yaml:"inputs"`
+	Variables map[string]string `yaml:"variables"`
+	Stages    []string          `yaml:"stages"`
+}
+
+// varPattern matches a "${name}" variable reference.
+var varPattern = regexp.MustCompile(`\$\{([A-Za-z0-9_]+)\}`)
+
+// Load reads and parses a recipe file at path, interpolates ${var}
+// references from its own Variables table into Inputs and Stages, and
+// validates that every stage name is registered (see Register) before
+// returning -- so a typo surfaces immediately rather than partway
+// through a long-running synthesis job.
+func Load(path string) (*Recipe, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recipe %s: %w", path, err)
+	}
+
+	var r Recipe
+	if err := yaml.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("failed to parse recipe %s: %w", path, err)
+	}
+
+	for i, in := range r.Inputs {
+		r.Inputs[i] = r.interpolate(in)
+	}
+	for i, s := range r.Stages {
+		r.Stages[i] = r.interpolate(s)
+	}
+
+	if len(r.Inputs) == 0 {
+		return nil, fmt.Errorf("recipe %s declares no inputs", path)
+	}
+	if len(r.Stages) == 0 {
+		return nil, fmt.Errorf("recipe %s declares no stages", path)
+	}
+
+	for _, link := range r.Stages {
+		name, _, err := parseStageLink(link)
+		if err != nil {
+			return nil, fmt.Errorf("recipe %s: %w", path, err)
+		}
+		if _, ok := Get(name); !ok {
+			return nil, fmt.Errorf("recipe %s: unknown stage %q (registered: %s)", path, name, strings.Join(Names(), ", "))
+		}
+	}
+
+	return &r, nil
+}
+
+// interpolate substitutes every "${var}" reference in s from r.Variables.
+// A reference with no matching variable is left as-is, so a typo shows
+// up as a literal "${...}" in the output rather than silently vanishing.
+func (r *Recipe) interpolate(s string) string {
+	return varPattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := match[2 : len(match)-1]
+		if v, ok := r.Variables[name]; ok {
+			return v
+		}
+		return match
+	})
+}
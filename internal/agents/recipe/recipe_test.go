@@ -0,0 +1,230 @@
+package recipe
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"studiospeech/internal/agents"
+)
+
+func writeTestCatalog(t *testing.T, dir string) string {
+	t.Helper()
+	catalogPath := filepath.Join(dir, "catalog.json")
+	catalog := `{"voices":[
+		{"id":"en_US-narrator-medium","language":"en-US","gender":"female","commercial_use_allowed":true,"license_name":"CC0","sample_rate":22050},
+		{"id":"en_US-alice-medium","language":"en-US","gender":"female","commercial_use_allowed":true,"license_name":"CC0","sample_rate":22050}
+	]}`
+	if err := os.WriteFile(catalogPath, []byte(catalog), 0644); err != nil {
+		t.Fatalf("failed to write test catalog: %v", err)
+	}
+	return catalogPath
+}
+
+func writeTestRecipe(t *testing.T, dir, body string) string {
+	t.Helper()
+	path := filepath.Join(dir, "book.yaml")
+	if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+		t.Fatalf("failed to write test recipe: %v", err)
+	}
+	return path
+}
+
+func TestLoad_InterpolatesVariablesAndValidatesStages(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestRecipe(t, dir, `
+inputs:
+  - ${source}
+variables:
+  source: book.txt
+  mode: paragraphs
+stages:
+  - splitChapters:mode=${mode}
+  - generateSSML
+`)
+
+	r, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if len(r.Inputs) != 1 || r.Inputs[0] != "book.txt" {
+		t.Fatalf("Inputs = %v, want [book.txt]", r.Inputs)
+	}
+	if len(r.Stages) != 2 || r.Stages[0] != "splitChapters:mode=paragraphs" {
+		t.Fatalf("Stages = %v, want splitChapters:mode=paragraphs first", r.Stages)
+	}
+}
+
+func TestLoad_UnknownStage(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestRecipe(t, dir, `
+inputs:
+  - book.txt
+stages:
+  - doesNotExist
+`)
+
+	if _, err := Load(path); err == nil {
+		t.Fatal("expected an error for an unregistered stage, got nil")
+	}
+}
+
+func TestLoad_NoInputs(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestRecipe(t, dir, "stages:\n  - splitChapters\n")
+	if _, err := Load(path); err == nil {
+		t.Fatal("expected an error for a recipe with no inputs, got nil")
+	}
+}
+
+func TestPipeline_Run_EndToEndDryRun(t *testing.T) {
+	tempDir := t.TempDir()
+	catalogPath := writeTestCatalog(t, tempDir)
+
+	book := &Book{
+		Content: &agents.TextContent{
+			Paragraphs: []string{
+				"# Chapter One",
+				"The room was quiet.",
+				"Alice: Is anyone there?",
+				"# Chapter Two",
+				"The door creaked open, and closed again.",
+			},
+			Language: "en-US",
+		},
+		Language:    "en-US",
+		CatalogPath: catalogPath,
+		TempDir:     tempDir,
+		OutputPath:  filepath.Join(tempDir, "book.mp3"),
+		DryRun:      true,
+	}
+
+	pipeline := New([]string{
+		"splitChapters",
+		"assignVoices:default=en_US-narrator-medium",
+		"generateSSML:sentenceBreak=300,commaBreak=100,voices=Alice=en_US-alice-medium",
+		"synthesize",
+		"mux:title=Test Book",
+	})
+
+	results, err := pipeline.Run(book)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(results) != len(pipeline.Stages) {
+		t.Fatalf("got %d step results, want %d", len(results), len(pipeline.Stages))
+	}
+
+	if len(book.Chapters) != 2 {
+		t.Fatalf("got %d chapters, want 2", len(book.Chapters))
+	}
+	if book.Chapters[0].Title != "Chapter One" || book.Chapters[1].Title != "Chapter Two" {
+		t.Errorf("chapter titles = %q, %q, want Chapter One, Chapter Two", book.Chapters[0].Title, book.Chapters[1].Title)
+	}
+	for i, ch := range book.Chapters {
+		if ch.Voice != "en_US-narrator-medium" {
+			t.Errorf("chapter %d Voice = %q, want en_US-narrator-medium (assignVoices default)", i, ch.Voice)
+		}
+		if !strings.Contains(ch.SSML, "<break") {
+			t.Errorf("chapter %d SSML has no <break>: %s", i, ch.SSML)
+		}
+		if ch.AudioPath == "" {
+			t.Errorf("chapter %d has no AudioPath after synthesize", i)
+		}
+	}
+	if !strings.Contains(book.Chapters[0].SSML, `<voice name="en_US-alice-medium">`) {
+		t.Errorf("chapter 0 SSML missing Alice's voice tag: %s", book.Chapters[0].SSML)
+	}
+
+	if _, err := os.Stat(book.OutputPath); err != nil {
+		t.Errorf("mux output %s does not exist: %v", book.OutputPath, err)
+	}
+	if _, err := os.Stat(book.PlaylistPath); err != nil {
+		t.Errorf("playlist %s does not exist: %v", book.PlaylistPath, err)
+	}
+
+	metaData, err := os.ReadFile(book.ChaptersMetaPath)
+	if err != nil {
+		t.Fatalf("failed to read chapters metadata: %v", err)
+	}
+	meta := string(metaData)
+	if !strings.Contains(meta, ";FFMETADATA1") || !strings.Contains(meta, "title=Chapter One") || !strings.Contains(meta, "title=Chapter Two") {
+		t.Errorf("chapters metadata missing expected chapter marks:\n%s", meta)
+	}
+}
+
+func TestPipeline_Run_EndToEndDryRun_Greek(t *testing.T) {
+	tempDir := t.TempDir()
+	catalogPath := filepath.Join(tempDir, "catalog.json")
+	catalog := `{"voices":[
+		{"id":"el_GR-narrator-medium","language":"el-GR","gender":"male","commercial_use_allowed":true,"license_name":"CC0","sample_rate":22050}
+	]}`
+	if err := os.WriteFile(catalogPath, []byte(catalog), 0644); err != nil {
+		t.Fatalf("failed to write test catalog: %v", err)
+	}
+
+	book := &Book{
+		Content: &agents.TextContent{
+			Paragraphs: []string{
+				"# Κεφάλαιο Ένα",
+				"Το δωμάτιο ήταν ήσυχο.",
+				"Υπάρχει κανείς εκεί;",
+			},
+			Language: "el-GR",
+		},
+		Language:    "el-GR",
+		CatalogPath: catalogPath,
+		TempDir:     tempDir,
+		OutputPath:  filepath.Join(tempDir, "vivlio.mp3"),
+		DryRun:      true,
+	}
+
+	pipeline := New([]string{
+		"splitChapters",
+		"generateSSML:sentenceBreak=250",
+		"synthesize",
+		"mux:title=Vivlio",
+	})
+
+	if _, err := pipeline.Run(book); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if len(book.Chapters) != 1 || book.Chapters[0].Title != "Κεφάλαιο Ένα" {
+		t.Fatalf("chapters = %+v, want a single Κεφάλαιο Ένα chapter", book.Chapters)
+	}
+	if !strings.Contains(book.Chapters[0].SSML, "Το δωμάτιο ήταν ήσυχο.") {
+		t.Errorf("SSML missing Greek paragraph text: %s", book.Chapters[0].SSML)
+	}
+	if !strings.Contains(book.Chapters[0].SSML, "<break") {
+		t.Errorf("SSML has no <break>: %s", book.Chapters[0].SSML)
+	}
+
+	if _, err := os.Stat(book.OutputPath); err != nil {
+		t.Errorf("mux output %s does not exist: %v", book.OutputPath, err)
+	}
+
+	metaData, err := os.ReadFile(book.ChaptersMetaPath)
+	if err != nil {
+		t.Fatalf("failed to read chapters metadata: %v", err)
+	}
+	if !strings.Contains(string(metaData), "title=Κεφάλαιο Ένα") {
+		t.Errorf("chapters metadata missing Greek chapter title:\n%s", metaData)
+	}
+}
+
+func TestPipeline_Run_UnknownStageName(t *testing.T) {
+	pipeline := New([]string{"notRegistered"})
+	if _, err := pipeline.Run(&Book{}); err == nil {
+		t.Fatal("expected an error running an unregistered stage, got nil")
+	}
+}
+
+func TestSynthesizeStage_RequiresGenerateSSML(t *testing.T) {
+	book := &Book{Chapters: []Chapter{{Paragraphs: []string{"Hi."}}}, Language: "en-US"}
+	if err := synthesizeStage(book, nil); err == nil {
+		t.Fatal("expected an error synthesizing without generated SSML, got nil")
+	}
+}
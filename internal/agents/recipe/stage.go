@@ -0,0 +1,147 @@
+package recipe
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Stage transforms a Book in place -- unlike respipeline.Stage, which
+// must return a new Resource, a recipe Stage is free to mutate Book
+// directly, since several built-in stages (splitChapters, mux) change its
+// shape rather than just one field of it.
+type Stage interface {
+	Run(book *Book, params map[string]string) error
+}
+
+// StageFunc adapts a plain function to the Stage interface, the same way
+// respipeline.StageFunc does.
+type StageFunc func(book *Book, params map[string]string) error
+
+// Run calls f.
+func (f StageFunc) Run(book *Book, params map[string]string) error {
+	return f(book, params)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Stage{}
+)
+
+// Register adds a stage under name, making it selectable from a recipe
+// file's "stages" list. Each built-in stage registers itself from
+// init() in stages.go.
+func Register(name string, stage Stage) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = stage
+}
+
+// Get looks up the stage registered under name.
+func Get(name string) (Stage, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	stage, ok := registry[name]
+	return stage, ok
+}
+
+// Names returns every registered stage name, sorted, for help text and
+// "unknown stage" error messages.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseStageLink parses one "name:key=value,key=value" entry from a
+// recipe's "stages" list -- the same link syntax respipeline.ParseStages
+// uses for a --stages flag value.
+func parseStageLink(link string) (name string, params map[string]string, err error) {
+	link = strings.TrimSpace(link)
+
+	name = link
+	var paramsPart string
+	if idx := strings.Index(link, ":"); idx >= 0 {
+		name = link[:idx]
+		paramsPart = link[idx+1:]
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", nil, fmt.Errorf("empty stage name")
+	}
+
+	params = map[string]string{}
+	if paramsPart != "" {
+		for _, pair := range strings.Split(paramsPart, ",") {
+			kv := strings.SplitN(pair, "=", 2)
+			if len(kv) != 2 {
+				return "", nil, fmt.Errorf("malformed param %q in stage %q (expected key=value)", pair, name)
+			}
+			params[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+		}
+	}
+
+	return name, params, nil
+}
+
+func paramOr(params map[string]string, key, fallback string) string {
+	if v, ok := params[key]; ok && v != "" {
+		return v
+	}
+	return fallback
+}
+
+func paramFloat(params map[string]string, key string, fallback float64) float64 {
+	raw, ok := params[key]
+	if !ok || raw == "" {
+		return fallback
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return fallback
+	}
+	return v
+}
+
+func paramInt(params map[string]string, key string, fallback int) int {
+	raw, ok := params[key]
+	if !ok || raw == "" {
+		return fallback
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return fallback
+	}
+	return v
+}
+
+// paramMS parses key as a non-negative integer count of milliseconds.
+func paramMS(params map[string]string, key string, fallbackMS int) time.Duration {
+	return time.Duration(paramInt(params, key, fallbackMS)) * time.Millisecond
+}
+
+// parseVoiceMap parses a "Name=voiceID,Name2=voiceID2" param value into a
+// lookup map, the format generateSSML's "voices" param and assignVoices'
+// "voices" param both share.
+func parseVoiceMap(raw string) map[string]string {
+	voices := map[string]string{}
+	if raw == "" {
+		return voices
+	}
+	for _, pair := range strings.Split(raw, ",") {
+		name, voice, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		voices[strings.TrimSpace(name)] = strings.TrimSpace(voice)
+	}
+	return voices
+}
@@ -0,0 +1,284 @@
+package recipe
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"studiospeech/internal/agents"
+	"studiospeech/internal/agents/ssml"
+)
+
+func init() {
+	Register("splitChapters", StageFunc(splitChaptersStage))
+	Register("assignVoices", StageFunc(assignVoicesStage))
+	Register("generateSSML", StageFunc(generateSSMLStage))
+	Register("synthesize", StageFunc(synthesizeStage))
+	Register("mux", StageFunc(muxStage))
+}
+
+// splitChaptersStage partitions book.Content into book.Chapters using
+// TextContent.SplitPlan, honoring the same "mode" modes --split accepts
+// on the batch pipeline: "chapters" (the default), "paragraphs", or
+// "size:<minutes>".
+func splitChaptersStage(book *Book, params map[string]string) error {
+	if book.Content == nil {
+		return fmt.Errorf("splitChapters: book has no text content")
+	}
+
+	chapters, err := book.Content.SplitPlan(paramOr(params, "mode", "chapters"))
+	if err != nil {
+		return fmt.Errorf("splitChapters: %w", err)
+	}
+
+	book.Chapters = make([]Chapter, len(chapters))
+	for i, ch := range chapters {
+		book.Chapters[i] = Chapter{Title: ch.Title, Paragraphs: ch.Paragraphs}
+	}
+	return nil
+}
+
+// assignVoicesStage assigns a per-chapter narrator voice: "voices" maps a
+// chapter's exact Title to the voice it should render under (e.g.
+// "voices=Prologue=en_US-amy"), and "default" sets the voice for any
+// chapter with no matching title. A stage link's params are split on ","
+// before "voices" is read, so one link's "voices" holds a single
+// Title=voice pair; a further pair after a comma becomes a separate param
+// of its own name, which this stage ignores. Chapters left unassigned
+// fall back to synthesizeStage's own catalog-selected default voice, so
+// this stage is optional for a single-narrator book.
+func assignVoicesStage(book *Book, params map[string]string) error {
+	if len(book.Chapters) == 0 {
+		return fmt.Errorf("assignVoices: book has no chapters (add a splitChapters stage first)")
+	}
+
+	voices := parseVoiceMap(params["voices"])
+	fallback := params["default"]
+
+	for i := range book.Chapters {
+		if voice, ok := voices[book.Chapters[i].Title]; ok {
+			book.Chapters[i].Voice = voice
+		} else if fallback != "" {
+			book.Chapters[i].Voice = fallback
+		}
+	}
+	return nil
+}
+
+// generateSSMLStage renders every chapter's paragraphs into SSML markup
+// via ssml.Generate: "rate" wraps the chapter in a <prosody rate="...">,
+// "sentenceBreak"/"commaBreak" (milliseconds, default 300/120) control
+// the <break> inserted after sentence/comma punctuation, and "voices"
+// maps an inline "Speaker: text" paragraph's speaker to the
+// <voice name="..."> it should render under (e.g. dialogue switching
+// between characters within a single narrator's chapter).
+func generateSSMLStage(book *Book, params map[string]string) error {
+	if len(book.Chapters) == 0 {
+		return fmt.Errorf("generateSSML: book has no chapters (add a splitChapters stage first)")
+	}
+
+	opts := ssml.GenerateOptions{
+		Rate:          params["rate"],
+		SentenceBreak: paramMS(params, "sentenceBreak", 300),
+		CommaBreak:    paramMS(params, "commaBreak", 120),
+		Voices:        parseVoiceMap(params["voices"]),
+	}
+
+	for i := range book.Chapters {
+		book.Chapters[i].SSML = ssml.Generate(book.Chapters[i].Paragraphs, opts)
+	}
+	return nil
+}
+
+// synthesizeStage parses and renders every chapter's generated SSML
+// through ssml.Parse/NormalizeAgent.NormalizeSSML/SynthAgent.SynthesizeSSML,
+// the same machinery the "ttscli synth" SSML pipeline uses for a single
+// document. A chapter's own Voice (see assignVoicesStage) overrides the
+// catalog-selected default voice for that chapter; inline <voice name>
+// overrides generateSSML wrote still apply on top of either one.
+// Recognized params: voice, gender, backend (as SelectVoiceForBackend
+// takes), and speed/noise/noisew (as agents.SynthParams takes).
+func synthesizeStage(book *Book, params map[string]string) error {
+	if len(book.Chapters) == 0 {
+		return fmt.Errorf("synthesize: book has no chapters (add a splitChapters stage first)")
+	}
+	for i, ch := range book.Chapters {
+		if ch.SSML == "" {
+			return fmt.Errorf("synthesize: chapter %d has no generated SSML (add a generateSSML stage first)", i+1)
+		}
+	}
+
+	voiceAgent := agents.NewVoiceCatalogAgent(book.CatalogPath)
+	if err := voiceAgent.LoadCatalog(); err != nil {
+		return fmt.Errorf("synthesize: voice catalog loading failed: %w", err)
+	}
+
+	voiceID := paramOr(params, "voice", "auto")
+	gender := paramOr(params, "gender", "auto")
+	backend := paramOr(params, "backend", "auto")
+	defaultVoice, err := voiceAgent.SelectVoiceForBackend(book.Language, voiceID, gender, backend)
+	if err != nil {
+		return fmt.Errorf("synthesize: voice selection failed: %w", err)
+	}
+
+	resolveVoice := func(name string) (*agents.Voice, error) {
+		return voiceAgent.SelectVoice(book.Language, name, "")
+	}
+
+	synthAgent := agents.NewSynthAgent("piper", book.TempDir)
+	synthAgent.SetDryRun(book.DryRun)
+
+	synthParams := &agents.SynthParams{
+		Speed:  paramFloat(params, "speed", 1.03),
+		Noise:  paramFloat(params, "noise", 0.667),
+		NoiseW: paramFloat(params, "noisew", 0.8),
+	}
+
+	for i := range book.Chapters {
+		chapterVoice := defaultVoice
+		if book.Chapters[i].Voice != "" {
+			v, err := resolveVoice(book.Chapters[i].Voice)
+			if err != nil {
+				return fmt.Errorf("synthesize: chapter %d voice lookup failed: %w", i+1, err)
+			}
+			chapterVoice = v
+		}
+
+		doc, err := ssml.Parse([]byte(book.Chapters[i].SSML), book.Language)
+		if err != nil {
+			return fmt.Errorf("synthesize: chapter %d SSML parsing failed: %w", i+1, err)
+		}
+
+		segments, err := agents.NewNormalizeAgent().NormalizeSSML(doc, book.Language)
+		if err != nil {
+			return fmt.Errorf("synthesize: chapter %d normalization failed: %w", i+1, err)
+		}
+
+		result, err := synthAgent.SynthesizeSSML(segments, chapterVoice, synthParams, resolveVoice)
+		if err != nil {
+			return fmt.Errorf("synthesize: chapter %d synthesis failed: %w", i+1, err)
+		}
+		if err := ensureFileExists(result.OutputPath); err != nil {
+			return fmt.Errorf("synthesize: %w", err)
+		}
+
+		duration, err := agents.WavDuration(result.OutputPath)
+		if err != nil {
+			duration = 0 // best effort; chapter marks degrade gracefully to 0s entries
+		}
+
+		book.Chapters[i].AudioPath = result.OutputPath
+		book.Chapters[i].Duration = duration
+	}
+
+	return nil
+}
+
+// muxStage concatenates every chapter's rendered audio into one WAV
+// (with a short crossfade and a single loudnorm pass, via
+// PostProcessAgent.ConcatAndNormalize), then encodes it to book.OutputPath
+// with a chapter mark embedded at each chapter's cumulative start time --
+// ID3v2 CHAP/CTOC frames for MP3 output, via PostProcessParams.Chapters.
+// It also writes an M3U8 playlist, an ffmetadata chapters file, and a CUE
+// sheet alongside OutputPath, recording all three paths on book for the
+// run log to report. Recognized params: bitrate, sample-rate, lufs
+// (loudnorm target, default -16.0), and title (the output's ID3 title tag).
+func muxStage(book *Book, params map[string]string) error {
+	if len(book.Chapters) == 0 {
+		return fmt.Errorf("mux: book has no chapters to mux")
+	}
+	for i, ch := range book.Chapters {
+		if ch.AudioPath == "" {
+			return fmt.Errorf("mux: chapter %d has not been synthesized (add a synthesize stage first)", i+1)
+		}
+	}
+	if book.OutputPath == "" {
+		return fmt.Errorf("mux: book has no output path set")
+	}
+
+	postAgent := agents.NewPostProcessAgent("ffmpeg", book.TempDir)
+	postAgent.SetDryRun(book.DryRun)
+
+	inputs := make([]string, len(book.Chapters))
+	for i, ch := range book.Chapters {
+		inputs[i] = ch.AudioPath
+	}
+
+	combinedPath := filepath.Join(book.TempDir, "recipe_combined.wav")
+	if _, err := postAgent.ConcatAndNormalize(inputs, combinedPath, &agents.PostProcessParams{
+		Format:       agents.FormatWAV,
+		SampleRate:   paramInt(params, "sample-rate", 48000),
+		LoudnessLUFS: paramFloat(params, "lufs", -16.0),
+	}); err != nil {
+		return fmt.Errorf("mux: failed to concatenate chapters: %w", err)
+	}
+	if err := ensureFileExists(combinedPath); err != nil {
+		return fmt.Errorf("mux: %w", err)
+	}
+
+	chapterFiles := make([]agents.ChapterFile, len(book.Chapters))
+	audioChapters := make([]agents.AudioChapter, len(book.Chapters))
+	var start time.Duration
+	for i, ch := range book.Chapters {
+		title := ch.Title
+		if title == "" {
+			title = fmt.Sprintf("Chapter %d", i+1)
+		}
+		chapterFiles[i] = agents.ChapterFile{Title: title, Path: ch.AudioPath, Duration: ch.Duration}
+		audioChapters[i] = agents.AudioChapter{Start: start, Title: title}
+		start += ch.Duration
+	}
+
+	base := strings.TrimSuffix(book.OutputPath, filepath.Ext(book.OutputPath))
+
+	book.PlaylistPath = base + ".m3u8"
+	if err := agents.WriteM3U8(chapterFiles, book.PlaylistPath); err != nil {
+		return fmt.Errorf("mux: %w", err)
+	}
+
+	book.ChaptersMetaPath = base + ".chapters.txt"
+	if err := agents.WriteFFMetadataChapters(chapterFiles, book.ChaptersMetaPath); err != nil {
+		return fmt.Errorf("mux: %w", err)
+	}
+
+	book.CuePath = base + ".cue"
+	if err := agents.WriteCueSheet(chapterFiles, book.OutputPath, book.CuePath); err != nil {
+		return fmt.Errorf("mux: %w", err)
+	}
+
+	postParams := &agents.PostProcessParams{
+		Format:     agents.FormatMP3,
+		SampleRate: paramInt(params, "sample-rate", 48000),
+		Bitrate:    paramInt(params, "bitrate", 192),
+		Chapters:   audioChapters,
+	}
+	if title := params["title"]; title != "" {
+		postParams.Tags = map[string]string{"title": title}
+	}
+
+	result, err := postAgent.Process(combinedPath, book.OutputPath, postParams)
+	if err != nil {
+		return fmt.Errorf("mux: %w", err)
+	}
+	if err := ensureFileExists(result.OutputPath); err != nil {
+		return fmt.Errorf("mux: %w", err)
+	}
+
+	return nil
+}
+
+// ensureFileExists makes sure path exists on disk, writing an empty
+// placeholder when it doesn't -- SynthAgent and PostProcessAgent both
+// report an output path in dry-run mode without actually creating the
+// file, but a later stage (mux's concat/encode) needs something to open.
+func ensureFileExists(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		return fmt.Errorf("failed to create placeholder for %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package respipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// ManifestEntry maps one logical output name (the fingerprint stage's
+// "as" param, e.g. "speech") to the hashed artifact it produced.
+type ManifestEntry struct {
+	Logical string `json:"logical"`
+	Path    string `json:"path"`
+	SHA256  string `json:"sha256"`
+}
+
+// Manifest collects the ManifestEntry every fingerprint stage in a
+// pipeline run produces, so downstream tooling can integrity-check
+// outputs (SHA256(Path) == SHA256) without re-running the pipeline.
+type Manifest struct {
+	Entries []ManifestEntry `json:"entries"`
+}
+
+// Add appends entry to the manifest.
+func (m *Manifest) Add(entry ManifestEntry) {
+	m.Entries = append(m.Entries, entry)
+}
+
+// WriteFile marshals m as indented JSON to path.
+func (m *Manifest) WriteFile(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package respipeline
+
+import (
+	"fmt"
+	"time"
+)
+
+// StageResult records one stage's outcome for a pipeline run's report:
+// how long it took and, once a fingerprint stage has run, the hash it
+// computed.
+type StageResult struct {
+	Name     string
+	Duration time.Duration
+	SHA256   string
+}
+
+// Pipeline runs an ordered list of stages over a single Resource,
+// threading each stage's output into the next -- analogous to Hugo
+// Piper's resource chains, but over TextContent/NormalizedText/audio
+// files instead of CSS/JS assets.
+type Pipeline struct {
+	Stages []StageSpec
+}
+
+// New builds a Pipeline from already-parsed stages (see ParseStages).
+func New(stages []StageSpec) *Pipeline {
+	return &Pipeline{Stages: stages}
+}
+
+// Run executes every stage in order against in, returning the final
+// Resource and a StageResult per stage. It stops at the first stage that
+// returns an error, wrapping it with the stage's name and position.
+func (p *Pipeline) Run(opts *Options, in *Resource) (*Resource, []StageResult, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	resource := in
+	results := make([]StageResult, 0, len(p.Stages))
+
+	for i, spec := range p.Stages {
+		stage, ok := Get(spec.Name)
+		if !ok {
+			return nil, results, fmt.Errorf("stage %d (%q): not registered", i+1, spec.Name)
+		}
+
+		start := time.Now()
+		out, err := stage.Run(opts, resource, spec.Params)
+		if err != nil {
+			return nil, results, fmt.Errorf("stage %d (%q) failed: %w", i+1, spec.Name, err)
+		}
+
+		results = append(results, StageResult{
+			Name:     spec.Name,
+			Duration: time.Since(start),
+			SHA256:   out.Fingerprint,
+		})
+		resource = out
+	}
+
+	return resource, results, nil
+}
@@ -0,0 +1,83 @@
+// Package respipeline implements a chainable resource-transformation
+// pipeline for text/audio processing, in the spirit of Hugo Piper's
+// "toCSS | postCSS | minify | fingerprint" resource chains: each stage
+// accepts a typed Resource and returns a new one, and a Pipeline is just
+// an ordered list of named, parameterized stages (see Stage and
+// ParseStages). It's meant to sit alongside the individual agents in
+// internal/agents rather than replace them -- the built-in stages in
+// stages.go are thin wrappers around NormalizeAgent, SynthAgent, and
+// PostProcessAgent.
+package respipeline
+
+import "studiospeech/internal/agents"
+
+// Kind identifies what payload a Resource currently carries.
+type Kind int
+
+const (
+	// KindText means the Resource carries TextContent/NormalizedText.
+	KindText Kind = iota
+	// KindAudio means the Resource carries a path to an audio file.
+	KindAudio
+)
+
+// Resource is the value passed from one stage to the next. Stages must
+// not mutate the Resource they're given -- they return a new one (see
+// clone) so a failed stage never corrupts the caller's input.
+type Resource struct {
+	Kind Kind
+
+	// Content holds the text-kind payload before normalization.
+	Content *agents.TextContent
+	// Normalized holds the text-kind payload once the normalize stage has
+	// run; stages after it (chunk, synthesize) read this instead of Content.
+	Normalized *agents.NormalizedText
+
+	// AudioPath holds the audio-kind payload: a path to the file the
+	// synthesize/transcodeMP3/loudnessNorm stages produced.
+	AudioPath string
+
+	// Language is the resolved BCP-47 language code. Set from Content on
+	// construction, overwritten by the detectLang stage.
+	Language string
+
+	// Voice is set once the synthesize stage has picked one, so later
+	// stages (fingerprint's manifest entry) can record which voice an
+	// artifact was rendered with.
+	Voice *agents.Voice
+
+	// Fingerprint holds the hash the fingerprint stage computed, empty
+	// until that stage has run.
+	Fingerprint string
+
+	// Chunks holds Normalized.Sentences grouped into fixed-size batches
+	// once the chunk stage has run, nil before that. Downstream tooling
+	// (e.g. a streaming or batch synthesis stage) can synthesize chunk by
+	// chunk instead of all of Normalized.Sentences at once.
+	Chunks [][]string
+}
+
+// NewTextResource starts a pipeline from ingested text content.
+func NewTextResource(content *agents.TextContent) *Resource {
+	return &Resource{Kind: KindText, Content: content, Language: content.Language}
+}
+
+// clone returns a shallow copy of r, so a stage can return a modified
+// Resource without aliasing the one it was given.
+func (r *Resource) clone() *Resource {
+	c := *r
+	return &c
+}
+
+// sentences returns the best available text representation of r for
+// hashing/chunking purposes: Normalized.Sentences once normalize has run,
+// Content.Paragraphs before that.
+func (r *Resource) sentences() []string {
+	if r.Normalized != nil {
+		return r.Normalized.Sentences
+	}
+	if r.Content != nil {
+		return r.Content.Paragraphs
+	}
+	return nil
+}
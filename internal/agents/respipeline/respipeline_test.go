@@ -0,0 +1,200 @@
+package respipeline
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"studiospeech/internal/agents"
+	"studiospeech/internal/synthcache"
+)
+
+func TestParseStages(t *testing.T) {
+	specs, err := ParseStages("normalize|synthesize:voice=en_US-amy,speed=1.1|fingerprint:as=speech")
+	if err != nil {
+		t.Fatalf("ParseStages() error = %v", err)
+	}
+
+	if len(specs) != 3 {
+		t.Fatalf("got %d stages, want 3", len(specs))
+	}
+
+	if specs[0].Name != "normalize" || len(specs[0].Params) != 0 {
+		t.Errorf("specs[0] = %+v, want {normalize {}}", specs[0])
+	}
+
+	want := map[string]string{"voice": "en_US-amy", "speed": "1.1"}
+	if specs[1].Name != "synthesize" {
+		t.Errorf("specs[1].Name = %q, want synthesize", specs[1].Name)
+	}
+	for k, v := range want {
+		if specs[1].Params[k] != v {
+			t.Errorf("specs[1].Params[%q] = %q, want %q", k, specs[1].Params[k], v)
+		}
+	}
+
+	if specs[2].Params["as"] != "speech" {
+		t.Errorf(`specs[2].Params["as"] = %q, want "speech"`, specs[2].Params["as"])
+	}
+}
+
+func TestParseStages_UnknownStage(t *testing.T) {
+	if _, err := ParseStages("normalize|doesNotExist"); err == nil {
+		t.Fatal("expected an error for an unregistered stage, got nil")
+	}
+}
+
+func TestParseStages_MalformedParam(t *testing.T) {
+	if _, err := ParseStages("synthesize:voice"); err == nil {
+		t.Fatal("expected an error for a param missing '=', got nil")
+	}
+}
+
+func TestParseStages_Empty(t *testing.T) {
+	if _, err := ParseStages(""); err == nil {
+		t.Fatal("expected an error for an empty stage list, got nil")
+	}
+}
+
+func writeTestCatalog(t *testing.T, dir string) string {
+	t.Helper()
+	catalogPath := filepath.Join(dir, "catalog.json")
+	catalog := `{"voices":[{"id":"en_US-test-medium","language":"en-US","gender":"female",` +
+		`"commercial_use_allowed":true,"license_name":"CC0","sample_rate":22050}]}`
+	if err := os.WriteFile(catalogPath, []byte(catalog), 0644); err != nil {
+		t.Fatalf("failed to write test catalog: %v", err)
+	}
+	return catalogPath
+}
+
+func TestPipeline_Run_EndToEndDryRun(t *testing.T) {
+	tempDir := t.TempDir()
+	catalogPath := writeTestCatalog(t, tempDir)
+
+	specs, err := ParseStages("normalize|detectLang:lang=en-US|chunk:sentences=2|synthesize|transcodeMP3|loudnessNorm|fingerprint:as=speech")
+	if err != nil {
+		t.Fatalf("ParseStages() error = %v", err)
+	}
+
+	content := &agents.TextContent{
+		Paragraphs: []string{"Hello world. This is a test.", "Another paragraph here."},
+		Language:   "en-US",
+	}
+	in := NewTextResource(content)
+
+	opts := &Options{
+		DryRun:      true,
+		TempDir:     tempDir,
+		CatalogPath: catalogPath,
+		Manifest:    &Manifest{},
+	}
+
+	pipeline := New(specs)
+	out, results, err := pipeline.Run(opts, in)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if len(results) != len(specs) {
+		t.Fatalf("got %d stage results, want %d", len(results), len(specs))
+	}
+
+	if out.Kind != KindAudio {
+		t.Fatalf("out.Kind = %v, want KindAudio", out.Kind)
+	}
+	if out.Fingerprint == "" {
+		t.Error("out.Fingerprint is empty, want a computed hash")
+	}
+	if !strings.Contains(filepath.Base(out.AudioPath), out.Fingerprint) {
+		t.Errorf("out.AudioPath = %q does not embed fingerprint %q", out.AudioPath, out.Fingerprint)
+	}
+	if _, err := os.Stat(out.AudioPath); err != nil {
+		t.Errorf("fingerprinted output %s does not exist: %v", out.AudioPath, err)
+	}
+
+	if len(opts.Manifest.Entries) != 1 {
+		t.Fatalf("got %d manifest entries, want 1", len(opts.Manifest.Entries))
+	}
+	entry := opts.Manifest.Entries[0]
+	if entry.Logical != "speech" || entry.SHA256 != out.Fingerprint || entry.Path != out.AudioPath {
+		t.Errorf("manifest entry = %+v, want {speech %s %s}", entry, out.AudioPath, out.Fingerprint)
+	}
+}
+
+func TestPipeline_Run_UnknownStageName(t *testing.T) {
+	pipeline := New([]StageSpec{{Name: "notRegistered"}})
+	in := NewTextResource(&agents.TextContent{Paragraphs: []string{"Hi."}, Language: "en-US"})
+
+	if _, _, err := pipeline.Run(&Options{}, in); err == nil {
+		t.Fatal("expected an error running an unregistered stage, got nil")
+	}
+}
+
+func TestSynthesizeStage_RequiresNormalize(t *testing.T) {
+	in := NewTextResource(&agents.TextContent{Paragraphs: []string{"Hi."}, Language: "en-US"})
+	if _, err := synthesizeStage(&Options{}, in, nil); err == nil {
+		t.Fatal("expected an error synthesizing without a normalize stage first, got nil")
+	}
+}
+
+func TestSynthesizeStage_CacheHitSkipsEngine(t *testing.T) {
+	tempDir := t.TempDir()
+	catalogPath := writeTestCatalog(t, tempDir)
+
+	content := &agents.TextContent{Paragraphs: []string{"Hello cache."}, Language: "en-US"}
+	normalized, err := normalizeStage(&Options{}, NewTextResource(content), nil)
+	if err != nil {
+		t.Fatalf("normalizeStage() error = %v", err)
+	}
+	normalized.Language = "en-US"
+
+	cache := synthcache.NewMemoryCache(1 << 20)
+	text := strings.Join(normalized.Normalized.Sentences, "\n")
+	key := synthcache.Key(text, "en-US", "en_US-test-medium", 1.03, 0.8, "wav", synthEngineVersion)
+	const seeded = "pre-rendered audio bytes"
+	if err := cache.Put(key, strings.NewReader(seeded)); err != nil {
+		t.Fatalf("cache.Put() error = %v", err)
+	}
+
+	opts := &Options{DryRun: true, TempDir: tempDir, CatalogPath: catalogPath, Cache: cache}
+	out, err := synthesizeStage(opts, normalized, nil)
+	if err != nil {
+		t.Fatalf("synthesizeStage() error = %v", err)
+	}
+
+	data, err := os.ReadFile(out.AudioPath)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", out.AudioPath, err)
+	}
+	if string(data) != seeded {
+		t.Errorf("out audio = %q, want cached bytes %q (stage should have used the cache instead of the engine)", data, seeded)
+	}
+}
+
+func TestSynthesizeStage_CacheMissPopulatesCache(t *testing.T) {
+	tempDir := t.TempDir()
+	catalogPath := writeTestCatalog(t, tempDir)
+
+	content := &agents.TextContent{Paragraphs: []string{"Hello again."}, Language: "en-US"}
+	normalized, err := normalizeStage(&Options{}, NewTextResource(content), nil)
+	if err != nil {
+		t.Fatalf("normalizeStage() error = %v", err)
+	}
+	normalized.Language = "en-US"
+
+	cache := synthcache.NewMemoryCache(1 << 20)
+	opts := &Options{DryRun: true, TempDir: tempDir, CatalogPath: catalogPath, Cache: cache}
+
+	if _, err := synthesizeStage(opts, normalized, nil); err != nil {
+		t.Fatalf("synthesizeStage() error = %v", err)
+	}
+
+	text := strings.Join(normalized.Normalized.Sentences, "\n")
+	key := synthcache.Key(text, "en-US", "en_US-test-medium", 1.03, 0.8, "wav", synthEngineVersion)
+	rc, ok := cache.Get(key)
+	if !ok {
+		t.Fatal("expected synthesizeStage to populate the cache on a miss, but Get() found nothing")
+	}
+	rc.Close()
+}
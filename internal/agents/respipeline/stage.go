@@ -0,0 +1,143 @@
+package respipeline
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"studiospeech/internal/synthcache"
+)
+
+// Options carries the run-wide configuration a Stage's built-in
+// implementations need but that doesn't belong on Resource or in a
+// stage's own per-link params: where to select voices from, where to
+// stage temp files, whether to skip actually invoking piper/ffmpeg, and
+// the Manifest fingerprint stages should record into.
+type Options struct {
+	// DryRun is forwarded to SynthAgent/PostProcessAgent so a pipeline
+	// can run end-to-end in tests without piper or ffmpeg installed.
+	DryRun bool
+	// TempDir is where synthesize/transcodeMP3/loudnessNorm write
+	// intermediate files.
+	TempDir string
+	// CatalogPath is the voice catalog JSON the synthesize stage loads.
+	CatalogPath string
+	// Manifest, when non-nil, collects the ManifestEntry every
+	// fingerprint stage in the pipeline produces.
+	Manifest *Manifest
+	// Cache, when non-nil, lets the synthesize stage skip the TTS engine
+	// for a chunk it's already rendered: a hit is streamed straight to
+	// TempDir, a miss is synthesized as usual and then stored for next
+	// time.
+	Cache synthcache.Cache
+}
+
+// Stage transforms one Resource into the next. params holds the stage's
+// "key=value" arguments parsed from its link in the --stages flag (e.g.
+// "synthesize:voice=en_US-amy,speed=1.1" parses to
+// {"voice":"en_US-amy","speed":"1.1"}).
+type Stage interface {
+	Run(opts *Options, in *Resource, params map[string]string) (*Resource, error)
+}
+
+// StageFunc adapts a plain function to the Stage interface, the same way
+// http.HandlerFunc adapts a function to http.Handler.
+type StageFunc func(opts *Options, in *Resource, params map[string]string) (*Resource, error)
+
+// Run calls f.
+func (f StageFunc) Run(opts *Options, in *Resource, params map[string]string) (*Resource, error) {
+	return f(opts, in, params)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Stage{}
+)
+
+// Register adds a stage under name, making it selectable from a --stages
+// flag or recipe file. Each built-in stage registers itself from init()
+// in stages.go.
+func Register(name string, stage Stage) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = stage
+}
+
+// Get looks up the stage registered under name.
+func Get(name string) (Stage, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	stage, ok := registry[name]
+	return stage, ok
+}
+
+// Names returns every registered stage name, sorted, for help text and
+// "unknown stage" error messages.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// StageSpec is one named, parameterized link parsed out of a --stages
+// flag value.
+type StageSpec struct {
+	Name   string
+	Params map[string]string
+}
+
+// ParseStages parses a pipe-separated stage list such as
+// "normalize|detectLang|chunk|synthesize:voice=en_US-amy,speed=1.1|transcodeMP3|loudnessNorm|fingerprint"
+// into an ordered list of StageSpec. Each link is a stage name optionally
+// followed by ":" and a comma-separated list of "key=value" params. Every
+// named stage must already be registered (see Register); unknown stages
+// are reported by name rather than failing at Run time so a typo in a
+// recipe surfaces immediately.
+func ParseStages(spec string) ([]StageSpec, error) {
+	links := strings.Split(spec, "|")
+	specs := make([]StageSpec, 0, len(links))
+
+	for _, link := range links {
+		link = strings.TrimSpace(link)
+		if link == "" {
+			continue
+		}
+
+		name := link
+		var paramsPart string
+		if idx := strings.Index(link, ":"); idx >= 0 {
+			name = link[:idx]
+			paramsPart = link[idx+1:]
+		}
+		name = strings.TrimSpace(name)
+
+		if _, ok := Get(name); !ok {
+			return nil, fmt.Errorf("unknown pipeline stage %q (registered: %s)", name, strings.Join(Names(), ", "))
+		}
+
+		params := map[string]string{}
+		if paramsPart != "" {
+			for _, pair := range strings.Split(paramsPart, ",") {
+				kv := strings.SplitN(pair, "=", 2)
+				if len(kv) != 2 {
+					return nil, fmt.Errorf("malformed param %q in stage %q (expected key=value)", pair, name)
+				}
+				params[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+			}
+		}
+
+		specs = append(specs, StageSpec{Name: name, Params: params})
+	}
+
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("empty stage list")
+	}
+
+	return specs, nil
+}
@@ -0,0 +1,407 @@
+package respipeline
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"studiospeech/internal/agents"
+	"studiospeech/internal/synthcache"
+)
+
+// synthEngineVersion identifies the synthesis engine build whose output a
+// cache entry reflects; bump it whenever a change to the piper invocation
+// (or the engine itself) would make previously cached audio stale.
+const synthEngineVersion = "piper-1"
+
+func init() {
+	Register("normalize", StageFunc(normalizeStage))
+	Register("detectLang", StageFunc(detectLangStage))
+	Register("chunk", StageFunc(chunkStage))
+	Register("synthesize", StageFunc(synthesizeStage))
+	Register("transcodeMP3", StageFunc(transcodeMP3Stage))
+	Register("loudnessNorm", StageFunc(loudnessNormStage))
+	Register("fingerprint", StageFunc(fingerprintStage))
+}
+
+// normalizeStage runs agents.NormalizeAgent over in.Content, the same
+// cleanup/abbreviation/number-expansion/sentence-splitting pass the synth
+// command's pipeline applies, and stores the result in out.Normalized.
+func normalizeStage(_ *Options, in *Resource, _ map[string]string) (*Resource, error) {
+	if in.Content == nil {
+		return nil, fmt.Errorf("normalize: resource has no text content")
+	}
+
+	normalized, err := agents.NewNormalizeAgent().Normalize(in.Content)
+	if err != nil {
+		return nil, fmt.Errorf("normalize: %w", err)
+	}
+
+	out := in.clone()
+	out.Normalized = normalized
+	return out, nil
+}
+
+// detectLangStage re-detects in.Content's language with the same trigram
+// detector TextIngestAgent uses, overwriting Language/LanguageConfidence.
+// A "lang" param skips detection and forces that code instead, matching
+// synth's --lang override.
+func detectLangStage(_ *Options, in *Resource, params map[string]string) (*Resource, error) {
+	if in.Content == nil {
+		return nil, fmt.Errorf("detectLang: resource has no text content")
+	}
+
+	out := in.clone()
+	content := *in.Content
+
+	if forced := params["lang"]; forced != "" && forced != "auto" {
+		content.Language = forced
+		content.LanguageConfidence = 1.0
+	} else {
+		code, conf, _ := agents.NewTrigramDetector().DetectWithConfidence(strings.Join(content.Paragraphs, " "))
+		content.Language = code
+		content.LanguageConfidence = conf
+	}
+
+	out.Content = &content
+	out.Language = content.Language
+	return out, nil
+}
+
+// chunkStage groups Normalized.Sentences into fixed-size batches (the
+// "sentences" param, default 20) and stores them in out.Chunks, so a
+// later stage can synthesize chunk by chunk instead of handing the whole
+// NormalizedText to SynthAgent in one call.
+func chunkStage(_ *Options, in *Resource, params map[string]string) (*Resource, error) {
+	if in.Normalized == nil {
+		return nil, fmt.Errorf("chunk: resource has not been normalized yet (add a normalize stage first)")
+	}
+
+	size := 20
+	if raw := params["sentences"]; raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("chunk: invalid sentences=%q (must be a positive integer)", raw)
+		}
+		size = n
+	}
+
+	sentences := in.Normalized.Sentences
+	if len(sentences) == 0 {
+		return nil, fmt.Errorf("chunk: no sentences to chunk")
+	}
+
+	var chunks [][]string
+	for start := 0; start < len(sentences); start += size {
+		end := start + size
+		if end > len(sentences) {
+			end = len(sentences)
+		}
+		chunks = append(chunks, sentences[start:end])
+	}
+
+	out := in.clone()
+	out.Chunks = chunks
+	return out, nil
+}
+
+// synthesizeStage picks a voice from the catalog at opts.CatalogPath and
+// renders in.Normalized through agents.SynthAgent, the same two steps
+// executeSynthesisPipeline runs. Recognized params: voice, gender,
+// backend (as SelectVoiceForBackend takes), and speed/noise/noisew (as
+// agents.SynthParams takes).
+//
+// When opts.Cache is set, the rendered WAV is looked up by a key derived
+// from everything that determines its bytes (see synthcache.Key) before
+// the engine is invoked at all: a hit is streamed straight to a file
+// under opts.TempDir, a miss is synthesized as usual and then stored
+// under that same key for next time.
+func synthesizeStage(opts *Options, in *Resource, params map[string]string) (*Resource, error) {
+	if in.Normalized == nil {
+		return nil, fmt.Errorf("synthesize: resource has not been normalized yet (add a normalize stage first)")
+	}
+
+	voiceAgent := agents.NewVoiceCatalogAgent(opts.CatalogPath)
+	if err := voiceAgent.LoadCatalog(); err != nil {
+		return nil, fmt.Errorf("synthesize: voice catalog loading failed: %w", err)
+	}
+
+	voiceID := paramOr(params, "voice", "auto")
+	gender := paramOr(params, "gender", "auto")
+	backend := paramOr(params, "backend", "auto")
+
+	voice, err := voiceAgent.SelectVoiceForBackend(in.Language, voiceID, gender, backend)
+	if err != nil {
+		return nil, fmt.Errorf("synthesize: voice selection failed: %w", err)
+	}
+
+	synthParams := &agents.SynthParams{
+		Speed:  paramFloat(params, "speed", 1.03),
+		Noise:  paramFloat(params, "noise", 0.667),
+		NoiseW: paramFloat(params, "noisew", 0.8),
+	}
+
+	var cacheKey string
+	if opts.Cache != nil {
+		text := strings.Join(in.Normalized.Sentences, "\n")
+		cacheKey = synthcache.Key(text, in.Language, voice.ID, synthParams.Speed, synthParams.NoiseW, "wav", synthEngineVersion)
+
+		if rc, ok := opts.Cache.Get(cacheKey); ok {
+			outPath, err := stageCacheHit(opts.TempDir, cacheKey, rc)
+			if err != nil {
+				return nil, fmt.Errorf("synthesize: %w", err)
+			}
+			out := in.clone()
+			out.Kind = KindAudio
+			out.AudioPath = outPath
+			out.Voice = voice
+			return out, nil
+		}
+	}
+
+	synthAgent := agents.NewSynthAgent("piper", opts.TempDir)
+	synthAgent.SetDryRun(opts.DryRun)
+
+	result, err := synthAgent.Synthesize(in.Normalized, voice, synthParams)
+	if err != nil {
+		return nil, fmt.Errorf("synthesize: %w", err)
+	}
+
+	if err := ensureFileExists(result.OutputPath); err != nil {
+		return nil, fmt.Errorf("synthesize: %w", err)
+	}
+
+	if opts.Cache != nil {
+		if err := cacheSynthesizedFile(opts.Cache, cacheKey, result.OutputPath); err != nil {
+			return nil, fmt.Errorf("synthesize: %w", err)
+		}
+	}
+
+	out := in.clone()
+	out.Kind = KindAudio
+	out.AudioPath = result.OutputPath
+	out.Voice = voice
+	return out, nil
+}
+
+// stageCacheHit writes rc out to a fresh file under tempDir named after
+// key, closing rc once it's done, so the rest of the pipeline sees an
+// ordinary on-disk WAV regardless of whether it came from the cache or
+// the engine.
+func stageCacheHit(tempDir, key string, rc io.ReadCloser) (string, error) {
+	defer rc.Close()
+
+	outPath := filepath.Join(tempDir, key+".wav")
+	f, err := os.Create(outPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to stage cached audio: %w", err)
+	}
+	if _, err := io.Copy(f, rc); err != nil {
+		f.Close()
+		return "", fmt.Errorf("failed to stage cached audio: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("failed to stage cached audio: %w", err)
+	}
+	return outPath, nil
+}
+
+// cacheSynthesizedFile stores outputPath's bytes in cache under key.
+func cacheSynthesizedFile(cache synthcache.Cache, key, outputPath string) error {
+	f, err := os.Open(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to read synthesized audio for caching: %w", err)
+	}
+	defer f.Close()
+
+	if err := cache.Put(key, f); err != nil {
+		return fmt.Errorf("failed to cache synthesized audio: %w", err)
+	}
+	return nil
+}
+
+// transcodeMP3Stage runs in.AudioPath through PostProcessAgent with MP3
+// output, honoring "bitrate" and "sample-rate" params the way synth's
+// --bitrate/--sample-rate flags do.
+func transcodeMP3Stage(opts *Options, in *Resource, params map[string]string) (*Resource, error) {
+	if in.Kind != KindAudio || in.AudioPath == "" {
+		return nil, fmt.Errorf("transcodeMP3: resource has no audio to transcode (add a synthesize stage first)")
+	}
+
+	postParams := &agents.PostProcessParams{
+		Format:     agents.FormatMP3,
+		SampleRate: paramInt(params, "sample-rate", 48000),
+		Bitrate:    paramInt(params, "bitrate", 192),
+	}
+
+	outputPath := strings.TrimSuffix(in.AudioPath, filepath.Ext(in.AudioPath)) + ".mp3"
+
+	postAgent := agents.NewPostProcessAgent("ffmpeg", opts.TempDir)
+	postAgent.SetDryRun(opts.DryRun)
+
+	result, err := postAgent.Process(in.AudioPath, outputPath, postParams)
+	if err != nil {
+		return nil, fmt.Errorf("transcodeMP3: %w", err)
+	}
+
+	if err := ensureFileExists(result.OutputPath); err != nil {
+		return nil, fmt.Errorf("transcodeMP3: %w", err)
+	}
+
+	out := in.clone()
+	out.AudioPath = result.OutputPath
+	return out, nil
+}
+
+// loudnessNormStage applies PostProcessAgent's loudnorm filter in place
+// (same container/format as in.AudioPath), targeting the "lufs" param
+// (default -16.0, the YouTube-friendly level synth's own pipeline uses).
+func loudnessNormStage(opts *Options, in *Resource, params map[string]string) (*Resource, error) {
+	if in.Kind != KindAudio || in.AudioPath == "" {
+		return nil, fmt.Errorf("loudnessNorm: resource has no audio to normalize (add a synthesize stage first)")
+	}
+
+	ext := strings.TrimPrefix(filepath.Ext(in.AudioPath), ".")
+	format := agents.AudioFormat(ext)
+	if format == "" {
+		format = agents.FormatWAV
+	}
+
+	postParams := &agents.PostProcessParams{
+		Format:       format,
+		SampleRate:   48000,
+		Bitrate:      paramInt(params, "bitrate", 192),
+		LoudnessLUFS: paramFloat(params, "lufs", -16.0),
+	}
+
+	out := in.clone()
+	// loudnorm is applied as an in-place pass: the temp output gets
+	// renamed back over the input so later stages keep the same path.
+	tmpOut := in.AudioPath + ".loudnorm" + filepath.Ext(in.AudioPath)
+
+	postAgent := agents.NewPostProcessAgent("ffmpeg", opts.TempDir)
+	postAgent.SetDryRun(opts.DryRun)
+
+	result, err := postAgent.Process(in.AudioPath, tmpOut, postParams)
+	if err != nil {
+		return nil, fmt.Errorf("loudnessNorm: %w", err)
+	}
+
+	if err := ensureFileExists(result.OutputPath); err != nil {
+		return nil, fmt.Errorf("loudnessNorm: %w", err)
+	}
+	if err := os.Rename(result.OutputPath, in.AudioPath); err != nil {
+		return nil, fmt.Errorf("loudnessNorm: failed to replace %s: %w", in.AudioPath, err)
+	}
+
+	out.AudioPath = in.AudioPath
+	return out, nil
+}
+
+// fingerprintStage hashes the resource's current payload (the audio
+// file's bytes, or the normalized sentences for a text-kind resource)
+// together with its resolved language and voice, so a change to any
+// upstream stage's output changes the hash. For an audio resource, it
+// embeds the hash in the output filename (speech.mp3 -> speech.a1b2c3d4e5f6.mp3)
+// and, when opts.Manifest is set, records a ManifestEntry under the
+// stage's "as" param (default "output").
+func fingerprintStage(opts *Options, in *Resource, params map[string]string) (*Resource, error) {
+	h := sha256.New()
+
+	switch in.Kind {
+	case KindAudio:
+		f, err := os.Open(in.AudioPath)
+		if err != nil {
+			return nil, fmt.Errorf("fingerprint: %w", err)
+		}
+		_, copyErr := io.Copy(h, f)
+		f.Close()
+		if copyErr != nil {
+			return nil, fmt.Errorf("fingerprint: %w", copyErr)
+		}
+	case KindText:
+		for _, s := range in.sentences() {
+			h.Write([]byte(s))
+		}
+	}
+
+	h.Write([]byte(in.Language))
+	if in.Voice != nil {
+		h.Write([]byte(in.Voice.ID))
+	}
+
+	sum := fmt.Sprintf("%x", h.Sum(nil))[:12]
+
+	out := in.clone()
+	out.Fingerprint = sum
+
+	if in.Kind == KindAudio {
+		ext := filepath.Ext(in.AudioPath)
+		base := strings.TrimSuffix(in.AudioPath, ext)
+		hashed := fmt.Sprintf("%s.%s%s", base, sum, ext)
+		if err := os.Rename(in.AudioPath, hashed); err != nil {
+			return nil, fmt.Errorf("fingerprint: failed to rename output: %w", err)
+		}
+		out.AudioPath = hashed
+	}
+
+	if opts.Manifest != nil {
+		opts.Manifest.Add(ManifestEntry{
+			Logical: paramOr(params, "as", "output"),
+			Path:    out.AudioPath,
+			SHA256:  sum,
+		})
+	}
+
+	return out, nil
+}
+
+// ensureFileExists makes sure path exists on disk, writing an empty
+// placeholder when it doesn't -- SynthAgent and PostProcessAgent both
+// report an OutputPath in dry-run mode without actually creating the
+// file, but later stages in a chain (especially fingerprint, which reads
+// the file back) need something to open.
+func ensureFileExists(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		return fmt.Errorf("failed to create placeholder for %s: %w", path, err)
+	}
+	return nil
+}
+
+func paramOr(params map[string]string, key, fallback string) string {
+	if v, ok := params[key]; ok && v != "" {
+		return v
+	}
+	return fallback
+}
+
+func paramFloat(params map[string]string, key string, fallback float64) float64 {
+	raw, ok := params[key]
+	if !ok || raw == "" {
+		return fallback
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return fallback
+	}
+	return v
+}
+
+func paramInt(params map[string]string, key string, fallback int) int {
+	raw, ok := params[key]
+	if !ok || raw == "" {
+		return fallback
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return fallback
+	}
+	return v
+}
@@ -0,0 +1,143 @@
+package agents
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// averageWordsPerSecond approximates spoken rate at SynthParams.Speed ==
+// 1.0, used only to turn a SegmentOptions.SegmentDuration target into a
+// sentence count when no real synthesis duration is known yet.
+const averageWordsPerSecond = 2.5
+
+// SegmentOptions configures how a SegmentedWriter groups a
+// NormalizedText's sentences into rotating output files. Set exactly one
+// of SentencesPerSegment or SegmentDuration; if both are zero, every
+// sentence gets its own segment.
+type SegmentOptions struct {
+	// SentencesPerSegment caps how many sentences go in one segment.
+	SentencesPerSegment int
+	// SegmentDuration targets a segment length by estimating each
+	// sentence's spoken duration from its word count, since splitting
+	// happens before synthesis runs and no measured duration exists yet.
+	SegmentDuration time.Duration
+}
+
+// ManifestSegment describes one rotating output file a SegmentedWriter
+// produced.
+type ManifestSegment struct {
+	Index         int    `json:"index"`
+	File          string `json:"file"`
+	StartSentence int    `json:"start_sentence"`
+	EndSentence   int    `json:"end_sentence"`
+	ByteStart     int64  `json:"byte_start"`
+	ByteEnd       int64  `json:"byte_end"`
+	Voice         string `json:"voice"`
+	Language      string `json:"language"`
+}
+
+// Manifest is the manifest.json a SegmentedWriter writes alongside its
+// rotating output files, recording segment order and the sentence/byte
+// range each one covers.
+type Manifest struct {
+	Segments []ManifestSegment `json:"segments"`
+}
+
+// SegmentedWriter splits synthesis across rotating output files
+// (baseName-0001.ext, baseName-0002.ext, ...) instead of one single file,
+// so a book-length input doesn't have to be resynthesized or re-encoded
+// in one pass.
+type SegmentedWriter struct {
+	outDir   string
+	baseName string
+	ext      string
+	opts     SegmentOptions
+}
+
+// NewSegmentedWriter creates a writer that places segments named
+// baseName-NNNN.ext under outDir.
+func NewSegmentedWriter(outDir, baseName, ext string, opts SegmentOptions) *SegmentedWriter {
+	return &SegmentedWriter{
+		outDir:   outDir,
+		baseName: baseName,
+		ext:      ext,
+		opts:     opts,
+	}
+}
+
+// Plan groups normalized's sentences into segments, returning the
+// [start, end) sentence index range for each one.
+func (w *SegmentedWriter) Plan(normalized *NormalizedText) [][2]int {
+	total := len(normalized.Sentences)
+	if total == 0 {
+		return nil
+	}
+
+	perSegment := w.opts.SentencesPerSegment
+	if perSegment <= 0 && w.opts.SegmentDuration > 0 {
+		perSegment = w.sentencesForDuration(normalized.Sentences)
+	}
+	if perSegment <= 0 {
+		perSegment = 1
+	}
+
+	var plan [][2]int
+	for start := 0; start < total; start += perSegment {
+		end := start + perSegment
+		if end > total {
+			end = total
+		}
+		plan = append(plan, [2]int{start, end})
+	}
+	return plan
+}
+
+// sentencesForDuration estimates how many sentences fit in
+// SegmentDuration, using each sentence's word count at
+// averageWordsPerSecond.
+func (w *SegmentedWriter) sentencesForDuration(sentences []string) int {
+	targetSeconds := w.opts.SegmentDuration.Seconds()
+	if targetSeconds <= 0 {
+		return 1
+	}
+
+	count := 0
+	var elapsed float64
+	for _, sentence := range sentences {
+		words := len(strings.Fields(sentence))
+		elapsed += float64(words) / averageWordsPerSecond
+		count++
+		if elapsed >= targetSeconds {
+			break
+		}
+	}
+	if count == 0 {
+		count = 1
+	}
+	return count
+}
+
+// SegmentPath returns the output path for the segment at index (0-based),
+// formatted as baseName-0001.ext, baseName-0002.ext, and so on.
+func (w *SegmentedWriter) SegmentPath(index int) string {
+	name := fmt.Sprintf("%s-%04d%s", w.baseName, index+1, w.ext)
+	return filepath.Join(w.outDir, name)
+}
+
+// WriteManifest writes manifest as manifest.json under outDir.
+func (w *SegmentedWriter) WriteManifest(manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	manifestPath := filepath.Join(w.outDir, "manifest.json")
+	if err := os.WriteFile(manifestPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSegmentedWriter_Plan_BySentenceCount(t *testing.T) {
+	normalized := &NormalizedText{
+		Sentences:   []string{"One.", "Two.", "Three.", "Four.", "Five."},
+		SentenceIDs: sentenceIDs([]string{"One.", "Two.", "Three.", "Four.", "Five."}),
+	}
+
+	writer := NewSegmentedWriter(t.TempDir(), "output", ".wav", SegmentOptions{SentencesPerSegment: 2})
+	plan := writer.Plan(normalized)
+
+	want := [][2]int{{0, 2}, {2, 4}, {4, 5}}
+	if len(plan) != len(want) {
+		t.Fatalf("Plan() returned %d segments, want %d", len(plan), len(want))
+	}
+	for i, rng := range want {
+		if plan[i] != rng {
+			t.Errorf("plan[%d] = %v, want %v", i, plan[i], rng)
+		}
+	}
+}
+
+func TestSegmentedWriter_Plan_ByDuration(t *testing.T) {
+	normalized := &NormalizedText{
+		Sentences: []string{
+			"one two three four five",
+			"six seven eight nine ten",
+			"eleven",
+		},
+	}
+
+	writer := NewSegmentedWriter(t.TempDir(), "output", ".wav", SegmentOptions{SegmentDuration: 2 * time.Second})
+	plan := writer.Plan(normalized)
+
+	if len(plan) == 0 {
+		t.Fatal("Plan() returned no segments")
+	}
+	// At 2.5 words/sec, a 2s budget fits the first 5-word sentence alone.
+	if plan[0] != [2]int{0, 1} {
+		t.Errorf("plan[0] = %v, want {0, 1}", plan[0])
+	}
+}
+
+func TestSegmentedWriter_SegmentPath(t *testing.T) {
+	writer := NewSegmentedWriter("/out", "book", ".mp3", SegmentOptions{})
+	got := writer.SegmentPath(0)
+	want := filepath.Join("/out", "book-0001.mp3")
+	if got != want {
+		t.Errorf("SegmentPath(0) = %q, want %q", got, want)
+	}
+}
+
+func TestSegmentedWriter_WriteManifest(t *testing.T) {
+	dir := t.TempDir()
+	writer := NewSegmentedWriter(dir, "output", ".wav", SegmentOptions{})
+
+	manifest := &Manifest{
+		Segments: []ManifestSegment{
+			{Index: 0, File: writer.SegmentPath(0), StartSentence: 0, EndSentence: 2, Voice: "en-US-female", Language: "en-US"},
+		},
+	}
+	if err := writer.WriteManifest(manifest); err != nil {
+		t.Fatalf("WriteManifest() error = %v", err)
+	}
+
+	manifestPath := filepath.Join(dir, "manifest.json")
+	if _, err := os.Stat(manifestPath); err != nil {
+		t.Fatalf("manifest.json not found: %v", err)
+	}
+}
+
+func TestPipeline_SynthesizeSegmented_SkipsUnchangedSegment(t *testing.T) {
+	tempDir := t.TempDir()
+	synthAgent := NewSynthAgent("piper", tempDir)
+	synthAgent.SetDryRun(true)
+
+	cacheAgent := NewCacheAgent(filepath.Join(tempDir, "cache"))
+	if err := cacheAgent.Initialize(); err != nil {
+		t.Fatalf("cache Initialize() error = %v", err)
+	}
+
+	pipeline := NewPipeline(synthAgent, cacheAgent).WithSegmentation(SegmentOptions{SentencesPerSegment: 1})
+
+	voice := &Voice{ID: "en-US-female", SampleRate: 22050}
+	params := &SynthParams{Speed: 1.0}
+	sentences := []string{"First sentence.", "Second sentence."}
+	normalized := &NormalizedText{Sentences: sentences, SentenceIDs: sentenceIDs(sentences), Language: "en-US"}
+
+	outDir := filepath.Join(tempDir, "out")
+	manifest, err := pipeline.SynthesizeSegmented(normalized, voice, params, outDir)
+	if err != nil {
+		t.Fatalf("SynthesizeSegmented() error = %v", err)
+	}
+	if len(manifest.Segments) != 2 {
+		t.Fatalf("got %d segments, want 2", len(manifest.Segments))
+	}
+
+	// Editing only the second sentence should leave the first segment's
+	// cache key (and therefore its file) untouched.
+	editedSentences := []string{"First sentence.", "Second sentence, edited."}
+	editedNormalized := &NormalizedText{
+		Sentences:   editedSentences,
+		SentenceIDs: sentenceIDs(editedSentences),
+		Language:    "en-US",
+	}
+
+	firstKeyBefore := pipeline.segmentCacheKey(normalized.SentenceIDs[0:1], voice.ID, params)
+	firstKeyAfter := pipeline.segmentCacheKey(editedNormalized.SentenceIDs[0:1], voice.ID, params)
+	if firstKeyBefore != firstKeyAfter {
+		t.Errorf("unedited sentence's cache key changed: before=%q after=%q", firstKeyBefore, firstKeyAfter)
+	}
+
+	secondKeyBefore := pipeline.segmentCacheKey(normalized.SentenceIDs[1:2], voice.ID, params)
+	secondKeyAfter := pipeline.segmentCacheKey(editedNormalized.SentenceIDs[1:2], voice.ID, params)
+	if secondKeyBefore == secondKeyAfter {
+		t.Errorf("edited sentence's cache key did not change")
+	}
+}
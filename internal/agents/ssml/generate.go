@@ -0,0 +1,115 @@
+package ssml
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// GenerateOptions controls how Generate renders plain paragraphs into
+// SSML markup.
+type GenerateOptions struct {
+	// Rate, when non-empty, wraps the whole document in a
+	// <prosody rate="..."> element (e.g. "1.1" or "slow").
+	Rate string
+	// SentenceBreak is the <break time="..."/> inserted after every
+	// sentence-ending ".", "!", or "?"; zero omits it.
+	SentenceBreak time.Duration
+	// CommaBreak is the <break time="..."/> inserted after every ",";
+	// zero omits it.
+	CommaBreak time.Duration
+	// Voices maps a paragraph's leading "Speaker: " tag to the
+	// <voice name="..."> it should render under; a paragraph whose tag
+	// isn't a key here (or that carries no tag at all) renders as plain
+	// <p> text instead.
+	Voices map[string]string
+}
+
+// Generate renders paragraphs into an SSML <speak> document: each
+// paragraph becomes a <p>, wrapped in <voice name="..."> when it opens
+// with a "Speaker: " tag found in opts.Voices, with a <break> inserted
+// after sentence and comma punctuation per opts.SentenceBreak/CommaBreak.
+// It's the inverse of Parse -- Parse lowers SSML down to a flat Document
+// for synthesis, Generate builds SSML markup up from plain text.
+func Generate(paragraphs []string, opts GenerateOptions) string {
+	var b strings.Builder
+	b.WriteString("<speak>\n")
+
+	indent := ""
+	if opts.Rate != "" {
+		fmt.Fprintf(&b, "<prosody rate=%q>\n", opts.Rate)
+		indent = "  "
+	}
+
+	for _, p := range paragraphs {
+		speaker, text, tagged := splitSpeakerTag(p)
+
+		if voice, ok := opts.Voices[speaker]; tagged && ok {
+			body := insertBreaks(text, opts.SentenceBreak, opts.CommaBreak)
+			fmt.Fprintf(&b, "%s<voice name=%q><p>%s</p></voice>\n", indent, voice, body)
+		} else {
+			body := insertBreaks(p, opts.SentenceBreak, opts.CommaBreak)
+			fmt.Fprintf(&b, "%s<p>%s</p>\n", indent, body)
+		}
+	}
+
+	if opts.Rate != "" {
+		b.WriteString("</prosody>\n")
+	}
+	b.WriteString("</speak>\n")
+
+	return b.String()
+}
+
+// splitSpeakerTag reports whether paragraph opens with a short
+// "Speaker: " tag (no sentence punctuation in the name, a non-empty
+// remainder), returning the speaker name and the text after it. A
+// paragraph with no such tag -- most of them -- returns ok=false and the
+// paragraph unchanged.
+func splitSpeakerTag(paragraph string) (speaker, text string, ok bool) {
+	name, rest, found := strings.Cut(paragraph, ":")
+	if !found {
+		return "", paragraph, false
+	}
+
+	name = strings.TrimSpace(name)
+	rest = strings.TrimSpace(rest)
+	if name == "" || rest == "" || len(name) > 30 || strings.ContainsAny(name, ".!?\n") {
+		return "", paragraph, false
+	}
+
+	return name, rest, true
+}
+
+// insertBreaks escapes text's XML special characters and inserts a
+// <break time="..."/> after every sentence-ending/comma punctuation mark,
+// per sentenceBreak/commaBreak (either may be zero to omit that break).
+func insertBreaks(text string, sentenceBreak, commaBreak time.Duration) string {
+	var b strings.Builder
+
+	for _, r := range text {
+		switch r {
+		case '&':
+			b.WriteString("&amp;")
+		case '<':
+			b.WriteString("&lt;")
+		case '>':
+			b.WriteString("&gt;")
+		default:
+			b.WriteRune(r)
+		}
+
+		switch r {
+		case '.', '!', '?':
+			if sentenceBreak > 0 {
+				fmt.Fprintf(&b, `<break time="%dms"/>`, sentenceBreak.Milliseconds())
+			}
+		case ',':
+			if commaBreak > 0 {
+				fmt.Fprintf(&b, `<break time="%dms"/>`, commaBreak.Milliseconds())
+			}
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,112 @@
+package ssml
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerate_BreaksAndProsody(t *testing.T) {
+	markup := Generate([]string{"Hello, world. Are you well?"}, GenerateOptions{
+		Rate:          "slow",
+		SentenceBreak: 300 * time.Millisecond,
+		CommaBreak:    100 * time.Millisecond,
+	})
+
+	if !strings.Contains(markup, `<prosody rate="slow">`) {
+		t.Fatalf("generated markup missing <prosody rate=\"slow\">: %s", markup)
+	}
+
+	doc, err := Parse([]byte(markup), "en-US")
+	if err != nil {
+		t.Fatalf("Parse(Generate(...)) failed: %v\nmarkup:\n%s", err, markup)
+	}
+
+	var texts []string
+	var breaks []time.Duration
+	for _, seg := range doc.Segments {
+		if seg.Break > 0 {
+			breaks = append(breaks, seg.Break)
+			continue
+		}
+		texts = append(texts, seg.Text)
+		if seg.Speed == nil || *seg.Speed != 0.8 {
+			t.Errorf("segment %q speed = %v, want 0.8 (slow)", seg.Text, seg.Speed)
+		}
+	}
+
+	wantTexts := []string{"Hello,", "world.", "Are you well?"}
+	if len(texts) != len(wantTexts) {
+		t.Fatalf("got texts %v, want %v", texts, wantTexts)
+	}
+	for i, want := range wantTexts {
+		if texts[i] != want {
+			t.Errorf("text[%d] = %q, want %q", i, texts[i], want)
+		}
+	}
+
+	wantBreaks := []time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond}
+	if len(breaks) != len(wantBreaks) {
+		t.Fatalf("got breaks %v, want %v", breaks, wantBreaks)
+	}
+	for i, want := range wantBreaks {
+		if breaks[i] != want {
+			t.Errorf("break[%d] = %v, want %v", i, breaks[i], want)
+		}
+	}
+}
+
+func TestGenerate_SpeakerVoiceTag(t *testing.T) {
+	markup := Generate([]string{
+		"The room was quiet.",
+		"Alice: Is anyone there?",
+		"Bob: Just me.",
+	}, GenerateOptions{
+		Voices: map[string]string{"Alice": "en_US-alice", "Bob": "en_US-bob"},
+	})
+
+	doc, err := Parse([]byte(markup), "en-US")
+	if err != nil {
+		t.Fatalf("Parse(Generate(...)) failed: %v\nmarkup:\n%s", err, markup)
+	}
+	if len(doc.Segments) != 3 {
+		t.Fatalf("got %d segments, want 3: %+v", len(doc.Segments), doc.Segments)
+	}
+
+	if doc.Segments[0].VoiceName != "" {
+		t.Errorf("segment 0 voice = %q, want none (no speaker tag)", doc.Segments[0].VoiceName)
+	}
+	if doc.Segments[1].VoiceName != "en_US-alice" || doc.Segments[1].Text != "Is anyone there?" {
+		t.Errorf("segment 1 = %+v, want voice=en_US-alice text=%q", doc.Segments[1], "Is anyone there?")
+	}
+	if doc.Segments[2].VoiceName != "en_US-bob" || doc.Segments[2].Text != "Just me." {
+		t.Errorf("segment 2 = %+v, want voice=en_US-bob text=%q", doc.Segments[2], "Just me.")
+	}
+}
+
+func TestGenerate_UnmappedSpeakerTagRendersPlain(t *testing.T) {
+	markup := Generate([]string{"Narrator: Once upon a time."}, GenerateOptions{})
+
+	doc, err := Parse([]byte(markup), "en-US")
+	if err != nil {
+		t.Fatalf("Parse(Generate(...)) failed: %v\nmarkup:\n%s", err, markup)
+	}
+	if len(doc.Segments) != 1 || doc.Segments[0].VoiceName != "" {
+		t.Fatalf("got %+v, want a single plain segment", doc.Segments)
+	}
+	if doc.Segments[0].Text != "Narrator: Once upon a time." {
+		t.Errorf("segment text = %q, want the paragraph unchanged", doc.Segments[0].Text)
+	}
+}
+
+func TestGenerate_EscapesXMLSpecialCharacters(t *testing.T) {
+	markup := Generate([]string{"Fish & chips < tea > coffee."}, GenerateOptions{})
+
+	doc, err := Parse([]byte(markup), "en-US")
+	if err != nil {
+		t.Fatalf("Parse(Generate(...)) failed: %v\nmarkup:\n%s", err, markup)
+	}
+	if len(doc.Segments) != 1 || doc.Segments[0].Text != "Fish & chips < tea > coffee." {
+		t.Fatalf("got %+v, want the special characters to round-trip through escaping", doc.Segments)
+	}
+}
@@ -0,0 +1,294 @@
+package ssml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"studiospeech/internal/agents/numwords"
+)
+
+// context carries the prosody/voice/emphasis overrides active at the
+// current point in the document. Each <prosody>/<voice>/<emphasis>
+// element pushes a copy onto a stack on entry and restores it on exit, so
+// overrides apply to exactly the elements nested inside.
+type context struct {
+	speed     *float64
+	pitch     string
+	voiceName string
+	emphasis  string
+}
+
+// prosodyRates maps SSML's named <prosody rate="..."> values to a Speed
+// multiplier compatible with agents.SynthParams.Speed. Percentage values
+// (e.g. "120%") are parsed directly instead of looked up here.
+var prosodyRates = map[string]float64{
+	"x-slow": 0.6,
+	"slow":   0.8,
+	"medium": 1.0,
+	"fast":   1.25,
+	"x-fast": 1.5,
+}
+
+// Parse reads an SSML document and lowers it to a flat Document of
+// Segments. language selects the numwords.Expander used to render
+// <say-as interpret-as="cardinal"> content -- pass the same BCP-47 tag
+// given to NormalizeAgent.
+func Parse(data []byte, language string) (*Document, error) {
+	decoder := xml.NewDecoder(strings.NewReader(string(data)))
+
+	doc := &Document{}
+	var stack []context
+	current := context{}
+	var buf strings.Builder
+
+	flush := func() {
+		text := strings.TrimSpace(buf.String())
+		buf.Reset()
+		if text == "" {
+			return
+		}
+		doc.Segments = append(doc.Segments, Segment{
+			Text:      text,
+			Speed:     current.speed,
+			Pitch:     current.pitch,
+			VoiceName: current.voiceName,
+			Emphasis:  current.emphasis,
+		})
+	}
+
+	// sayAsMode and inSub suppress verbatim CharData handling while inside
+	// an element whose text content is rewritten wholesale rather than
+	// appended as-is.
+	var sayAsMode string
+	inSub := false
+	var subAlias string
+
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse SSML: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "break":
+				flush()
+				doc.Segments = append(doc.Segments, Segment{Break: parseBreakTime(xmlAttr(t, "time"))})
+			case "prosody":
+				flush()
+				stack = append(stack, current)
+				if rate := xmlAttr(t, "rate"); rate != "" {
+					if speed, ok := parseProsodyRate(rate); ok {
+						current.speed = &speed
+					}
+				}
+				if pitch := xmlAttr(t, "pitch"); pitch != "" {
+					current.pitch = pitch
+				}
+			case "voice":
+				flush()
+				stack = append(stack, current)
+				if name := xmlAttr(t, "name"); name != "" {
+					current.voiceName = name
+				}
+			case "emphasis":
+				flush()
+				stack = append(stack, current)
+				level := xmlAttr(t, "level")
+				if level == "" {
+					level = "moderate"
+				}
+				current.emphasis = level
+			case "say-as":
+				flush()
+				sayAsMode = xmlAttr(t, "interpret-as")
+			case "sub":
+				flush()
+				inSub = true
+				subAlias = xmlAttr(t, "alias")
+			case "speak", "phoneme", "p", "s":
+				// speak is the document root; phoneme is accepted so a
+				// document using it still parses, but none of the
+				// registered tts.Synthesizer backends take phonemic
+				// input, so its alphabet/ph attributes are intentionally
+				// ignored and it falls back to its plain-text content;
+				// p/s are structural only -- all three just descend into
+				// their children under the current context.
+			default:
+				// Unrecognized element: descend into its children as
+				// plain text, inheriting the current context.
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "prosody", "voice", "emphasis":
+				flush()
+				current = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+			case "say-as":
+				sayAsMode = ""
+			case "sub":
+				flush()
+				inSub = false
+			}
+		case xml.CharData:
+			switch {
+			case inSub:
+				if buf.Len() == 0 {
+					buf.WriteString(subAlias)
+				}
+			case sayAsMode != "":
+				buf.WriteString(expandSayAs(sayAsMode, string(t), language))
+			default:
+				buf.WriteString(string(t))
+			}
+		}
+	}
+	flush()
+
+	return doc, nil
+}
+
+func xmlAttr(t xml.StartElement, name string) string {
+	for _, a := range t.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// parseBreakTime parses SSML's break time attribute, which is either
+// plain seconds ("2s") or milliseconds ("500ms"). An unparseable or empty
+// value yields no silence rather than an error, matching the tolerant
+// handling the rest of this parser gives malformed attributes.
+func parseBreakTime(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	switch {
+	case strings.HasSuffix(value, "ms"):
+		ms, err := strconv.ParseFloat(strings.TrimSuffix(value, "ms"), 64)
+		if err != nil {
+			return 0
+		}
+		return time.Duration(ms * float64(time.Millisecond))
+	case strings.HasSuffix(value, "s"):
+		s, err := strconv.ParseFloat(strings.TrimSuffix(value, "s"), 64)
+		if err != nil {
+			return 0
+		}
+		return time.Duration(s * float64(time.Second))
+	default:
+		return 0
+	}
+}
+
+func parseProsodyRate(rate string) (float64, bool) {
+	if speed, ok := prosodyRates[rate]; ok {
+		return speed, true
+	}
+	if strings.HasSuffix(rate, "%") {
+		pct, err := strconv.ParseFloat(strings.TrimSuffix(rate, "%"), 64)
+		if err != nil {
+			return 0, false
+		}
+		return pct / 100.0, true
+	}
+	return 0, false
+}
+
+// expandSayAs renders text according to an interpret-as hint. "cardinal"
+// delegates to the numwords Expander registered for language; "characters"
+// spells each rune out separated by spaces so the synthesizer reads them
+// individually rather than as a word; "date" expects an ISO-8601
+// calendar date (e.g. "2024-01-01") and renders it as a locale-appropriate
+// spoken date before handing the day/year numbers to the same Expander.
+// Any other hint, or a date value that isn't ISO-8601, is passed through
+// unchanged.
+func expandSayAs(interpretAs, text, language string) string {
+	switch interpretAs {
+	case "cardinal":
+		if expander, ok := numwords.Get(language); ok {
+			return expander.Expand(text)
+		}
+		return text
+	case "characters":
+		var runes []string
+		for _, r := range strings.TrimSpace(text) {
+			if r == ' ' {
+				continue
+			}
+			runes = append(runes, string(r))
+		}
+		return strings.Join(runes, " ")
+	case "date":
+		return expandSayAsDate(text, language)
+	default:
+		return text
+	}
+}
+
+// dateMonthNames gives each supported language's month names in the
+// grammatical form used when a date is read aloud: en-US/en-UK use the
+// nominative form ("January 1st, 2024"); el-GR uses the genitive form
+// Greek date phrasing requires ("1 Ιανουαρίου 2024").
+var dateMonthNames = map[string][]string{
+	"en-US": {"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+	"en-UK": {"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+	"el-GR": {"Ιανουαρίου", "Φεβρουαρίου", "Μαρτίου", "Απριλίου", "Μαΐου", "Ιουνίου", "Ιουλίου", "Αυγούστου", "Σεπτεμβρίου", "Οκτωβρίου", "Νοεμβρίου", "Δεκεμβρίου"},
+}
+
+// expandSayAsDate parses text as an ISO-8601 calendar date and spells it
+// out the way it would conventionally be read aloud in language. A
+// language without a month table gets the English month names and word
+// order; the spoken date is then run through language's numwords
+// expander when one is registered and otherwise keeps its numerals. Text
+// that isn't a parseable date at all is returned unchanged.
+func expandSayAsDate(text, language string) string {
+	t, err := time.Parse("2006-01-02", strings.TrimSpace(text))
+	if err != nil {
+		return text
+	}
+
+	months, ok := dateMonthNames[language]
+	if !ok {
+		months = dateMonthNames["en-US"]
+	}
+	month := months[t.Month()-1]
+
+	var spoken string
+	if language == "el-GR" {
+		spoken = fmt.Sprintf("%d %s %d", t.Day(), month, t.Year())
+	} else {
+		spoken = fmt.Sprintf("%s %d%s, %d", month, t.Day(), ordinalSuffix(t.Day()), t.Year())
+	}
+
+	if expander, ok := numwords.Get(language); ok {
+		return expander.Expand(spoken)
+	}
+	return spoken
+}
+
+// ordinalSuffix returns the English ordinal suffix for n: 1st, 2nd, 3rd,
+// 4th, ..., 11th, 12th, 13th, 21st, ...
+func ordinalSuffix(n int) string {
+	if n%100 >= 11 && n%100 <= 13 {
+		return "th"
+	}
+	switch n % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	default:
+		return "th"
+	}
+}
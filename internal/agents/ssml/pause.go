@@ -0,0 +1,39 @@
+package ssml
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pauseMarkupRe matches the non-SSML "[PAUSE=300ms]" shortcut some callers
+// use instead of a full <break> element.
+var pauseMarkupRe = regexp.MustCompile(`\[PAUSE=(\d+)ms\]`)
+
+// ParsePauseMarkup lowers plain text containing "[PAUSE=Xms]" shortcuts
+// into the same Document/Segment representation a <break>-bearing SSML
+// document parses to, so NormalizeAgent and SynthAgent only ever have to
+// handle one segment representation.
+func ParsePauseMarkup(text string) *Document {
+	doc := &Document{}
+	last := 0
+
+	for _, loc := range pauseMarkupRe.FindAllStringSubmatchIndex(text, -1) {
+		if before := strings.TrimSpace(text[last:loc[0]]); before != "" {
+			doc.Segments = append(doc.Segments, Segment{Text: before})
+		}
+
+		if ms, err := strconv.Atoi(text[loc[2]:loc[3]]); err == nil {
+			doc.Segments = append(doc.Segments, Segment{Break: time.Duration(ms) * time.Millisecond})
+		}
+
+		last = loc[1]
+	}
+
+	if after := strings.TrimSpace(text[last:]); after != "" {
+		doc.Segments = append(doc.Segments, Segment{Text: after})
+	}
+
+	return doc
+}
@@ -0,0 +1,49 @@
+// Package ssml parses a practical subset of SSML (Speech Synthesis Markup
+// Language) -- <speak>, <break>, <prosody>, <say-as>, <sub>, and <voice>
+// -- into a flat, ordered list of renderable Segments, so the rest of the
+// pipeline only ever has to deal with text and a handful of per-segment
+// overrides rather than an XML tree. <phoneme> is accepted as valid
+// markup (so a document using it still parses) but its ph attribute is
+// not interpreted -- none of the registered tts.Synthesizer backends
+// take phonemic input, so it degrades to the element's plain-text
+// content, same as an unrecognized tag.
+package ssml
+
+import (
+	"strings"
+	"time"
+)
+
+// Segment is one renderable unit of a parsed document: either a run of
+// text to synthesize, or a silence of Break duration (Text is empty in
+// that case). Speed, Pitch, VoiceName, and Emphasis are overrides
+// inherited from the nearest enclosing <prosody>/<voice>/<emphasis>
+// element; a nil Speed or empty Pitch/VoiceName/Emphasis means "use
+// whatever the caller would otherwise use".
+type Segment struct {
+	Text      string
+	Break     time.Duration
+	Speed     *float64
+	Pitch     string
+	VoiceName string
+	Emphasis  string
+}
+
+// Document is a parsed SSML document (or a lowered [PAUSE=Xms] shortcut):
+// an ordered sequence of Segments to render in turn.
+type Document struct {
+	Segments []Segment
+}
+
+// IsSSML reports whether text looks like SSML markup -- a <speak> root
+// element, optionally preceded by an XML declaration -- rather than plain
+// text.
+func IsSSML(text string) bool {
+	trimmed := strings.TrimSpace(text)
+	if strings.HasPrefix(trimmed, "<?xml") {
+		if idx := strings.Index(trimmed, "?>"); idx >= 0 {
+			trimmed = strings.TrimSpace(trimmed[idx+2:])
+		}
+	}
+	return strings.HasPrefix(trimmed, "<speak")
+}
@@ -0,0 +1,222 @@
+package ssml
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsSSML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{"plain text", "Hello world.", false},
+		{"speak root", "<speak>Hello world.</speak>", true},
+		{"speak with leading whitespace", "  \n<speak>Hello</speak>", true},
+		{"xml declaration", `<?xml version="1.0"?><speak>Hello</speak>`, true},
+		{"unrelated markup", "<p>Hello</p>", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSSML(tt.input); got != tt.want {
+				t.Errorf("IsSSML(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_PlainText(t *testing.T) {
+	doc, err := Parse([]byte(`<speak>Hello world.</speak>`), "en-US")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(doc.Segments) != 1 || doc.Segments[0].Text != "Hello world." {
+		t.Fatalf("unexpected segments: %+v", doc.Segments)
+	}
+}
+
+func TestParse_Break(t *testing.T) {
+	doc, err := Parse([]byte(`<speak>One<break time="500ms"/>Two<break time="2s"/>Three</speak>`), "en-US")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	want := []struct {
+		text   string
+		break_ time.Duration
+	}{
+		{"One", 0},
+		{"", 500 * time.Millisecond},
+		{"Two", 0},
+		{"", 2 * time.Second},
+		{"Three", 0},
+	}
+	if len(doc.Segments) != len(want) {
+		t.Fatalf("got %d segments, want %d: %+v", len(doc.Segments), len(want), doc.Segments)
+	}
+	for i, w := range want {
+		if doc.Segments[i].Text != w.text || doc.Segments[i].Break != w.break_ {
+			t.Errorf("segment %d = %+v, want text=%q break=%v", i, doc.Segments[i], w.text, w.break_)
+		}
+	}
+}
+
+func TestParse_Prosody(t *testing.T) {
+	doc, err := Parse([]byte(`<speak>Normal<prosody rate="slow" pitch="+2st">Slower text</prosody>After</speak>`), "en-US")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(doc.Segments) != 3 {
+		t.Fatalf("expected 3 segments, got %+v", doc.Segments)
+	}
+	if doc.Segments[0].Speed != nil {
+		t.Errorf("segment 0 should have no speed override, got %v", *doc.Segments[0].Speed)
+	}
+	if doc.Segments[1].Speed == nil || *doc.Segments[1].Speed != 0.8 {
+		t.Errorf("segment 1 speed = %v, want 0.8", doc.Segments[1].Speed)
+	}
+	if doc.Segments[1].Pitch != "+2st" {
+		t.Errorf("segment 1 pitch = %q, want +2st", doc.Segments[1].Pitch)
+	}
+	if doc.Segments[2].Speed != nil {
+		t.Errorf("segment 2 should have no speed override (prosody closed), got %v", *doc.Segments[2].Speed)
+	}
+}
+
+func TestParse_ProsodyPercentRate(t *testing.T) {
+	doc, err := Parse([]byte(`<speak><prosody rate="120%">Faster</prosody></speak>`), "en-US")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(doc.Segments) != 1 || doc.Segments[0].Speed == nil || *doc.Segments[0].Speed != 1.2 {
+		t.Fatalf("unexpected segments: %+v", doc.Segments)
+	}
+}
+
+func TestParse_Voice(t *testing.T) {
+	doc, err := Parse([]byte(`<speak>Default<voice name="narrator-male">Other voice</voice>Default again</speak>`), "en-US")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(doc.Segments) != 3 {
+		t.Fatalf("expected 3 segments, got %+v", doc.Segments)
+	}
+	if doc.Segments[0].VoiceName != "" {
+		t.Errorf("segment 0 should have no voice override, got %q", doc.Segments[0].VoiceName)
+	}
+	if doc.Segments[1].VoiceName != "narrator-male" {
+		t.Errorf("segment 1 voice = %q, want narrator-male", doc.Segments[1].VoiceName)
+	}
+	if doc.Segments[2].VoiceName != "" {
+		t.Errorf("segment 2 should have no voice override (voice closed), got %q", doc.Segments[2].VoiceName)
+	}
+}
+
+func TestParse_Emphasis(t *testing.T) {
+	doc, err := Parse([]byte(`<speak>Normal<emphasis level="strong">Loud</emphasis>After</speak>`), "en-US")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(doc.Segments) != 3 {
+		t.Fatalf("expected 3 segments, got %+v", doc.Segments)
+	}
+	if doc.Segments[0].Emphasis != "" {
+		t.Errorf("segment 0 should have no emphasis override, got %q", doc.Segments[0].Emphasis)
+	}
+	if doc.Segments[1].Emphasis != "strong" {
+		t.Errorf("segment 1 emphasis = %q, want strong", doc.Segments[1].Emphasis)
+	}
+	if doc.Segments[2].Emphasis != "" {
+		t.Errorf("segment 2 should have no emphasis override (emphasis closed), got %q", doc.Segments[2].Emphasis)
+	}
+}
+
+func TestParse_EmphasisDefaultLevel(t *testing.T) {
+	doc, err := Parse([]byte(`<speak><emphasis>Loud</emphasis></speak>`), "en-US")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(doc.Segments) != 1 || doc.Segments[0].Emphasis != "moderate" {
+		t.Fatalf("unexpected segments: %+v", doc.Segments)
+	}
+}
+
+func TestParse_SayAs(t *testing.T) {
+	tests := []struct {
+		name     string
+		markup   string
+		lang     string
+		wantText string
+	}{
+		{"cardinal english", `<speak><say-as interpret-as="cardinal">42</say-as></speak>`, "en-US", "forty-two"},
+		{"cardinal greek", `<speak><say-as interpret-as="cardinal">42</say-as></speak>`, "el-GR", "σαράντα δύο"},
+		{"characters", `<speak><say-as interpret-as="characters">ab</say-as></speak>`, "en-US", "a b"},
+		{"date english", `<speak><say-as interpret-as="date">2024-01-01</say-as></speak>`, "en-US", "January first, twenty twenty-four"},
+		{"date greek", `<speak><say-as interpret-as="date">2024-01-01</say-as></speak>`, "el-GR", "ένα Ιανουαρίου δύο χιλιάδες είκοσι τέσσερα"},
+		{"date unparseable passthrough", `<speak><say-as interpret-as="date">not a date</say-as></speak>`, "en-US", "not a date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Parse([]byte(tt.markup), tt.lang)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			if len(doc.Segments) != 1 || doc.Segments[0].Text != tt.wantText {
+				t.Fatalf("got %+v, want single segment with text %q", doc.Segments, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestParse_Sub(t *testing.T) {
+	doc, err := Parse([]byte(`<speak><sub alias="World Wide Web Consortium">W3C</sub></speak>`), "en-US")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(doc.Segments) != 1 || doc.Segments[0].Text != "World Wide Web Consortium" {
+		t.Fatalf("unexpected segments: %+v", doc.Segments)
+	}
+}
+
+func TestParse_Phoneme(t *testing.T) {
+	doc, err := Parse([]byte(`<speak><phoneme alphabet="ipa" ph="təˈmeɪtoʊ">tomato</phoneme></speak>`), "en-US")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(doc.Segments) != 1 || doc.Segments[0].Text != "tomato" {
+		t.Fatalf("expected fallback to orthographic text, got %+v", doc.Segments)
+	}
+}
+
+func TestParsePauseMarkup(t *testing.T) {
+	doc := ParsePauseMarkup("Hello [PAUSE=500ms] world[PAUSE=200ms]goodbye")
+
+	want := []struct {
+		text   string
+		break_ time.Duration
+	}{
+		{"Hello", 0},
+		{"", 500 * time.Millisecond},
+		{"world", 0},
+		{"", 200 * time.Millisecond},
+		{"goodbye", 0},
+	}
+	if len(doc.Segments) != len(want) {
+		t.Fatalf("got %d segments, want %d: %+v", len(doc.Segments), len(want), doc.Segments)
+	}
+	for i, w := range want {
+		if doc.Segments[i].Text != w.text || doc.Segments[i].Break != w.break_ {
+			t.Errorf("segment %d = %+v, want text=%q break=%v", i, doc.Segments[i], w.text, w.break_)
+		}
+	}
+}
+
+func TestParsePauseMarkup_NoMarkup(t *testing.T) {
+	doc := ParsePauseMarkup("Just plain text")
+	if len(doc.Segments) != 1 || doc.Segments[0].Text != "Just plain text" {
+		t.Fatalf("unexpected segments: %+v", doc.Segments)
+	}
+}
@@ -0,0 +1,302 @@
+package agents
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"studiospeech/internal/audio/encoder"
+)
+
+// SynthesizeSSML renders an ordered list of RenderSegments -- the lowered
+// form of either a parsed SSML document or a [PAUSE=Xms] shortcut -- into
+// a single WAV file. Each text segment is synthesized with its own
+// per-segment Speed/Voice override (falling back to defaultVoice/
+// baseParams when absent, via resolveVoice for a <voice name="..."> one);
+// each break segment becomes silence generated as PCM zeros at the
+// currently active voice's sample rate. Segment PCM is concatenated in
+// order and wrapped in a single WAV header, so downstream post-processing
+// never needs to know the input was segmented.
+func (s *SynthAgent) SynthesizeSSML(segments []RenderSegment, defaultVoice *Voice, baseParams *SynthParams, resolveVoice func(name string) (*Voice, error)) (*SynthResult, error) {
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("no segments to synthesize")
+	}
+	if defaultVoice == nil {
+		return nil, fmt.Errorf("voice is nil")
+	}
+	if baseParams == nil {
+		baseParams = s.getDefaultParams()
+	}
+
+	if s.dryRun {
+		if err := s.validateParams(baseParams); err != nil {
+			return nil, fmt.Errorf("invalid synthesis parameters: %w", err)
+		}
+		return &SynthResult{
+			OutputPath: filepath.Join(s.tempDir, fmt.Sprintf("synth_ssml_%d.wav", time.Now().UnixNano())),
+			SampleRate: defaultVoice.SampleRate,
+			Channels:   1,
+		}, nil
+	}
+
+	var readers []io.Reader
+	var closers []io.Closer
+	defer func() {
+		for _, c := range closers {
+			c.Close()
+		}
+	}()
+
+	activeVoice := defaultVoice
+	sampleRate := defaultVoice.SampleRate
+
+	for i, seg := range segments {
+		if seg.Break > 0 {
+			readers = append(readers, silencePCM(seg.Break, sampleRate))
+			continue
+		}
+		if seg.Normalized == nil {
+			continue
+		}
+
+		voice := activeVoice
+		if seg.VoiceName != "" {
+			if resolveVoice == nil {
+				return nil, fmt.Errorf("segment %d requests voice %q but no voice resolver was provided", i, seg.VoiceName)
+			}
+			resolved, err := resolveVoice(seg.VoiceName)
+			if err != nil {
+				return nil, fmt.Errorf("segment %d voice lookup failed: %w", i, err)
+			}
+			voice = resolved
+		}
+		activeVoice = voice
+		sampleRate = voice.SampleRate
+
+		params := *baseParams
+		if seg.Speed != nil {
+			params.Speed = *seg.Speed
+		}
+
+		result, err := s.Synthesize(seg.Normalized, voice, &params)
+		if err != nil {
+			return nil, fmt.Errorf("segment %d synthesis failed: %w", i, err)
+		}
+
+		pcm, segSampleRate, _, err := openWavPCM(result.OutputPath)
+		s.CleanupTempFiles(result)
+		if err != nil {
+			return nil, fmt.Errorf("segment %d pcm read failed: %w", i, err)
+		}
+		sampleRate = segSampleRate
+
+		readers = append(readers, pcm)
+		closers = append(closers, pcm)
+	}
+
+	outputPath := filepath.Join(s.tempDir, fmt.Sprintf("synth_ssml_%d.wav", time.Now().UnixNano()))
+	outFile, err := os.Create(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ssml output file: %w", err)
+	}
+	defer outFile.Close()
+
+	wavEncoder, ok := encoder.Get("wav")
+	if !ok {
+		return nil, fmt.Errorf("wav encoder not registered")
+	}
+	if err := wavEncoder.Encode(io.MultiReader(readers...), encoder.Config{SampleRate: sampleRate, Channels: 1}, outFile); err != nil {
+		return nil, fmt.Errorf("failed to concatenate ssml segments: %w", err)
+	}
+
+	fileInfo, err := outFile.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat ssml output file: %w", err)
+	}
+
+	return &SynthResult{
+		OutputPath: outputPath,
+		SampleRate: sampleRate,
+		Channels:   1,
+		FileSize:   fileInfo.Size(),
+	}, nil
+}
+
+// silencePCM returns a reader yielding d worth of zero-valued 16-bit mono
+// PCM samples at sampleRate, for a <break>/[PAUSE] segment.
+func silencePCM(d time.Duration, sampleRate int) io.Reader {
+	samples := int(d.Seconds() * float64(sampleRate))
+	return bytes.NewReader(make([]byte, samples*2))
+}
+
+// emphasisGainDB maps an SSML <emphasis level="..."> value to a gain
+// adjustment applied to that segment's rendered PCM, approximating the
+// loudness shift a prosody-aware backend would give emphasized text.
+// Levels this parser doesn't recognize are simply not in the map, which
+// applyGainPCM treats the same as an explicit zero.
+var emphasisGainDB = map[string]float64{
+	"reduced":  -3,
+	"moderate": 2,
+	"strong":   4,
+}
+
+// applyGainPCM scales raw little-endian signed 16-bit pcm by dB,
+// clamping each sample to the int16 range instead of letting it wrap. A
+// zero dB is a no-op that returns pcm unchanged.
+func applyGainPCM(pcm []byte, dB float64) []byte {
+	if dB == 0 || len(pcm) < 2 {
+		return pcm
+	}
+
+	gain := math.Pow(10, dB/20)
+	out := make([]byte, len(pcm))
+	for i := 0; i+1 < len(pcm); i += 2 {
+		sample := float64(int16(binary.LittleEndian.Uint16(pcm[i:]))) * gain
+		switch {
+		case sample > math.MaxInt16:
+			sample = math.MaxInt16
+		case sample < math.MinInt16:
+			sample = math.MinInt16
+		}
+		binary.LittleEndian.PutUint16(out[i:], uint16(int16(sample)))
+	}
+	return out
+}
+
+// applyGainToWAVFile rewrites the WAV file at path in place with dB of
+// gain applied to its PCM data.
+func applyGainToWAVFile(path string, dB float64) error {
+	pcmReader, sampleRate, channels, err := openWavPCM(path)
+	if err != nil {
+		return err
+	}
+	pcm, err := io.ReadAll(pcmReader)
+	pcmReader.Close()
+	if err != nil {
+		return fmt.Errorf("failed to read pcm for gain adjustment: %w", err)
+	}
+
+	out, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to reopen wav file for gain adjustment: %w", err)
+	}
+	defer out.Close()
+
+	wavEncoder, ok := encoder.Get("wav")
+	if !ok {
+		return fmt.Errorf("wav encoder not registered")
+	}
+	cfg := encoder.Config{SampleRate: sampleRate, Channels: channels}
+	return wavEncoder.Encode(bytes.NewReader(applyGainPCM(pcm, dB)), cfg, out)
+}
+
+// writeSilenceWAV writes d worth of silence at sampleRate to path as a
+// standalone WAV file, for a <break>/[PAUSE] segment rendered as its own
+// input to SynthesizeSSMLCrossfade's crossfade-concat pipeline.
+func writeSilenceWAV(path string, d time.Duration, sampleRate int) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create silence file: %w", err)
+	}
+	defer out.Close()
+
+	wavEncoder, ok := encoder.Get("wav")
+	if !ok {
+		return fmt.Errorf("wav encoder not registered")
+	}
+	return wavEncoder.Encode(silencePCM(d, sampleRate), encoder.Config{SampleRate: sampleRate, Channels: 1}, out)
+}
+
+// SynthesizeSSMLCrossfade renders segments the same way SynthesizeSSML
+// does -- each text segment with its own per-segment Speed/Voice/
+// Emphasis override, each break as silence -- but keeps every segment as
+// its own temporary WAV file instead of concatenating their raw PCM in
+// process, then hands the ordered file list to post.ConcatAndNormalize
+// to join them with a short crossfade and a single loudnorm pass. That
+// crossfade is what SynthesizeSSML's flat concatenation doesn't give you:
+// independently-rendered segments can each land at a slightly different
+// level, and a hard cut between them reads as an audible seam or click.
+// Emphasis overrides are applied as a PCM gain adjustment (see
+// emphasisGainDB) before a segment's file is handed off.
+func (s *SynthAgent) SynthesizeSSMLCrossfade(segments []RenderSegment, defaultVoice *Voice, baseParams *SynthParams, resolveVoice func(name string) (*Voice, error), post *PostProcessAgent, outputPath string, postParams *PostProcessParams) (*PostProcessResult, error) {
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("no segments to synthesize")
+	}
+	if defaultVoice == nil {
+		return nil, fmt.Errorf("voice is nil")
+	}
+	if post == nil {
+		return nil, fmt.Errorf("post-process agent is nil")
+	}
+	if baseParams == nil {
+		baseParams = s.getDefaultParams()
+	}
+
+	var files []string
+	defer func() {
+		for _, f := range files {
+			os.Remove(f)
+		}
+	}()
+
+	activeVoice := defaultVoice
+	sampleRate := defaultVoice.SampleRate
+
+	for i, seg := range segments {
+		if seg.Break > 0 {
+			path := filepath.Join(s.tempDir, fmt.Sprintf("synth_ssml_silence_%d_%d.wav", time.Now().UnixNano(), i))
+			if err := writeSilenceWAV(path, seg.Break, sampleRate); err != nil {
+				return nil, fmt.Errorf("segment %d silence generation failed: %w", i, err)
+			}
+			files = append(files, path)
+			continue
+		}
+		if seg.Normalized == nil {
+			continue
+		}
+
+		voice := activeVoice
+		if seg.VoiceName != "" {
+			if resolveVoice == nil {
+				return nil, fmt.Errorf("segment %d requests voice %q but no voice resolver was provided", i, seg.VoiceName)
+			}
+			resolved, err := resolveVoice(seg.VoiceName)
+			if err != nil {
+				return nil, fmt.Errorf("segment %d voice lookup failed: %w", i, err)
+			}
+			voice = resolved
+		}
+		activeVoice = voice
+		sampleRate = voice.SampleRate
+
+		params := *baseParams
+		if seg.Speed != nil {
+			params.Speed = *seg.Speed
+		}
+
+		result, err := s.Synthesize(seg.Normalized, voice, &params)
+		if err != nil {
+			return nil, fmt.Errorf("segment %d synthesis failed: %w", i, err)
+		}
+
+		if gain, ok := emphasisGainDB[seg.Emphasis]; ok {
+			if err := applyGainToWAVFile(result.OutputPath, gain); err != nil {
+				s.CleanupTempFiles(result)
+				return nil, fmt.Errorf("segment %d emphasis gain failed: %w", i, err)
+			}
+		}
+
+		files = append(files, result.OutputPath)
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no renderable segments")
+	}
+
+	return post.ConcatAndNormalize(files, outputPath, postParams)
+}
@@ -0,0 +1,114 @@
+package agents
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSynthAgent_SynthesizeSSML_BreaksOnly(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "ssml_synth_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent := NewSynthAgent("piper", tempDir)
+	voice := &Voice{ID: "test_voice", SampleRate: 22050}
+
+	segments := []RenderSegment{
+		{Break: 100 * time.Millisecond},
+		{Break: 50 * time.Millisecond},
+	}
+
+	result, err := agent.SynthesizeSSML(segments, voice, nil, nil)
+	if err != nil {
+		t.Fatalf("SynthesizeSSML failed: %v", err)
+	}
+
+	samples, sampleRate, err := readWavSampleCount(result.OutputPath)
+	if err != nil {
+		t.Fatalf("failed to read generated wav: %v", err)
+	}
+	if sampleRate != voice.SampleRate {
+		t.Errorf("sample rate = %d, want %d", sampleRate, voice.SampleRate)
+	}
+
+	var wantSamples int64
+	for _, seg := range segments {
+		wantSamples += int64(int(seg.Break.Seconds() * float64(voice.SampleRate)))
+	}
+	if samples != wantSamples {
+		t.Errorf("sample count = %d, want %d", samples, wantSamples)
+	}
+}
+
+func TestSynthAgent_SynthesizeSSML_NoSegments(t *testing.T) {
+	agent := NewSynthAgent("piper", t.TempDir())
+	if _, err := agent.SynthesizeSSML(nil, &Voice{SampleRate: 22050}, nil, nil); err == nil {
+		t.Error("expected an error for an empty segment list")
+	}
+}
+
+func TestSynthAgent_SynthesizeSSML_NilVoice(t *testing.T) {
+	agent := NewSynthAgent("piper", t.TempDir())
+	segments := []RenderSegment{{Break: 100 * time.Millisecond}}
+	if _, err := agent.SynthesizeSSML(segments, nil, nil, nil); err == nil {
+		t.Error("expected an error for a nil default voice")
+	}
+}
+
+func TestSynthAgent_SynthesizeSSML_MissingVoiceResolver(t *testing.T) {
+	agent := NewSynthAgent("piper", t.TempDir())
+	segments := []RenderSegment{
+		{Normalized: &NormalizedText{Sentences: []string{"Hello."}, Language: "en-US"}, VoiceName: "narrator-male"},
+	}
+	if _, err := agent.SynthesizeSSML(segments, &Voice{SampleRate: 22050}, nil, nil); err == nil {
+		t.Error("expected an error when a segment requests a voice but no resolver was provided")
+	}
+}
+
+func TestSynthAgent_SynthesizeSSMLCrossfade(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "ssml_synth_crossfade_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent := NewSynthAgent("piper", tempDir)
+	voice := &Voice{ID: "test_voice", Path: "faketest://voice", SampleRate: 24000}
+
+	segments := []RenderSegment{
+		{Normalized: &NormalizedText{Sentences: []string{"Hello."}, Language: "en-US"}},
+		{Break: 100 * time.Millisecond},
+		{Normalized: &NormalizedText{Sentences: []string{"World."}, Language: "en-US"}, Emphasis: "strong"},
+	}
+
+	post := NewPostProcessAgent("ffmpeg", tempDir)
+	post.SetDryRun(true)
+
+	outputPath := tempDir + "/out.mp3"
+	result, err := agent.SynthesizeSSMLCrossfade(segments, voice, nil, nil, post, outputPath, nil)
+	if err != nil {
+		t.Fatalf("SynthesizeSSMLCrossfade failed: %v", err)
+	}
+	if result.OutputPath != outputPath {
+		t.Errorf("OutputPath = %q, want %q", result.OutputPath, outputPath)
+	}
+}
+
+func TestSynthAgent_SynthesizeSSMLCrossfade_NoPostAgent(t *testing.T) {
+	agent := NewSynthAgent("piper", t.TempDir())
+	segments := []RenderSegment{{Break: 100 * time.Millisecond}}
+	if _, err := agent.SynthesizeSSMLCrossfade(segments, &Voice{SampleRate: 22050}, nil, nil, nil, "/tmp/out.mp3", nil); err == nil {
+		t.Error("expected an error for a nil post-process agent")
+	}
+}
+
+func TestSynthAgent_SynthesizeSSMLCrossfade_NoSegments(t *testing.T) {
+	agent := NewSynthAgent("piper", t.TempDir())
+	post := NewPostProcessAgent("ffmpeg", t.TempDir())
+	if _, err := agent.SynthesizeSSMLCrossfade(nil, &Voice{SampleRate: 22050}, nil, nil, post, "/tmp/out.mp3", nil); err == nil {
+		t.Error("expected an error for an empty segment list")
+	}
+}
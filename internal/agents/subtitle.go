@@ -0,0 +1,271 @@
+package agents
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// SubtitleFormat identifies a supported subtitle output format.
+type SubtitleFormat string
+
+const (
+	SubtitleFormatSRT SubtitleFormat = "srt"
+	SubtitleFormatVTT SubtitleFormat = "vtt"
+)
+
+// SubtitleGranularity controls how text is chunked into timed cues.
+type SubtitleGranularity string
+
+const (
+	GranularitySentence SubtitleGranularity = "sentence"
+	GranularityWord     SubtitleGranularity = "word"
+)
+
+// Cue represents a single timed subtitle entry.
+type Cue struct {
+	Index int
+	Start time.Duration
+	End   time.Duration
+	Text  string
+}
+
+// sentenceSplitRegex splits on sentence-ending punctuation followed by
+// whitespace. Greek text uses ';' (U+037E GREEK QUESTION MARK, which
+// renders like an ASCII semicolon) as its question mark, so it is folded
+// into '?' before splitting.
+var sentenceSplitRegex = regexp.MustCompile(`[.!?]+\s+`)
+
+// SubtitleAgent derives SRT/VTT caption files from the same per-chunk
+// synthesis Piper performs for the main audio track. Timings are computed
+// from the raw PCM sample counts of each chunk rather than from the final
+// (possibly lossily re-encoded) output, so captions stay frame-accurate
+// regardless of the chosen output codec.
+type SubtitleAgent struct {
+	synth *SynthAgent
+}
+
+// NewSubtitleAgent creates a new subtitle generation agent. It reuses synth
+// to render each chunk individually, so the two must share compatible
+// temp-file handling.
+func NewSubtitleAgent(synth *SynthAgent) *SubtitleAgent {
+	return &SubtitleAgent{synth: synth}
+}
+
+// Generate chunks the paragraphs at the requested granularity, synthesizes
+// each chunk in turn, and accumulates cue timings from the PCM sample
+// counts of each rendered chunk.
+func (s *SubtitleAgent) Generate(content *TextContent, voice *Voice, params *SynthParams, granularity SubtitleGranularity) ([]Cue, error) {
+	if content == nil {
+		return nil, fmt.Errorf("content is nil")
+	}
+	if voice == nil {
+		return nil, fmt.Errorf("voice is nil")
+	}
+
+	var chunks []string
+	for _, paragraph := range content.Paragraphs {
+		for _, sentence := range s.splitSentences(paragraph) {
+			if granularity == GranularityWord {
+				chunks = append(chunks, strings.Fields(sentence)...)
+			} else {
+				chunks = append(chunks, sentence)
+			}
+		}
+	}
+
+	var cues []Cue
+	var elapsed time.Duration
+
+	for i, chunk := range chunks {
+		chunk = strings.TrimSpace(chunk)
+		if chunk == "" {
+			continue
+		}
+
+		chunkText := &NormalizedText{Sentences: []string{chunk}, Language: content.Language}
+		result, err := s.synth.Synthesize(chunkText, voice, params)
+		if err != nil {
+			return nil, fmt.Errorf("subtitle chunk %d synthesis failed: %w", i, err)
+		}
+
+		samples, sampleRate, err := readWavSampleCount(result.OutputPath)
+		s.synth.CleanupTempFiles(result)
+		if err != nil {
+			return nil, fmt.Errorf("subtitle chunk %d timing failed: %w", i, err)
+		}
+
+		duration := time.Duration(float64(samples) / float64(sampleRate) * float64(time.Second))
+
+		cues = append(cues, Cue{
+			Index: len(cues) + 1,
+			Start: elapsed,
+			End:   elapsed + duration,
+			Text:  chunk,
+		})
+		elapsed += duration
+	}
+
+	return cues, nil
+}
+
+// splitSentences performs punctuation-aware sentence splitting, folding the
+// Greek question mark (';', not the visually-identical ASCII ';') into
+// '?' first.
+func (s *SubtitleAgent) splitSentences(text string) []string {
+	text = strings.ReplaceAll(text, ";", "?")
+
+	var sentences []string
+	for _, part := range sentenceSplitRegex.Split(text, -1) {
+		trimmed := strings.TrimSpace(part)
+		if trimmed != "" {
+			sentences = append(sentences, trimmed)
+		}
+	}
+	return sentences
+}
+
+// WriteSRT serializes cues to SubRip (.srt) format at the given path.
+func (s *SubtitleAgent) WriteSRT(cues []Cue, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create SRT file: %w", err)
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	for _, cue := range cues {
+		fmt.Fprintf(w, "%d\n", cue.Index)
+		fmt.Fprintf(w, "%s --> %s\n", formatSRTTimestamp(cue.Start), formatSRTTimestamp(cue.End))
+		fmt.Fprintf(w, "%s\n\n", cue.Text)
+	}
+	return w.Flush()
+}
+
+// WriteVTT serializes cues to WebVTT (.vtt) format at the given path.
+func (s *SubtitleAgent) WriteVTT(cues []Cue, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create VTT file: %w", err)
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	fmt.Fprintf(w, "WEBVTT\n\n")
+	for _, cue := range cues {
+		fmt.Fprintf(w, "%d\n", cue.Index)
+		fmt.Fprintf(w, "%s --> %s\n", formatVTTTimestamp(cue.Start), formatVTTTimestamp(cue.End))
+		fmt.Fprintf(w, "%s\n\n", cue.Text)
+	}
+	return w.Flush()
+}
+
+// formatSRTTimestamp renders a duration as SRT's "HH:MM:SS,mmm".
+func formatSRTTimestamp(d time.Duration) string {
+	return formatCueTimestamp(d, ",")
+}
+
+// formatVTTTimestamp renders a duration as WebVTT's "HH:MM:SS.mmm".
+func formatVTTTimestamp(d time.Duration) string {
+	return formatCueTimestamp(d, ".")
+}
+
+func formatCueTimestamp(d time.Duration, msSep string) string {
+	if d < 0 {
+		d = 0
+	}
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+	seconds := d / time.Second
+	d -= seconds * time.Second
+	millis := d / time.Millisecond
+
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", hours, minutes, seconds, msSep, millis)
+}
+
+// readWavSampleCount parses a canonical RIFF/WAVE file's "fmt " and "data"
+// chunks to recover the per-channel sample count and sample rate without
+// shelling out to ffprobe.
+func readWavSampleCount(path string) (samples int64, sampleRate int, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to open wav file: %w", err)
+	}
+	defer file.Close()
+
+	var header [12]byte
+	if _, err := io.ReadFull(file, header[:]); err != nil {
+		return 0, 0, fmt.Errorf("failed to read riff header: %w", err)
+	}
+	if string(header[0:4]) != "RIFF" || string(header[8:12]) != "WAVE" {
+		return 0, 0, fmt.Errorf("not a valid WAV file")
+	}
+
+	var channels uint16
+	var bitsPerSample uint16
+	var dataSize uint32
+	var chunkHeader [8]byte
+
+	for {
+		if _, err := io.ReadFull(file, chunkHeader[:]); err != nil {
+			break
+		}
+		chunkID := string(chunkHeader[0:4])
+		chunkSize := binary.LittleEndian.Uint32(chunkHeader[4:8])
+
+		switch chunkID {
+		case "fmt ":
+			body := make([]byte, chunkSize)
+			if _, err := io.ReadFull(file, body); err != nil {
+				return 0, 0, fmt.Errorf("failed to read fmt chunk: %w", err)
+			}
+			channels = binary.LittleEndian.Uint16(body[2:4])
+			sampleRate = int(binary.LittleEndian.Uint32(body[4:8]))
+			bitsPerSample = binary.LittleEndian.Uint16(body[14:16])
+		case "data":
+			dataSize = chunkSize
+			if _, err := file.Seek(int64(chunkSize), 1); err != nil {
+				return 0, 0, fmt.Errorf("failed to skip data chunk: %w", err)
+			}
+		default:
+			if _, err := file.Seek(int64(chunkSize), 1); err != nil {
+				break
+			}
+		}
+
+		if dataSize > 0 && sampleRate > 0 {
+			break
+		}
+	}
+
+	if channels == 0 || bitsPerSample == 0 || sampleRate == 0 {
+		return 0, 0, fmt.Errorf("incomplete WAV header: missing fmt chunk")
+	}
+
+	bytesPerSample := int64(bitsPerSample / 8)
+	if bytesPerSample == 0 || channels == 0 {
+		return 0, 0, fmt.Errorf("invalid WAV fmt chunk")
+	}
+
+	samples = int64(dataSize) / (bytesPerSample * int64(channels))
+	return samples, sampleRate, nil
+}
+
+// WavDuration returns the playback duration of a canonical RIFF/WAVE
+// file, for callers (like chapter-aware batch synthesis) that need an
+// audio file's actual length rather than how long synthesis took to
+// produce it.
+func WavDuration(path string) (time.Duration, error) {
+	samples, sampleRate, err := readWavSampleCount(path)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(samples) * time.Second / time.Duration(sampleRate), nil
+}
@@ -0,0 +1,39 @@
+package agents
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatSRTTimestamp(t *testing.T) {
+	d := 1*time.Hour + 2*time.Minute + 3*time.Second + 456*time.Millisecond
+	got := formatSRTTimestamp(d)
+	want := "01:02:03,456"
+	if got != want {
+		t.Errorf("formatSRTTimestamp(%v) = %s, want %s", d, got, want)
+	}
+}
+
+func TestFormatVTTTimestamp(t *testing.T) {
+	d := 90 * time.Second
+	got := formatVTTTimestamp(d)
+	want := "00:01:30.000"
+	if got != want {
+		t.Errorf("formatVTTTimestamp(%v) = %s, want %s", d, got, want)
+	}
+}
+
+func TestSubtitleAgent_SplitSentences(t *testing.T) {
+	agent := NewSubtitleAgent(NewSynthAgent("piper", "/tmp"))
+
+	sentences := agent.splitSentences("Hello world. How are you? Fine!")
+	if len(sentences) != 3 {
+		t.Fatalf("expected 3 sentences, got %d: %v", len(sentences), sentences)
+	}
+
+	// Greek question mark (rendered as ';') should split like '?'.
+	greek := agent.splitSentences("Τι κάνεις; Καλά είμαι.")
+	if len(greek) != 2 {
+		t.Fatalf("expected 2 sentences, got %d: %v", len(greek), greek)
+	}
+}
@@ -1,6 +1,8 @@
 package agents
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -9,6 +11,9 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"studiospeech/internal/agents/tts"
+	"studiospeech/internal/audio/encoder"
 )
 
 // SynthParams contains synthesis parameters
@@ -28,6 +33,14 @@ type SynthResult struct {
 	FileSize   int64
 }
 
+// StreamResult carries the stream metadata SynthesizeStream learns from
+// the backend's first PCM chunk, since there's no WAV header on the wire
+// for a caller (e.g. PostProcessAgent.ProcessStream) to read it back from.
+type StreamResult struct {
+	SampleRate int
+	Channels   int
+}
+
 // SynthAgent handles text-to-speech synthesis using Piper
 type SynthAgent struct {
 	piperPath string
@@ -49,7 +62,11 @@ func (s *SynthAgent) SetDryRun(enabled bool) {
 	s.dryRun = enabled
 }
 
-// Synthesize converts normalized text to speech using Piper
+// Synthesize converts normalized text to speech, draining SynthesizeStream
+// into a single temporary WAV file. It's a thin wrapper kept for callers
+// that want a file on disk; long-form or real-time callers should prefer
+// SynthesizeStream directly so a full utterance never has to hit disk as
+// an intermediate WAV file.
 func (s *SynthAgent) Synthesize(normalized *NormalizedText, voice *Voice, params *SynthParams) (*SynthResult, error) {
 	if normalized == nil {
 		return nil, fmt.Errorf("normalized text is nil")
@@ -63,29 +80,13 @@ func (s *SynthAgent) Synthesize(normalized *NormalizedText, voice *Voice, params
 		params = s.getDefaultParams()
 	}
 
-	// Validate parameters
-	if err := s.validateParams(params); err != nil {
-		return nil, fmt.Errorf("invalid synthesis parameters: %w", err)
-	}
-
-	// Check if voice model file exists (skip for macOS voices and dry-run mode)
-	if !s.dryRun && !s.isMacOSVoice(voice) {
-		if _, err := os.Stat(voice.Path); os.IsNotExist(err) {
-			return nil, fmt.Errorf("voice model file not found: %s", voice.Path)
-		}
-	}
-
 	// Create temporary output file
 	outputPath := filepath.Join(s.tempDir, fmt.Sprintf("synth_%d.wav", time.Now().UnixNano()))
 
-	// Combine sentences with proper pauses between them
-	text := strings.Join(normalized.Sentences, ". ")
-
-	// Build Piper command
-	cmd := s.buildPiperCommand(voice.Path, outputPath, params)
-
 	if s.dryRun {
-		// Return command for testing without execution
+		if err := s.validateParams(params); err != nil {
+			return nil, fmt.Errorf("invalid synthesis parameters: %w", err)
+		}
 		return &SynthResult{
 			OutputPath: outputPath,
 			Duration:   0,
@@ -95,42 +96,43 @@ func (s *SynthAgent) Synthesize(normalized *NormalizedText, voice *Voice, params
 		}, nil
 	}
 
-	// Execute synthesis
 	startTime := time.Now()
 
-	// Use macOS TTS for macOS voices, Piper for others
-	if s.isMacOSVoice(voice) {
-		macTTS := NewMacOSTTSAgent(s.tempDir)
-		if macTTS.IsAvailable() {
-			if err := macTTS.Synthesize(text, outputPath, voice.Gender, normalized.Language); err != nil {
-				return nil, fmt.Errorf("macOS TTS synthesis failed: %w", err)
-			}
-		} else {
-			return nil, fmt.Errorf("macOS TTS not available")
-		}
-	} else {
-		// Try Piper first, fallback to macOS TTS if Piper fails
-		err := s.executePiper(cmd, text)
-		if err != nil {
-			// Try macOS TTS fallback
-			macTTS := NewMacOSTTSAgent(s.tempDir)
-			if macTTS.IsAvailable() {
-				gender := "female"
-				if params.Speaker > 0 {
-					gender = "male"
-				}
-				if err := macTTS.Synthesize(text, outputPath, gender, normalized.Language); err != nil {
-					return nil, fmt.Errorf("both Piper and macOS TTS failed: piper=%v, macos=%v", err, err)
-				}
-			} else {
-				return nil, fmt.Errorf("piper synthesis failed and no fallback available: %w", err)
-			}
-		}
+	stream, streamResult, err := s.SynthesizeStream(context.Background(), normalized, voice, params)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	pcm, err := io.ReadAll(stream)
+	if err != nil {
+		return nil, fmt.Errorf("synthesis stream failed: %w", err)
+	}
+	if len(pcm) == 0 {
+		return nil, fmt.Errorf("synthesis produced no audio")
+	}
+
+	out, err := os.Create(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	wavEncoder, ok := encoder.Get("wav")
+	if !ok {
+		out.Close()
+		return nil, fmt.Errorf("wav encoder not registered")
+	}
+	cfg := encoder.Config{SampleRate: streamResult.SampleRate, Channels: streamResult.Channels}
+	if err := wavEncoder.Encode(bytes.NewReader(pcm), cfg, out); err != nil {
+		out.Close()
+		return nil, fmt.Errorf("failed to write synthesized wav: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close output file: %w", err)
 	}
 
 	duration := time.Since(startTime)
 
-	// Get output file info
 	fileInfo, err := os.Stat(outputPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get output file info: %w", err)
@@ -139,12 +141,132 @@ func (s *SynthAgent) Synthesize(normalized *NormalizedText, voice *Voice, params
 	return &SynthResult{
 		OutputPath: outputPath,
 		Duration:   duration,
-		SampleRate: voice.SampleRate,
-		Channels:   1,
+		SampleRate: streamResult.SampleRate,
+		Channels:   streamResult.Channels,
 		FileSize:   fileInfo.Size(),
 	}, nil
 }
 
+// SynthesizeStream converts normalized text to speech by dispatching to
+// whichever tts.Synthesizer backend voice.Path's URI scheme selects (see
+// backendFor) - Piper, macOS "say", Coqui XTTS, ElevenLabs, or a remote
+// gRPC engine - and streams the resulting raw PCM back through the
+// returned io.ReadCloser as the backend produces it, rather than
+// buffering a full utterance before returning anything. Pair it with
+// PostProcessAgent.ProcessStream through an io.Pipe to keep a sentence's
+// audio off disk end-to-end. StreamResult is populated (sample rate
+// learned from the backend's first chunk) before any data is available to
+// read, so a caller can wire up ProcessStream immediately.
+//
+// Canceling ctx stops the backend (its chunk sends are ctx-aware, see
+// tts.Synthesizer) and unblocks the relay goroutine feeding the returned
+// pipe, so a caller whose downstream consumer stops reading early - e.g.
+// ProcessStream when ffmpeg exits before consuming all of its stdin - can
+// cancel ctx to tear the whole chain down instead of leaking the relay
+// goroutine and the backend's subprocess.
+func (s *SynthAgent) SynthesizeStream(ctx context.Context, normalized *NormalizedText, voice *Voice, params *SynthParams) (io.ReadCloser, *StreamResult, error) {
+	if normalized == nil {
+		return nil, nil, fmt.Errorf("normalized text is nil")
+	}
+	if voice == nil {
+		return nil, nil, fmt.Errorf("voice is nil")
+	}
+	if params == nil {
+		params = s.getDefaultParams()
+	}
+	if err := s.validateParams(params); err != nil {
+		return nil, nil, fmt.Errorf("invalid synthesis parameters: %w", err)
+	}
+
+	backend, err := s.backendFor(voice)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	text := strings.Join(normalized.Sentences, ". ")
+
+	chunks, err := backend.Synthesize(ctx, tts.SynthRequest{
+		Text:    text,
+		Voice:   voice.Path,
+		Speed:   params.Speed,
+		Noise:   params.Noise,
+		NoiseW:  params.NoiseW,
+		Speaker: params.Speaker,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s synthesis failed: %w", backend.Name(), err)
+	}
+
+	first, ok := <-chunks
+	if !ok {
+		return nil, nil, fmt.Errorf("%s synthesis produced no audio", backend.Name())
+	}
+
+	sampleRate := voice.SampleRate
+	if first.SampleRate > 0 {
+		sampleRate = first.SampleRate
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		writeErr := func() error {
+			if _, err := pw.Write(first.Data); err != nil {
+				return err
+			}
+			for {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case chunk, ok := <-chunks:
+					if !ok {
+						return nil
+					}
+					if _, err := pw.Write(chunk.Data); err != nil {
+						return err
+					}
+				}
+			}
+		}()
+		pw.CloseWithError(writeErr)
+	}()
+
+	return pr, &StreamResult{SampleRate: sampleRate, Channels: 1}, nil
+}
+
+// backendFor resolves which tts.Synthesizer should handle voice.
+// Voice.Backend, when set, names the backend directly (the convention
+// catalog entries predating pluggable backends already use, e.g. for
+// Coqui's CLI-driven backend which has no natural URI scheme). Otherwise
+// the backend is taken from the URI scheme on voice.Path (e.g.
+// "say://Alex", "xtts://host:port/speaker", "grpc://host:port/voice");
+// a Path with no scheme is a plain Piper model file, the convention every
+// catalog entry used before backends were pluggable.
+//
+// A resolved "piper" backend is built against s.piperPath rather than
+// taken from the registry when that path isn't the registry default, so
+// a caller that pointed NewSynthAgent at a custom Piper binary keeps
+// using it.
+func (s *SynthAgent) backendFor(voice *Voice) (tts.Synthesizer, error) {
+	name := voice.Backend
+	if name == "" {
+		if idx := strings.Index(voice.Path, "://"); idx >= 0 {
+			name = voice.Path[:idx]
+		} else {
+			name = "piper"
+		}
+	}
+
+	if name == "piper" && s.piperPath != "" && s.piperPath != "piper" {
+		return tts.NewPiperSynthesizer(s.piperPath), nil
+	}
+
+	backend, ok := tts.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("no registered tts backend %q for voice %s", name, voice.ID)
+	}
+	return backend, nil
+}
+
 // buildPiperCommand constructs the Piper command line
 func (s *SynthAgent) buildPiperCommand(modelPath, outputPath string, params *SynthParams) *exec.Cmd {
 	args := []string{
@@ -168,33 +290,6 @@ func (s *SynthAgent) buildPiperCommand(modelPath, outputPath string, params *Syn
 	return exec.Command(s.piperPath, args...)
 }
 
-// executePiper runs Piper with the given text input
-func (s *SynthAgent) executePiper(cmd *exec.Cmd, text string) error {
-	// Set up stdin pipe for text input
-	stdin, err := cmd.StdinPipe()
-	if err != nil {
-		return fmt.Errorf("failed to create stdin pipe: %w", err)
-	}
-
-	// Start the command
-	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("failed to start piper: %w", err)
-	}
-
-	// Write text to stdin
-	go func() {
-		defer stdin.Close()
-		io.WriteString(stdin, text)
-	}()
-
-	// Wait for completion
-	if err := cmd.Wait(); err != nil {
-		return fmt.Errorf("piper execution failed: %w", err)
-	}
-
-	return nil
-}
-
 // getDefaultParams returns safe default synthesis parameters
 func (s *SynthAgent) getDefaultParams() *SynthParams {
 	return &SynthParams{
@@ -226,7 +321,10 @@ func (s *SynthAgent) validateParams(params *SynthParams) error {
 	return nil
 }
 
-// GetCommandLine returns the command line that would be executed (for testing)
+// GetCommandLine returns the Piper command line that would be executed
+// for voice, for testing. It's only accurate when voice resolves to the
+// Piper backend (see backendFor); other backends don't shell out to a
+// local binary at all.
 func (s *SynthAgent) GetCommandLine(voice *Voice, params *SynthParams, outputPath string) string {
 	if params == nil {
 		params = s.getDefaultParams()
@@ -236,13 +334,6 @@ func (s *SynthAgent) GetCommandLine(voice *Voice, params *SynthParams, outputPat
 	return strings.Join(append([]string{cmd.Path}, cmd.Args[1:]...), " ")
 }
 
-// isMacOSVoice checks if a voice is a macOS system voice
-func (s *SynthAgent) isMacOSVoice(voice *Voice) bool {
-	// macOS voices have simple names like "Alex", "Samantha", "Melina"
-	// and don't have file extensions
-	return !strings.Contains(voice.Path, "/") && !strings.Contains(voice.Path, ".")
-}
-
 // CleanupTempFiles removes temporary synthesis files
 func (s *SynthAgent) CleanupTempFiles(result *SynthResult) error {
 	if result != nil && result.OutputPath != "" {
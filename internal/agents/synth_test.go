@@ -1,12 +1,36 @@
 package agents
 
 import (
+	"context"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"studiospeech/internal/agents/tts"
 )
 
+// fakeStreamSynthesizer is a minimal tts.Synthesizer used to exercise
+// SynthAgent's streaming path without shelling out to a real backend.
+type fakeStreamSynthesizer struct{}
+
+func (f *fakeStreamSynthesizer) Name() string                        { return "faketest" }
+func (f *fakeStreamSynthesizer) Available(ctx context.Context) error { return nil }
+func (f *fakeStreamSynthesizer) SupportedLanguages() []string        { return nil }
+
+func (f *fakeStreamSynthesizer) Synthesize(ctx context.Context, req tts.SynthRequest) (<-chan tts.PCMChunk, error) {
+	out := make(chan tts.PCMChunk, 2)
+	out <- tts.PCMChunk{Data: []byte{1, 2, 3, 4}, SampleRate: 24000, Channels: 1}
+	out <- tts.PCMChunk{Data: []byte{5, 6, 7, 8}, SampleRate: 24000, Channels: 1}
+	close(out)
+	return out, nil
+}
+
+func init() {
+	tts.Register(&fakeStreamSynthesizer{})
+}
+
 func TestSynthAgent_ValidateParams(t *testing.T) {
 	agent := NewSynthAgent("piper", "/tmp")
 
@@ -105,6 +129,94 @@ func TestSynthAgent_GetCommandLine(t *testing.T) {
 	}
 }
 
+func TestSynthAgent_BackendFor(t *testing.T) {
+	agent := NewSynthAgent("piper", "/tmp")
+
+	tests := []struct {
+		voice   Voice
+		want    string
+		wantErr bool
+	}{
+		{voice: Voice{Path: "/path/to/voice.onnx"}, want: "piper"},
+		{voice: Voice{Path: "say://Alex"}, want: "say"},
+		{voice: Voice{Path: "grpc://localhost:9090/en-US-female"}, want: "grpc"},
+		{voice: Voice{Path: "xtts://localhost:8020/speaker-1"}, want: "xtts"},
+		{voice: Voice{Path: "elevenlabs://voice-id"}, want: "elevenlabs"},
+		{voice: Voice{Path: "/path/to/voice.onnx", Backend: "coqui"}, want: "coqui"},
+		{voice: Voice{Path: "unknown://thing"}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		backend, err := agent.backendFor(&test.voice)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("voice %+v: expected error, got backend %q", test.voice, backend.Name())
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("voice %+v: unexpected error: %v", test.voice, err)
+			continue
+		}
+		if backend.Name() != test.want {
+			t.Errorf("voice %+v: backend = %q, want %q", test.voice, backend.Name(), test.want)
+		}
+	}
+}
+
+func TestSynthAgent_SynthesizeStream(t *testing.T) {
+	agent := NewSynthAgent("piper", "/tmp")
+
+	normalized := &NormalizedText{Sentences: []string{"Hello world."}, Language: "en-US"}
+	voice := &Voice{ID: "test_voice", Path: "faketest://voice", SampleRate: 22050}
+	params := &SynthParams{Speed: 1.0, Noise: 0.5, NoiseW: 0.8}
+
+	stream, streamResult, err := agent.SynthesizeStream(context.Background(), normalized, voice, params)
+	if err != nil {
+		t.Fatalf("SynthesizeStream failed: %v", err)
+	}
+	defer stream.Close()
+
+	if streamResult.SampleRate != 24000 {
+		t.Errorf("expected sample rate 24000 (from the backend's first chunk), got %d", streamResult.SampleRate)
+	}
+
+	data, err := io.ReadAll(stream)
+	if err != nil {
+		t.Fatalf("failed to read stream: %v", err)
+	}
+	want := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	if string(data) != string(want) {
+		t.Errorf("expected concatenated PCM %v, got %v", want, data)
+	}
+}
+
+func TestSynthAgent_Synthesize_UsesStream(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "synth_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent := NewSynthAgent("piper", tempDir)
+
+	normalized := &NormalizedText{Sentences: []string{"Hello world."}, Language: "en-US"}
+	voice := &Voice{ID: "test_voice", Path: "faketest://voice", SampleRate: 22050}
+	params := &SynthParams{Speed: 1.0, Noise: 0.5, NoiseW: 0.8}
+
+	result, err := agent.Synthesize(normalized, voice, params)
+	if err != nil {
+		t.Fatalf("Synthesize failed: %v", err)
+	}
+
+	if result.SampleRate != 24000 {
+		t.Errorf("expected sample rate 24000, got %d", result.SampleRate)
+	}
+	if _, err := os.Stat(result.OutputPath); err != nil {
+		t.Errorf("expected output WAV file to exist: %v", err)
+	}
+}
+
 func TestSynthAgent_DryRun(t *testing.T) {
 	// Create temporary directory
 	tempDir, err := os.MkdirTemp("", "synth_test")
@@ -11,31 +11,52 @@ import (
 
 	"github.com/ledongthuc/pdf"
 	"github.com/unidoc/unioffice/document"
+
+	"studiospeech/internal/fsys"
 )
 
 // TextContent represents processed text content
 type TextContent struct {
-	Paragraphs []string
-	Language   string // Detected or specified language
-	WordCount  int
-	Source     string // Original file path
+	Paragraphs         []string
+	Language           string  // Detected or specified language
+	LanguageConfidence float64 // Confidence of the detected Language, in [0,1]; 1.0 when Language was explicitly specified
+	WordCount          int
+	Source             string // Original file path
+	// Chapters records chapter headings found during ingestion (.docx
+	// "Heading*"/"Title" styles, PDF outline entries) as the index into
+	// Paragraphs where each chapter starts, in order. Empty when the
+	// source format carries no heading metadata; DetectChapters falls
+	// back to Markdown "# " lines in that case.
+	Chapters []ChapterMark
+}
+
+// ChapterMark records where a detected chapter heading starts.
+type ChapterMark struct {
+	ParagraphIndex int
+	Title          string
 }
 
 // TextIngestAgent handles reading and processing text files
-type TextIngestAgent struct{}
+type TextIngestAgent struct {
+	detector LangDetector
+	fs       fsys.FS
+}
 
 // NewTextIngestAgent creates a new text ingestion agent
 func NewTextIngestAgent() *TextIngestAgent {
-	return &TextIngestAgent{}
+	return &TextIngestAgent{detector: NewTrigramDetector(), fs: fsys.NewOSFS()}
+}
+
+// SetFS overrides the agent's filesystem, e.g. with an fsys.MemFS for
+// tests or an fsys.RemoteFS to accept http(s):// and s3:// source URIs.
+func (t *TextIngestAgent) SetFS(fs fsys.FS) {
+	t.fs = fs
 }
 
-// ProcessFile reads and processes a text file (.txt, .docx, or .pdf)
+// ProcessFile reads and processes a text file (.txt, .docx, or .pdf).
+// filePath may be a local path or, when the agent's fs is an
+// fsys.RemoteFS, an http(s):// or s3:// URI.
 func (t *TextIngestAgent) ProcessFile(filePath string) (*TextContent, error) {
-	// Validate file exists
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("file does not exist: %s", filePath)
-	}
-	
 	ext := strings.ToLower(filepath.Ext(filePath))
 	
 	var content *TextContent
@@ -64,12 +85,12 @@ func (t *TextIngestAgent) ProcessFile(filePath string) (*TextContent, error) {
 
 // processTxtFile reads and processes a plain text file
 func (t *TextIngestAgent) processTxtFile(filePath string) (*TextContent, error) {
-	file, err := os.Open(filePath)
+	file, err := t.fs.Open(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open text file: %w", err)
 	}
 	defer file.Close()
-	
+
 	// Read file content
 	content, err := io.ReadAll(file)
 	if err != nil {
@@ -83,24 +104,35 @@ func (t *TextIngestAgent) processTxtFile(filePath string) (*TextContent, error)
 	
 	text := string(content)
 	paragraphs := t.splitIntoParagraphs(text)
-	
+	lang, conf := t.detectLanguage(text)
+
 	return &TextContent{
-		Paragraphs: paragraphs,
-		Language:   t.detectLanguage(text),
+		Paragraphs:         paragraphs,
+		Language:           lang,
+		LanguageConfidence: conf,
 	}, nil
 }
 
-// processDocxFile reads and processes a Microsoft Word document
+// processDocxFile reads and processes a Microsoft Word document. The
+// unioffice document reader requires a real local path, so a remote or
+// in-memory filePath is staged to disk first.
 func (t *TextIngestAgent) processDocxFile(filePath string) (*TextContent, error) {
-	doc, err := document.Open(filePath)
+	localPath, cleanup, err := t.fs.Stage(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stage DOCX file: %w", err)
+	}
+	defer cleanup()
+
+	doc, err := document.Open(localPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open DOCX file: %w", err)
 	}
 	defer doc.Close()
 	
 	var paragraphs []string
+	var chapters []ChapterMark
 	var allText strings.Builder
-	
+
 	// Extract text from all paragraphs
 	for _, para := range doc.Paragraphs() {
 		// Extract text from paragraph runs
@@ -108,27 +140,51 @@ func (t *TextIngestAgent) processDocxFile(filePath string) (*TextContent, error)
 		for _, run := range para.Runs() {
 			paraText.WriteString(run.Text())
 		}
-		
+
 		text := strings.TrimSpace(paraText.String())
-		if text != "" {
-			paragraphs = append(paragraphs, text)
-			allText.WriteString(text + " ")
+		if text == "" {
+			continue
+		}
+
+		if isHeadingStyle(para.Style()) {
+			chapters = append(chapters, ChapterMark{ParagraphIndex: len(paragraphs), Title: text})
 		}
+
+		paragraphs = append(paragraphs, text)
+		allText.WriteString(text + " ")
 	}
-	
+
 	if len(paragraphs) == 0 {
 		return nil, fmt.Errorf("no text content found in DOCX file")
 	}
-	
+
+	lang, conf := t.detectLanguage(allText.String())
 	return &TextContent{
-		Paragraphs: paragraphs,
-		Language:   t.detectLanguage(allText.String()),
+		Paragraphs:         paragraphs,
+		Language:           lang,
+		LanguageConfidence: conf,
+		Chapters:           chapters,
 	}, nil
 }
 
-// processPdfFile reads and processes a PDF document
+// isHeadingStyle reports whether a DOCX paragraph style name denotes a
+// chapter-level heading ("Heading1", "Heading 2", "Title", ...).
+func isHeadingStyle(style string) bool {
+	style = strings.ToLower(strings.TrimSpace(style))
+	return style == "title" || strings.HasPrefix(style, "heading")
+}
+
+// processPdfFile reads and processes a PDF document. The pdf reader
+// needs an io.ReaderAt over a real file, so a remote or in-memory
+// filePath is staged to disk first.
 func (t *TextIngestAgent) processPdfFile(filePath string) (*TextContent, error) {
-	file, err := os.Open(filePath)
+	localPath, cleanup, err := t.fs.Stage(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stage PDF file: %w", err)
+	}
+	defer cleanup()
+
+	file, err := os.Open(localPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open PDF file: %w", err)
 	}
@@ -175,13 +231,53 @@ func (t *TextIngestAgent) processPdfFile(filePath string) (*TextContent, error)
 	if len(paragraphs) == 0 {
 		return nil, fmt.Errorf("no text content found in PDF file")
 	}
-	
+
+	lang, conf := t.detectLanguage(allText.String())
 	return &TextContent{
-		Paragraphs: paragraphs,
-		Language:   t.detectLanguage(allText.String()),
+		Paragraphs:         paragraphs,
+		Language:           lang,
+		LanguageConfidence: conf,
+		Chapters:           chapterMarksFromOutline(reader, paragraphs),
 	}, nil
 }
 
+// chapterMarksFromOutline walks a PDF's outline (bookmarks) and matches
+// each entry's title against the flattened paragraph list, recording the
+// first paragraph at or after the previous match that contains it. The
+// PDF outline carries no paragraph offsets of its own, so this is a
+// best-effort text match rather than an exact page/paragraph mapping;
+// searching forward-only from the previous match keeps marks in the
+// ascending ParagraphIndex order chaptersFromMarks requires, even when
+// an earlier paragraph (e.g. a table of contents) happens to mention a
+// later chapter's title. Outline entries with no matching paragraph at
+// or after that point are skipped. An empty/missing outline yields no
+// marks.
+func chapterMarksFromOutline(reader *pdf.Reader, paragraphs []string) []ChapterMark {
+	outline := reader.Outline()
+
+	var marks []ChapterMark
+	searchFrom := 0
+	var walk func(entries []pdf.Outline)
+	walk = func(entries []pdf.Outline) {
+		for _, entry := range entries {
+			title := strings.TrimSpace(entry.Title)
+			if title != "" {
+				for i := searchFrom; i < len(paragraphs); i++ {
+					if strings.Contains(paragraphs[i], title) {
+						marks = append(marks, ChapterMark{ParagraphIndex: i, Title: title})
+						searchFrom = i + 1
+						break
+					}
+				}
+			}
+			walk(entry.Child)
+		}
+	}
+	walk(outline.Child)
+
+	return marks
+}
+
 // splitIntoParagraphs splits text into paragraphs, preserving structure
 func (t *TextIngestAgent) splitIntoParagraphs(text string) []string {
 	var paragraphs []string
@@ -218,31 +314,13 @@ func (t *TextIngestAgent) splitIntoParagraphs(text string) []string {
 	return paragraphs
 }
 
-// detectLanguage performs simple heuristic language detection
-func (t *TextIngestAgent) detectLanguage(text string) string {
-	// Simple heuristic based on character patterns
-	text = strings.ToLower(text)
-	
-	// Count Greek characters
-	greekCount := 0
-	englishCount := 0
-	
-	for _, r := range text {
-		if r >= 'α' && r <= 'ω' || r >= 'Α' && r <= 'Ω' {
-			greekCount++
-		} else if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
-			englishCount++
-		}
-	}
-	
-	// Determine language based on character distribution
-	if greekCount > englishCount {
-		return "el-GR"
-	} else if englishCount > 0 {
-		return "en-US"
-	}
-	
-	return "auto" // Unknown
+// detectLanguage runs the statistical trigram detector and returns the
+// winning BCP-47 language code plus its confidence. It replaces the
+// earlier Greek/English character-range heuristic, which silently
+// mislabeled anything outside those two alphabets.
+func (t *TextIngestAgent) detectLanguage(text string) (string, float64) {
+	code, conf, _ := t.DetectWithConfidence(text)
+	return code, conf
 }
 
 // countWords counts the total number of words in all paragraphs
@@ -3,6 +3,8 @@ package agents
 import (
 	"path/filepath"
 	"testing"
+
+	"studiospeech/internal/fsys"
 )
 
 func TestTextIngestAgent_ProcessTxtFile(t *testing.T) {
@@ -57,6 +59,24 @@ func TestTextIngestAgent_ProcessGreekFile(t *testing.T) {
 		len(content.Paragraphs), content.WordCount, content.Language)
 }
 
+func TestTextIngestAgent_ProcessFile_MemFS(t *testing.T) {
+	agent := NewTextIngestAgent()
+	mem := fsys.NewMemFS()
+	agent.SetFS(mem)
+
+	if err := mem.WriteFile("/book.txt", []byte("Hello there.\n\nGeneral Kenobi."), 0644); err != nil {
+		t.Fatalf("failed to seed in-memory file: %v", err)
+	}
+
+	content, err := agent.ProcessFile("/book.txt")
+	if err != nil {
+		t.Fatalf("ProcessFile failed: %v", err)
+	}
+	if len(content.Paragraphs) != 2 {
+		t.Errorf("got %d paragraphs, want 2", len(content.Paragraphs))
+	}
+}
+
 func TestTextIngestAgent_ValidateContent(t *testing.T) {
 	agent := NewTextIngestAgent()
 
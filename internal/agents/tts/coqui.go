@@ -0,0 +1,108 @@
+package tts
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+func init() {
+	Register(&CoquiSynthesizer{binPath: "tts"})
+}
+
+// CoquiSynthesizer drives Coqui-TTS via its "tts" CLI, giving StudioSpeech
+// access to languages Piper doesn't ship voices for (e.g. Arabic). Unlike
+// Piper and espeak-ng, the CLI only writes a complete WAV file, so
+// streaming here means reading that file back once synthesis finishes
+// rather than tailing a live process.
+type CoquiSynthesizer struct {
+	binPath string
+}
+
+// NewCoquiSynthesizer creates a Coqui-TTS backend.
+func NewCoquiSynthesizer(binPath string) *CoquiSynthesizer {
+	if binPath == "" {
+		binPath = "tts"
+	}
+	return &CoquiSynthesizer{binPath: binPath}
+}
+
+// Name implements Synthesizer.
+func (c *CoquiSynthesizer) Name() string { return "coqui" }
+
+// Available implements Synthesizer.
+func (c *CoquiSynthesizer) Available(ctx context.Context) error {
+	if _, err := exec.LookPath(c.binPath); err != nil {
+		return fmt.Errorf("coqui tts CLI not found in PATH: %w", err)
+	}
+	return nil
+}
+
+// SupportedLanguages implements Synthesizer. XTTS-v2, Coqui's flagship
+// model, covers these locales out of the box.
+func (c *CoquiSynthesizer) SupportedLanguages() []string {
+	return []string{"en-US", "es-ES", "fr-FR", "de-DE", "it-IT", "pt-PT", "ar", "zh-CN", "ja-JP"}
+}
+
+// Synthesize implements Synthesizer by shelling out to "tts --text ...
+// --out_path <tmp.wav>" and then streaming the resulting file's PCM body.
+func (c *CoquiSynthesizer) Synthesize(ctx context.Context, req SynthRequest) (<-chan PCMChunk, error) {
+	tmpFile, err := os.CreateTemp("", "coqui_*.wav")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp output file: %w", err)
+	}
+	outPath := tmpFile.Name()
+	tmpFile.Close()
+
+	cmd := exec.CommandContext(ctx, c.binPath,
+		"--text", req.Text,
+		"--model_name", req.Voice,
+		"--out_path", outPath,
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		os.Remove(outPath)
+		return nil, fmt.Errorf("coqui tts failed: %w\nOutput: %s", err, string(output))
+	}
+
+	file, err := os.Open(outPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open coqui output: %w", err)
+	}
+
+	const wavHeaderBytes = 44
+	const frameBytes = 4096
+
+	out := make(chan PCMChunk)
+	go func() {
+		defer close(out)
+		defer file.Close()
+		defer os.Remove(filepath.Clean(outPath))
+
+		reader := bufio.NewReader(file)
+		if _, err := reader.Discard(wavHeaderBytes); err != nil {
+			return
+		}
+
+		buf := make([]byte, frameBytes)
+		for {
+			n, err := reader.Read(buf)
+			if n > 0 {
+				chunk := make([]byte, n)
+				copy(chunk, buf[:n])
+				select {
+				case out <- PCMChunk{Data: chunk, SampleRate: 24000, Channels: 1}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,103 @@
+package tts
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+)
+
+func init() {
+	Register(&EspeakSynthesizer{binPath: "espeak-ng"})
+}
+
+// EspeakSynthesizer drives espeak-ng, which ships with far broader
+// language coverage than Piper (if lower audio quality) and serves as a
+// dependency-free fallback backend.
+type EspeakSynthesizer struct {
+	binPath string
+}
+
+// NewEspeakSynthesizer creates an espeak-ng backend.
+func NewEspeakSynthesizer(binPath string) *EspeakSynthesizer {
+	if binPath == "" {
+		binPath = "espeak-ng"
+	}
+	return &EspeakSynthesizer{binPath: binPath}
+}
+
+// Name implements Synthesizer.
+func (e *EspeakSynthesizer) Name() string { return "espeak" }
+
+// Available implements Synthesizer.
+func (e *EspeakSynthesizer) Available(ctx context.Context) error {
+	if _, err := exec.LookPath(e.binPath); err != nil {
+		return fmt.Errorf("espeak-ng not found in PATH: %w", err)
+	}
+	return nil
+}
+
+// SupportedLanguages implements Synthesizer. espeak-ng ships voice data
+// for well over 100 languages; this lists the ones StudioSpeech's catalog
+// currently cares about.
+func (e *EspeakSynthesizer) SupportedLanguages() []string {
+	return []string{"en-US", "en-UK", "el-GR", "es-ES", "fr-FR", "de-DE", "it-IT", "pt-PT", "ru-RU", "ar"}
+}
+
+// Synthesize implements Synthesizer by invoking espeak-ng with
+// "--stdout" to get raw WAV on stdout, then streaming the PCM body (the
+// 44-byte canonical header is skipped).
+func (e *EspeakSynthesizer) Synthesize(ctx context.Context, req SynthRequest) (<-chan PCMChunk, error) {
+	// espeak-ng's -s is words-per-minute; map our 0.5-2.0 multiplier
+	// onto its ~175 wpm default.
+	wpm := int(175 * nonZero(req.Speed, 1.0))
+
+	args := []string{
+		"-v", req.Voice,
+		"-s", strconv.Itoa(wpm),
+		"--stdout",
+	}
+
+	cmd := exec.CommandContext(ctx, e.binPath, append(args, req.Text)...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start espeak-ng: %w", err)
+	}
+
+	const wavHeaderBytes = 44
+	const frameBytes = 4096
+
+	out := make(chan PCMChunk)
+	go func() {
+		defer close(out)
+		defer cmd.Wait()
+
+		reader := bufio.NewReader(stdout)
+		if _, err := reader.Discard(wavHeaderBytes); err != nil {
+			return
+		}
+
+		buf := make([]byte, frameBytes)
+		for {
+			n, err := reader.Read(buf)
+			if n > 0 {
+				chunk := make([]byte, n)
+				copy(chunk, buf[:n])
+				select {
+				case out <- PCMChunk{Data: chunk, SampleRate: 22050, Channels: 1}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,105 @@
+package tts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"studiospeech/internal/agents/tts/ttspb"
+)
+
+func init() {
+	Register(&GRPCSynthesizer{})
+}
+
+// GRPCSynthesizer forwards synthesis to a remote engine speaking the
+// TTSService protocol defined in grpc.proto (see ttspb for the generated
+// stubs). Voice.Path for this backend looks like "grpc://host:port/voice-name".
+type GRPCSynthesizer struct {
+	// DialTimeout bounds how long Synthesize waits for a connection
+	// (5s when zero).
+	DialTimeout time.Duration
+}
+
+// Name implements Synthesizer.
+func (g *GRPCSynthesizer) Name() string { return "grpc" }
+
+// Available implements Synthesizer. The address to dial is only known
+// from each request's Voice, so Available dials nothing and always
+// returns nil, leaving real health checking to Synthesize.
+func (g *GRPCSynthesizer) Available(ctx context.Context) error {
+	return nil
+}
+
+// SupportedLanguages implements Synthesizer. A gRPC backend's language
+// coverage depends entirely on the remote engine it fronts.
+func (g *GRPCSynthesizer) SupportedLanguages() []string { return nil }
+
+// Synthesize implements Synthesizer, dialing the target embedded in
+// req.Voice (as "host:port/voice-name") and streaming AudioChunk messages
+// back as PCMChunk.
+func (g *GRPCSynthesizer) Synthesize(ctx context.Context, req SynthRequest) (<-chan PCMChunk, error) {
+	target, voiceName, err := parseSchemeVoice("grpc", req.Voice)
+	if err != nil {
+		return nil, err
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, nonZeroDuration(g.DialTimeout, 5*time.Second))
+	defer cancel()
+
+	conn, err := grpc.DialContext(dialCtx, target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial gRPC TTS backend %s: %w", target, err)
+	}
+
+	client := ttspb.NewTTSServiceClient(conn)
+	stream, err := client.Synthesize(ctx, &ttspb.SynthesizeRequest{
+		Text:    req.Text,
+		Voice:   voiceName,
+		Speed:   req.Speed,
+		Noise:   req.Noise,
+		NoiseW:  req.NoiseW,
+		Speaker: int32(req.Speaker),
+	})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to start gRPC synthesis stream: %w", err)
+	}
+
+	out := make(chan PCMChunk)
+	go func() {
+		defer close(out)
+		defer conn.Close()
+
+		for {
+			chunk, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			select {
+			case out <- PCMChunk{
+				Data:       chunk.Pcm,
+				SampleRate: int(chunk.SampleRate),
+				Channels:   int(chunk.Channels),
+			}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func nonZeroDuration(v, fallback time.Duration) time.Duration {
+	if v == 0 {
+		return fallback
+	}
+	return v
+}
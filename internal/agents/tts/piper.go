@@ -0,0 +1,122 @@
+package tts
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	Register(&PiperSynthesizer{piperPath: "piper"})
+}
+
+// PiperSynthesizer drives the Piper neural TTS engine as a subprocess,
+// the same way agents.SynthAgent does today, but streams raw PCM instead
+// of writing a temporary WAV file.
+type PiperSynthesizer struct {
+	piperPath string
+}
+
+// NewPiperSynthesizer creates a Piper backend that invokes the given
+// binary (or "piper" if empty).
+func NewPiperSynthesizer(piperPath string) *PiperSynthesizer {
+	if piperPath == "" {
+		piperPath = "piper"
+	}
+	return &PiperSynthesizer{piperPath: piperPath}
+}
+
+// Name implements Synthesizer.
+func (p *PiperSynthesizer) Name() string { return "piper" }
+
+// Available implements Synthesizer.
+func (p *PiperSynthesizer) Available(ctx context.Context) error {
+	path, err := exec.LookPath(p.piperPath)
+	if err != nil {
+		return fmt.Errorf("piper not found in PATH: %w", err)
+	}
+	cmd := exec.CommandContext(ctx, path, "--version")
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("piper --version failed: %w", err)
+	}
+	return nil
+}
+
+// SupportedLanguages implements Synthesizer. Piper's language coverage is
+// determined entirely by which voice models are installed, so it has no
+// fixed list.
+func (p *PiperSynthesizer) SupportedLanguages() []string { return nil }
+
+// Synthesize implements Synthesizer, streaming raw 16-bit PCM read from
+// Piper's "--output_raw" stdout in fixed-size frames.
+func (p *PiperSynthesizer) Synthesize(ctx context.Context, req SynthRequest) (<-chan PCMChunk, error) {
+	args := []string{
+		"--model", req.Voice,
+		"--output_raw",
+		"--length_scale", strconv.FormatFloat(1.0/nonZero(req.Speed, 1.0), 'f', 3, 64),
+		"--noise_scale", strconv.FormatFloat(req.Noise, 'f', 3, 64),
+		"--noise_w", strconv.FormatFloat(req.NoiseW, 'f', 3, 64),
+	}
+	if req.Speaker > 0 {
+		args = append(args, "--speaker", strconv.Itoa(req.Speaker))
+	}
+
+	cmd := exec.CommandContext(ctx, p.piperPath, args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdin pipe: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start piper: %w", err)
+	}
+
+	go func() {
+		defer stdin.Close()
+		io.WriteString(stdin, strings.TrimSpace(req.Text))
+	}()
+
+	const sampleRate = 22050
+	const frameBytes = 4096
+
+	out := make(chan PCMChunk)
+	go func() {
+		defer close(out)
+		defer cmd.Wait()
+
+		reader := bufio.NewReader(stdout)
+		buf := make([]byte, frameBytes)
+		for {
+			n, err := reader.Read(buf)
+			if n > 0 {
+				chunk := make([]byte, n)
+				copy(chunk, buf[:n])
+				select {
+				case out <- PCMChunk{Data: chunk, SampleRate: sampleRate, Channels: 1}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func nonZero(v, fallback float64) float64 {
+	if v == 0 {
+		return fallback
+	}
+	return v
+}
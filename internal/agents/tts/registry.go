@@ -0,0 +1,38 @@
+package tts
+
+import "sync"
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Synthesizer{}
+)
+
+// Register adds a backend to the global registry under s.Name(). Backends
+// typically call this from an init() in their own file so simply
+// importing the package (or a build-tagged variant of it) is enough to
+// make the backend selectable.
+func Register(s Synthesizer) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[s.Name()] = s
+}
+
+// Get looks up a registered backend by name.
+func Get(name string) (Synthesizer, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	s, ok := registry[name]
+	return s, ok
+}
+
+// All returns every registered backend, in no particular order.
+func All() []Synthesizer {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	backends := make([]Synthesizer, 0, len(registry))
+	for _, s := range registry {
+		backends = append(backends, s)
+	}
+	return backends
+}
@@ -0,0 +1,32 @@
+package tts
+
+import "testing"
+
+func TestRegistry_BuiltinBackendsRegistered(t *testing.T) {
+	for _, name := range []string{"piper", "espeak", "coqui", "grpc", "say", "xtts", "elevenlabs"} {
+		if _, ok := Get(name); !ok {
+			t.Errorf("expected backend %q to be registered", name)
+		}
+	}
+}
+
+func TestParseSchemeVoice(t *testing.T) {
+	addr, voice, err := parseSchemeVoice("grpc", "grpc://localhost:9090/en-US-female")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if addr != "localhost:9090" {
+		t.Errorf("expected addr localhost:9090, got %s", addr)
+	}
+	if voice != "en-US-female" {
+		t.Errorf("expected voice en-US-female, got %s", voice)
+	}
+
+	if _, _, err := parseSchemeVoice("grpc", "not-a-grpc-uri"); err == nil {
+		t.Error("expected error for malformed voice reference")
+	}
+
+	if _, _, err := parseSchemeVoice("grpc", "xtts://localhost:9090/voice"); err == nil {
+		t.Error("expected error when the scheme doesn't match")
+	}
+}
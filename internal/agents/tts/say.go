@@ -0,0 +1,123 @@
+package tts
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	Register(&SaySynthesizer{binPath: "say"})
+}
+
+// SaySynthesizer drives macOS's built-in "say" command, replacing the old
+// isMacOSVoice special case in agents.SynthAgent with an ordinary
+// registered backend. Voice.Path for this backend looks like "say://Alex"
+// or "say://Samantha"; Synthesize strips the scheme before handing the
+// voice name to say.
+type SaySynthesizer struct {
+	binPath string
+}
+
+// NewSaySynthesizer creates a macOS "say" backend that invokes the given
+// binary (or "say" if empty).
+func NewSaySynthesizer(binPath string) *SaySynthesizer {
+	if binPath == "" {
+		binPath = "say"
+	}
+	return &SaySynthesizer{binPath: binPath}
+}
+
+// Name implements Synthesizer.
+func (s *SaySynthesizer) Name() string { return "say" }
+
+// Available implements Synthesizer.
+func (s *SaySynthesizer) Available(ctx context.Context) error {
+	if runtime.GOOS != "darwin" {
+		return fmt.Errorf("the say backend only runs on macOS")
+	}
+	if _, err := exec.LookPath(s.binPath); err != nil {
+		return fmt.Errorf("say not found in PATH: %w", err)
+	}
+	return nil
+}
+
+// SupportedLanguages implements Synthesizer. Which languages are usable
+// depends on which system voices are installed, so it has no fixed list.
+func (s *SaySynthesizer) SupportedLanguages() []string { return nil }
+
+// Synthesize implements Synthesizer by invoking "say -o <tmp.wav>
+// --file-format=WAVE --data-format=LEI16@22050 ..." and streaming the
+// resulting file's PCM body back, the same file-then-reread pattern
+// CoquiSynthesizer uses since "say" has no raw-PCM-to-stdout mode.
+func (s *SaySynthesizer) Synthesize(ctx context.Context, req SynthRequest) (<-chan PCMChunk, error) {
+	tmpFile, err := os.CreateTemp("", "say_*.wav")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp output file: %w", err)
+	}
+	outPath := tmpFile.Name()
+	tmpFile.Close()
+
+	const sampleRate = 22050
+	wpm := int(175 * nonZero(req.Speed, 1.0))
+
+	voiceName := strings.TrimPrefix(req.Voice, "say://")
+
+	cmd := exec.CommandContext(ctx, s.binPath,
+		"-v", voiceName,
+		"-r", strconv.Itoa(wpm),
+		"--file-format=WAVE",
+		fmt.Sprintf("--data-format=LEI16@%d", sampleRate),
+		"-o", outPath,
+		req.Text,
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		os.Remove(outPath)
+		return nil, fmt.Errorf("say failed: %w\nOutput: %s", err, string(output))
+	}
+
+	file, err := os.Open(outPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open say output: %w", err)
+	}
+
+	const wavHeaderBytes = 44
+	const frameBytes = 4096
+
+	out := make(chan PCMChunk)
+	go func() {
+		defer close(out)
+		defer file.Close()
+		defer os.Remove(filepath.Clean(outPath))
+
+		reader := bufio.NewReader(file)
+		if _, err := reader.Discard(wavHeaderBytes); err != nil {
+			return
+		}
+
+		buf := make([]byte, frameBytes)
+		for {
+			n, err := reader.Read(buf)
+			if n > 0 {
+				chunk := make([]byte, n)
+				copy(chunk, buf[:n])
+				select {
+				case out <- PCMChunk{Data: chunk, SampleRate: sampleRate, Channels: 1}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
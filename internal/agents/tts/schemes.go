@@ -0,0 +1,23 @@
+package tts
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseSchemeVoice splits a "<scheme>://addr/identifier" voice reference
+// (the convention the gRPC and XTTS backends use for Voice.Path) into its host/port-or-origin portion and trailing
+// identifier.
+func parseSchemeVoice(scheme, voice string) (addr, identifier string, err error) {
+	prefix := scheme + "://"
+	rest := strings.TrimPrefix(voice, prefix)
+	if rest == voice {
+		return "", "", fmt.Errorf("%s backend expects a %saddr/identifier reference, got %q", scheme, prefix, voice)
+	}
+
+	parts := strings.SplitN(rest, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("%s backend expects a %saddr/identifier reference, got %q", scheme, prefix, voice)
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,53 @@
+// Package tts defines the pluggable synthesis backend contract used by
+// agents.SynthAgent. Each backend (Piper, espeak-ng, Coqui-TTS, a remote
+// gRPC engine, ...) registers itself at init time so the CLI and the
+// voice catalog can discover what is actually available on a given
+// machine without SynthAgent knowing about any concrete engine.
+package tts
+
+import "context"
+
+// PCMChunk is one slice of raw, little-endian signed 16-bit PCM audio
+// produced by a Synthesizer.
+type PCMChunk struct {
+	Data       []byte
+	SampleRate int
+	Channels   int
+}
+
+// SynthRequest describes a single synthesis call. Backends translate the
+// generic fields into their own parameter space (e.g. Piper's
+// length_scale, ElevenLabs' stability/style).
+type SynthRequest struct {
+	Text    string
+	Voice   string // backend-specific voice identifier (model path, voice ID, ...)
+	Speed   float64
+	Noise   float64
+	NoiseW  float64
+	Speaker int
+}
+
+// Synthesizer is implemented by every TTS engine backend StudioSpeech can
+// drive. Synthesize streams PCM so long inputs don't have to be buffered
+// in full before playback or encoding can start.
+type Synthesizer interface {
+	// Name returns the backend's registry key, e.g. "piper" or "coqui".
+	Name() string
+
+	// Available reports whether the backend's runtime dependencies
+	// (binary on PATH, reachable endpoint, ...) are usable right now.
+	Available(ctx context.Context) error
+
+	// SupportedLanguages returns the BCP-47 language codes this backend
+	// can synthesize, or nil if it accepts any voice the catalog offers.
+	SupportedLanguages() []string
+
+	// Synthesize renders req.Text and streams the result as PCM chunks.
+	// The returned channel is closed when synthesis completes; an error
+	// encountered mid-stream is returned from the initial call only if
+	// it happens before streaming starts. Implementations must select on
+	// ctx.Done() around each channel send so that canceling ctx stops
+	// the backend (and reaps its subprocess/connection) even if the
+	// consumer has already stopped reading from the channel.
+	Synthesize(ctx context.Context, req SynthRequest) (<-chan PCMChunk, error)
+}
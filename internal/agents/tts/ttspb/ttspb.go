@@ -0,0 +1,76 @@
+// Package ttspb contains the client stubs that protoc --go_out=.
+// --go-grpc_out=. would generate from ../grpc.proto. They are hand-written
+// here (rather than checked-in generated output) to keep the module
+// buildable without a protoc toolchain; regenerate and replace this file
+// if the .proto grows beyond what GRPCSynthesizer needs.
+package ttspb
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// SynthesizeRequest mirrors the proto message of the same name.
+type SynthesizeRequest struct {
+	Text    string
+	Voice   string
+	Speed   float64
+	Noise   float64
+	NoiseW  float64
+	Speaker int32
+}
+
+// AudioChunk mirrors the proto message of the same name.
+type AudioChunk struct {
+	Pcm        []byte
+	SampleRate int32
+	Channels   int32
+}
+
+// TTSServiceClient is the client API for TTSService.
+type TTSServiceClient interface {
+	Synthesize(ctx context.Context, in *SynthesizeRequest, opts ...grpc.CallOption) (TTSService_SynthesizeClient, error)
+}
+
+// TTSService_SynthesizeClient is the streaming response handle returned
+// by TTSServiceClient.Synthesize.
+type TTSService_SynthesizeClient interface {
+	Recv() (*AudioChunk, error)
+}
+
+type ttsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewTTSServiceClient wraps a gRPC client connection with the TTSService
+// stub.
+func NewTTSServiceClient(cc grpc.ClientConnInterface) TTSServiceClient {
+	return &ttsServiceClient{cc: cc}
+}
+
+func (c *ttsServiceClient) Synthesize(ctx context.Context, in *SynthesizeRequest, opts ...grpc.CallOption) (TTSService_SynthesizeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &grpc.StreamDesc{StreamName: "Synthesize", ServerStreams: true}, "/tts.TTSService/Synthesize", opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return &ttsServiceSynthesizeClient{stream}, nil
+}
+
+type ttsServiceSynthesizeClient struct {
+	grpc.ClientStream
+}
+
+func (c *ttsServiceSynthesizeClient) Recv() (*AudioChunk, error) {
+	m := new(AudioChunk)
+	if err := c.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
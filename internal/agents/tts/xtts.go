@@ -0,0 +1,111 @@
+package tts
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+func init() {
+	Register(&XTTSSynthesizer{})
+}
+
+// XTTSSynthesizer drives a Coqui XTTS-v2 server over its HTTP API,
+// letting StudioSpeech use XTTS's voice-cloning/cross-lingual models
+// without shelling out to the Python CLI the way CoquiSynthesizer does.
+// Voice.Path for this backend looks like "xtts://host:port/speaker-name".
+type XTTSSynthesizer struct {
+	// Client sends the synthesis request; defaults to http.DefaultClient
+	// when nil.
+	Client *http.Client
+}
+
+// Name implements Synthesizer.
+func (x *XTTSSynthesizer) Name() string { return "xtts" }
+
+// Available implements Synthesizer. Like GRPCSynthesizer, there's no
+// fixed address to health-check ahead of time, so real availability is
+// only known once Synthesize dials the server embedded in the voice
+// reference.
+func (x *XTTSSynthesizer) Available(ctx context.Context) error { return nil }
+
+// SupportedLanguages implements Synthesizer, listing the locales XTTS-v2
+// ships pretrained speakers for.
+func (x *XTTSSynthesizer) SupportedLanguages() []string {
+	return []string{"en", "es", "fr", "de", "it", "pt", "pl", "tr", "ru", "nl", "cs", "ar", "zh-cn", "ja", "hu", "ko"}
+}
+
+// Synthesize implements Synthesizer by POSTing to the XTTS server's
+// "/api/tts" endpoint embedded in req.Voice and streaming the WAV
+// response's PCM body back.
+func (x *XTTSSynthesizer) Synthesize(ctx context.Context, req SynthRequest) (<-chan PCMChunk, error) {
+	addr, speaker, err := parseSchemeVoice("xtts", req.Voice)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"text":    req.Text,
+		"speaker": speaker,
+		"speed":   nonZero(req.Speed, 1.0),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode xtts request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("http://%s/api/tts", addr), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build xtts request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	client := x.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("xtts request failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("xtts server returned %s", resp.Status)
+	}
+
+	const wavHeaderBytes = 44
+	const frameBytes = 4096
+	const sampleRate = 24000
+
+	out := make(chan PCMChunk)
+	go func() {
+		defer close(out)
+		defer resp.Body.Close()
+
+		reader := bufio.NewReader(resp.Body)
+		if _, err := reader.Discard(wavHeaderBytes); err != nil {
+			return
+		}
+
+		buf := make([]byte, frameBytes)
+		for {
+			n, err := reader.Read(buf)
+			if n > 0 {
+				chunk := make([]byte, n)
+				copy(chunk, buf[:n])
+				select {
+				case out <- PCMChunk{Data: chunk, SampleRate: sampleRate, Channels: 1}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
@@ -7,22 +7,62 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync"
 )
 
 // Voice represents a voice model in the catalog
 type Voice struct {
-	ID                   string `json:"id"`
-	Language             string `json:"language"`
-	Gender               string `json:"gender"`
-	Style                string `json:"style"`
-	SampleRate           int    `json:"sample_rate"`
-	CommercialUseAllowed bool   `json:"commercial_use_allowed"`
-	AttributionRequired  bool   `json:"attribution_required"`
-	LicenseName          string `json:"license_name"`
-	LicenseURL           string `json:"license_url"`
-	SourceURL            string `json:"source_url"`
-	SHA256               string `json:"sha256"`
-	Path                 string `json:"path"`
+	ID                   string     `json:"id"`
+	Language             string     `json:"language"`
+	Gender               string     `json:"gender"`
+	Style                string     `json:"style"`
+	Backend              string     `json:"backend"` // tts backend name (e.g. "piper", "coqui"); defaults to "piper" when empty
+	SampleRate           int        `json:"sample_rate"`
+	CommercialUseAllowed bool       `json:"commercial_use_allowed"`
+	AttributionRequired  bool       `json:"attribution_required"`
+	LicenseName          string     `json:"license_name"`
+	LicenseURL           string     `json:"license_url"`
+	SourceURL            SourceURLs `json:"source_url"`
+	SHA256               string     `json:"sha256"`
+	Path                 string     `json:"path"`
+	// SizeBytes is the expected size of the primary model file, used as
+	// a download progress total when the server response omits
+	// Content-Length.
+	SizeBytes int64 `json:"size_bytes,omitempty"`
+	// Mirrors lists alternate URLs for the primary model file (SourceURL[0]),
+	// tried in order if the primary source fails.
+	Mirrors []string `json:"mirrors,omitempty"`
+}
+
+// SourceURLs is a voice's download source(s). A catalog entry can give a
+// single URL as a plain JSON string, or a list when the voice ships as
+// multiple files (e.g. a Piper .onnx model plus its .onnx.json config
+// sidecar, when the sidecar isn't at the conventional "<model>.json" URL).
+type SourceURLs []string
+
+// UnmarshalJSON accepts either a single JSON string or an array of strings.
+func (s *SourceURLs) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*s = SourceURLs{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("source_url must be a string or an array of strings: %w", err)
+	}
+	*s = SourceURLs(list)
+	return nil
+}
+
+// MarshalJSON writes a single-element list back out as a plain string, so
+// catalogs round-trip without growing brackets around the common case.
+func (s SourceURLs) MarshalJSON() ([]byte, error) {
+	if len(s) == 1 {
+		return json.Marshal(s[0])
+	}
+	return json.Marshal([]string(s))
 }
 
 // VoiceCatalog contains all available voices
@@ -33,7 +73,12 @@ type VoiceCatalog struct {
 // VoiceCatalogAgent manages voice model selection and validation
 type VoiceCatalogAgent struct {
 	catalogPath string
-	catalog     *VoiceCatalog
+
+	mu      sync.RWMutex
+	catalog *VoiceCatalog
+
+	subMu       sync.Mutex
+	subscribers []func(*VoiceCatalog)
 }
 
 // NewVoiceCatalogAgent creates a new voice catalog agent
@@ -45,9 +90,65 @@ func NewVoiceCatalogAgent(catalogPath string) *VoiceCatalogAgent {
 
 // LoadCatalog reads and validates the voice catalog
 func (v *VoiceCatalogAgent) LoadCatalog() error {
+	catalog, err := v.parseAndValidate()
+	if err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	v.catalog = catalog
+	v.mu.Unlock()
+	return nil
+}
+
+// ReloadCatalog re-reads and validates catalogPath, swapping it in only
+// if validation succeeds, then notifies every Subscribe callback with
+// the new catalog. It's safe to call concurrently with SelectVoice and
+// the other read methods below - a reader either sees the previous
+// catalog in full or the new one in full, never a partial swap.
+func (v *VoiceCatalogAgent) ReloadCatalog() error {
+	catalog, err := v.parseAndValidate()
+	if err != nil {
+		return fmt.Errorf("catalog reload rejected: %w", err)
+	}
+
+	v.mu.Lock()
+	v.catalog = catalog
+	v.mu.Unlock()
+
+	v.notifySubscribers(catalog)
+	return nil
+}
+
+// Subscribe registers fn to be called with the new catalog every time
+// ReloadCatalog swaps one in, so a long-running caller (the HTTP server,
+// a batch pipeline) can react - e.g. re-resolving any voice it cached by
+// ID - without polling GetAvailableVoices itself.
+func (v *VoiceCatalogAgent) Subscribe(fn func(*VoiceCatalog)) {
+	v.subMu.Lock()
+	defer v.subMu.Unlock()
+	v.subscribers = append(v.subscribers, fn)
+}
+
+// notifySubscribers calls every Subscribe callback with catalog, outside
+// of v.mu so a slow subscriber can't block SelectVoice.
+func (v *VoiceCatalogAgent) notifySubscribers(catalog *VoiceCatalog) {
+	v.subMu.Lock()
+	subs := append([]func(*VoiceCatalog){}, v.subscribers...)
+	v.subMu.Unlock()
+
+	for _, fn := range subs {
+		fn(catalog)
+	}
+}
+
+// parseAndValidate reads and validates catalogPath without touching any
+// live state, so LoadCatalog and ReloadCatalog can share it while only
+// the latter needs to swap-then-notify.
+func (v *VoiceCatalogAgent) parseAndValidate() (*VoiceCatalog, error) {
 	file, err := os.Open(v.catalogPath)
 	if err != nil {
-		return fmt.Errorf("failed to open catalog file: %w", err)
+		return nil, fmt.Errorf("failed to open catalog file: %w", err)
 	}
 	defer file.Close()
 
@@ -55,18 +156,16 @@ func (v *VoiceCatalogAgent) LoadCatalog() error {
 	catalog := &VoiceCatalog{}
 
 	if err := decoder.Decode(catalog); err != nil {
-		return fmt.Errorf("failed to parse catalog JSON: %w", err)
+		return nil, fmt.Errorf("failed to parse catalog JSON: %w", err)
 	}
 
-	// Validate each voice entry
 	for i, voice := range catalog.Voices {
 		if err := v.validateVoice(&voice); err != nil {
-			return fmt.Errorf("invalid voice entry %d (%s): %w", i, voice.ID, err)
+			return nil, fmt.Errorf("invalid voice entry %d (%s): %w", i, voice.ID, err)
 		}
 	}
 
-	v.catalog = catalog
-	return nil
+	return catalog, nil
 }
 
 // validateVoice ensures a voice entry meets commercial safety requirements
@@ -102,37 +201,70 @@ func (v *VoiceCatalogAgent) validateVoice(voice *Voice) error {
 	return nil
 }
 
-// SelectVoice chooses appropriate voice based on language, voice ID, and gender
+// SelectVoice chooses appropriate voice based on language, voice ID, and gender.
+// It is equivalent to SelectVoiceForBackend(language, voiceID, gender, "auto").
 func (v *VoiceCatalogAgent) SelectVoice(language, voiceID, gender string) (*Voice, error) {
+	return v.SelectVoiceForBackend(language, voiceID, gender, "auto")
+}
+
+// SelectVoiceForBackend chooses a voice as SelectVoice does, additionally
+// restricting candidates to the given tts backend name (e.g. "piper",
+// "coqui"), or considering all backends when backend is "auto" or empty.
+// Voices with an empty Backend field are treated as "piper" for backward
+// compatibility with catalogs predating multi-backend support. It is
+// equivalent to SelectVoiceForQuality(..., QualityStandard), which has no
+// minimum sample rate worth warning about.
+func (v *VoiceCatalogAgent) SelectVoiceForBackend(language, voiceID, gender, backend string) (*Voice, error) {
+	voice, _, err := v.SelectVoiceForQuality(language, voiceID, gender, backend, QualityStandard)
+	return voice, err
+}
+
+// SelectVoiceForQuality chooses a voice as SelectVoiceForBackend does,
+// additionally preferring a candidate whose native SampleRate meets
+// tier's QualitySpec.MinVoiceSampleRate. When no candidate clears that
+// bar, it falls back to the best available candidate and returns a
+// non-empty warning describing the shortfall, rather than failing
+// outright.
+func (v *VoiceCatalogAgent) SelectVoiceForQuality(language, voiceID, gender, backend string, tier QualityTier) (voice *Voice, warning string, err error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
 	if v.catalog == nil {
-		return nil, fmt.Errorf("catalog not loaded")
+		return nil, "", fmt.Errorf("catalog not loaded")
 	}
 
 	// If specific voice ID requested, find it
 	if voiceID != "auto" && voiceID != "" {
 		for _, voice := range v.catalog.Voices {
 			if voice.ID == voiceID {
-				return &voice, nil
+				return &voice, "", nil
 			}
 		}
-		return nil, fmt.Errorf("voice ID %s not found in catalog", voiceID)
+		return nil, "", fmt.Errorf("voice ID %s not found in catalog", voiceID)
 	}
 
-	// Auto-select based on language and gender
+	// Auto-select based on language, gender, and backend
 	var candidates []Voice
 
 	// Normalize language code
 	lang := v.normalizeLanguage(language)
 
-	// Find voices matching the language
+	// Find voices matching the language and backend
 	for _, voice := range v.catalog.Voices {
-		if strings.HasPrefix(voice.Language, lang) {
-			candidates = append(candidates, voice)
+		if !strings.HasPrefix(voice.Language, lang) {
+			continue
 		}
+		if backend != "auto" && backend != "" && voiceBackend(voice) != backend {
+			continue
+		}
+		candidates = append(candidates, voice)
 	}
 
 	if len(candidates) == 0 {
-		return nil, fmt.Errorf("no voices found for language %s", language)
+		if backend != "auto" && backend != "" {
+			return nil, "", fmt.Errorf("no voices found for language %s on backend %s", language, backend)
+		}
+		return nil, "", fmt.Errorf("no voices found for language %s", language)
 	}
 
 	// Filter by gender if specified
@@ -148,15 +280,45 @@ func (v *VoiceCatalogAgent) SelectVoice(language, voiceID, gender string) (*Voic
 		}
 	}
 
+	// Prefer candidates that meet the tier's minimum sample rate; fall
+	// back to all candidates (with a warning) if none do.
+	minRate := tier.Spec().MinVoiceSampleRate
+	tierCandidates := candidates
+	if minRate > 0 {
+		var meetsTarget []Voice
+		for _, voice := range candidates {
+			if voice.SampleRate >= minRate {
+				meetsTarget = append(meetsTarget, voice)
+			}
+		}
+		if len(meetsTarget) > 0 {
+			tierCandidates = meetsTarget
+		}
+	}
+
 	// Prefer higher quality voices (heuristic: higher sample rate)
-	bestVoice := &candidates[0]
-	for i := 1; i < len(candidates); i++ {
-		if candidates[i].SampleRate > bestVoice.SampleRate {
-			bestVoice = &candidates[i]
+	bestVoice := &tierCandidates[0]
+	for i := 1; i < len(tierCandidates); i++ {
+		if tierCandidates[i].SampleRate > bestVoice.SampleRate {
+			bestVoice = &tierCandidates[i]
 		}
 	}
 
-	return bestVoice, nil
+	if minRate > 0 && bestVoice.SampleRate < minRate {
+		warning = fmt.Sprintf("no %s-tier voice (>= %d Hz) available for language %s; falling back to %s (%d Hz)",
+			tier, minRate, language, bestVoice.ID, bestVoice.SampleRate)
+	}
+
+	return bestVoice, warning, nil
+}
+
+// voiceBackend returns voice.Backend, defaulting to "piper" for catalog
+// entries that predate the Backend field.
+func voiceBackend(voice Voice) string {
+	if voice.Backend == "" {
+		return "piper"
+	}
+	return voice.Backend
 }
 
 // normalizeLanguage converts language codes to standard format
@@ -168,9 +330,25 @@ func (v *VoiceCatalogAgent) normalizeLanguage(lang string) string {
 		return "en-UK"
 	case "el", "greek", "el-gr", "el_gr":
 		return "el-GR"
+	case "es", "spanish", "es-es", "es_es":
+		return "es-ES"
+	case "fr", "french", "fr-fr", "fr_fr":
+		return "fr-FR"
+	case "de", "german", "de-de", "de_de":
+		return "de-DE"
+	case "it", "italian", "it-it", "it_it":
+		return "it-IT"
+	case "pt", "portuguese", "pt-pt", "pt_pt":
+		return "pt-PT"
+	case "nl", "dutch", "nl-nl", "nl_nl":
+		return "nl-NL"
+	case "ru", "russian", "ru-ru", "ru_ru":
+		return "ru-RU"
 	case "auto":
 		return "en-US" // Default to English
 	default:
+		// Already a full BCP-47 tag (e.g. from TextIngestAgent's
+		// trigram detector) - pass it through unchanged.
 		return lang
 	}
 }
@@ -210,6 +388,9 @@ func (v *VoiceCatalogAgent) ValidateVoiceFile(voice *Voice) error {
 
 // GetAvailableVoices returns list of all valid voices
 func (v *VoiceCatalogAgent) GetAvailableVoices() []Voice {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
 	if v.catalog == nil {
 		return nil
 	}
@@ -218,6 +399,9 @@ func (v *VoiceCatalogAgent) GetAvailableVoices() []Voice {
 
 // GetAttributionText returns required attribution text for voices that need it
 func (v *VoiceCatalogAgent) GetAttributionText() []string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
 	var attributions []string
 
 	if v.catalog == nil {
@@ -226,18 +410,25 @@ func (v *VoiceCatalogAgent) GetAttributionText() []string {
 
 	for _, voice := range v.catalog.Voices {
 		if voice.AttributionRequired {
-			switch {
-			case strings.Contains(strings.ToLower(voice.LicenseName), "libritts"):
-				attributions = append(attributions,
-					fmt.Sprintf("Voice %s: This project uses the LibriTTS dataset (CC BY 4.0). "+
-						"© Original contributors. Licensed under CC BY 4.0 (%s). No endorsement implied.",
-						voice.ID, voice.LicenseURL))
-			default:
-				attributions = append(attributions,
-					fmt.Sprintf("Voice %s: %s (%s)", voice.ID, voice.LicenseName, voice.LicenseURL))
-			}
+			attributions = append(attributions, AttributionTextForVoice(voice))
 		}
 	}
 
 	return attributions
 }
+
+// AttributionTextForVoice formats the attribution line a downstream
+// consumer must display for a single voice. It is exported standalone (not
+// just via GetAttributionText) so callers that only have one freshly
+// downloaded voice in hand, like VoiceInstaller, don't need a loaded
+// catalog to print the same text.
+func AttributionTextForVoice(voice Voice) string {
+	switch {
+	case strings.Contains(strings.ToLower(voice.LicenseName), "libritts"):
+		return fmt.Sprintf("Voice %s: This project uses the LibriTTS dataset (CC BY 4.0). "+
+			"© Original contributors. Licensed under CC BY 4.0 (%s). No endorsement implied.",
+			voice.ID, voice.LicenseURL)
+	default:
+		return fmt.Sprintf("Voice %s: %s (%s)", voice.ID, voice.LicenseName, voice.LicenseURL)
+	}
+}
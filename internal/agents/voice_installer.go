@@ -0,0 +1,277 @@
+package agents
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ProgressFunc reports download progress for a single source URL. total is
+// 0 when the server didn't report a Content-Length.
+type ProgressFunc func(url string, downloaded, total int64)
+
+// DownloadOptions controls VoiceInstaller.Download and DownloadAll.
+type DownloadOptions struct {
+	// AllowNonCommercial permits downloading a voice whose
+	// CommercialUseAllowed is false. Without it, Download refuses.
+	AllowNonCommercial bool
+	// Progress, if set, is called periodically while each source file
+	// streams to disk.
+	Progress ProgressFunc
+}
+
+// VoiceInstaller downloads voice model files referenced by a catalog's
+// Voice.SourceURL and verifies them against Voice.SHA256, filling in the
+// fetch step that ValidateVoiceFile assumes has already happened.
+type VoiceInstaller struct {
+	catalog    *VoiceCatalogAgent
+	httpClient *http.Client
+}
+
+// NewVoiceInstaller creates an installer over an already-loaded catalog.
+func NewVoiceInstaller(catalog *VoiceCatalogAgent) *VoiceInstaller {
+	return &VoiceInstaller{
+		catalog:    catalog,
+		httpClient: http.DefaultClient,
+	}
+}
+
+// downloadTarget is one file to fetch: a source URL and the local path it
+// should end up at. sizeHint, if set, is used as the progress total when
+// the server response doesn't report a Content-Length.
+type downloadTarget struct {
+	url      string
+	path     string
+	sizeHint int64
+}
+
+// sourceTargets resolves a voice's SourceURL into the concrete files to
+// download. Most voices ship as a single file. Piper voices additionally
+// need an ".onnx.json" config sidecar; when SourceURL names exactly one
+// ".onnx" file we derive the conventional sidecar URL automatically, so
+// catalogs only need to list both URLs explicitly when the sidecar lives
+// somewhere non-standard.
+func sourceTargets(voice *Voice) ([]downloadTarget, error) {
+	if len(voice.SourceURL) == 0 {
+		return nil, fmt.Errorf("voice %s has no source_url", voice.ID)
+	}
+
+	targets := []downloadTarget{{url: voice.SourceURL[0], path: voice.Path, sizeHint: voice.SizeBytes}}
+
+	if len(voice.SourceURL) > 1 {
+		for _, u := range voice.SourceURL[1:] {
+			targets = append(targets, downloadTarget{
+				url:  u,
+				path: filepath.Join(filepath.Dir(voice.Path), filepath.Base(u)),
+			})
+		}
+		return targets, nil
+	}
+
+	if strings.HasSuffix(voice.SourceURL[0], ".onnx") {
+		targets = append(targets, downloadTarget{
+			url:  voice.SourceURL[0] + ".json",
+			path: voice.Path + ".json",
+		})
+	}
+
+	return targets, nil
+}
+
+// Download fetches and verifies every file a voice needs, refusing
+// non-commercial voices unless opts.AllowNonCommercial is set. On a
+// SHA-256 mismatch the partially verified file is deleted before the error
+// is returned, so a retry starts clean instead of resuming bad data.
+func (vi *VoiceInstaller) Download(voice *Voice, opts DownloadOptions) error {
+	if !voice.CommercialUseAllowed && !opts.AllowNonCommercial {
+		return fmt.Errorf("voice %s is not licensed for commercial use (license: %s); pass --allow-non-commercial to override",
+			voice.ID, voice.LicenseName)
+	}
+
+	targets, err := sourceTargets(voice)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(voice.Path), 0755); err != nil {
+		return fmt.Errorf("failed to create voice directory: %w", err)
+	}
+
+	for i, target := range targets {
+		urls := []string{target.url}
+		if i == 0 {
+			// Only the primary model file is eligible for mirrors; every
+			// other file is fetched from its own URL alone.
+			urls = append(urls, voice.Mirrors...)
+		}
+		if err := vi.fetchWithFallback(target, urls, opts.Progress); err != nil {
+			return err
+		}
+	}
+
+	if err := vi.catalog.ValidateVoiceFile(voice); err != nil {
+		os.Remove(voice.Path)
+		return fmt.Errorf("downloaded voice failed verification: %w", err)
+	}
+
+	return nil
+}
+
+// DownloadAll downloads every voice in the catalog, collecting one error
+// per failing voice rather than stopping at the first failure.
+func (vi *VoiceInstaller) DownloadAll(opts DownloadOptions) []error {
+	var errs []error
+	for _, voice := range vi.catalog.GetAvailableVoices() {
+		voice := voice
+		if err := vi.Download(&voice, opts); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", voice.ID, err))
+		}
+	}
+	return errs
+}
+
+// Verify checks every catalog voice already on disk against its recorded
+// SHA-256, returning one error per voice that is missing or mismatched.
+func (vi *VoiceInstaller) Verify() []error {
+	var errs []error
+	for _, voice := range vi.catalog.GetAvailableVoices() {
+		voice := voice
+		if err := vi.catalog.ValidateVoiceFile(&voice); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", voice.ID, err))
+		}
+	}
+	return errs
+}
+
+// Prune removes stale ".part" resume files and any installed voice file
+// that fails SHA-256 verification, so a later download starts fresh
+// instead of silently resuming corrupt data.
+func (vi *VoiceInstaller) Prune() ([]string, error) {
+	var removed []string
+	for _, voice := range vi.catalog.GetAvailableVoices() {
+		voice := voice
+
+		partPath := voice.Path + ".part"
+		if _, err := os.Stat(partPath); err == nil {
+			if err := os.Remove(partPath); err != nil {
+				return removed, fmt.Errorf("failed to remove %s: %w", partPath, err)
+			}
+			removed = append(removed, partPath)
+		}
+
+		if _, err := os.Stat(voice.Path); err != nil {
+			continue
+		}
+		if err := vi.catalog.ValidateVoiceFile(&voice); err != nil {
+			if err := os.Remove(voice.Path); err != nil {
+				return removed, fmt.Errorf("failed to remove %s: %w", voice.Path, err)
+			}
+			removed = append(removed, voice.Path)
+		}
+	}
+	return removed, nil
+}
+
+// fetchWithFallback tries each of urls in turn against target.path,
+// returning as soon as one succeeds. It returns the last error if all of
+// them fail, so a caller sees why the final attempt didn't work rather
+// than an error from an earlier, possibly unrelated, mirror.
+func (vi *VoiceInstaller) fetchWithFallback(target downloadTarget, urls []string, progress ProgressFunc) error {
+	var lastErr error
+	for _, url := range urls {
+		attempt := target
+		attempt.url = url
+		if err := vi.fetch(attempt, progress); err != nil {
+			lastErr = err
+			continue
+		}
+		return nil
+	}
+	return lastErr
+}
+
+// fetch streams a single target to disk via a ".part" temp file, resuming
+// from wherever a previous attempt left off using an HTTP Range request.
+// The temp file is renamed into place only once the full body has been
+// written.
+func (vi *VoiceInstaller) fetch(target downloadTarget, progress ProgressFunc) error {
+	partPath := target.path + ".part"
+
+	var resumeFrom int64
+	if info, err := os.Stat(partPath); err == nil {
+		resumeFrom = info.Size()
+	}
+
+	req, err := http.NewRequest(http.MethodGet, target.url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request for %s: %w", target.url, err)
+	}
+	if resumeFrom > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", resumeFrom))
+	}
+
+	resp, err := vi.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", target.url, err)
+	}
+	defer resp.Body.Close()
+
+	var flags int
+	switch resp.StatusCode {
+	case http.StatusOK:
+		resumeFrom = 0
+		flags = os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	case http.StatusPartialContent:
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	default:
+		return fmt.Errorf("download %s failed: unexpected status %s", target.url, resp.Status)
+	}
+
+	total := int64(0)
+	if resp.ContentLength >= 0 {
+		total = resumeFrom + resp.ContentLength
+	} else if target.sizeHint > 0 {
+		total = target.sizeHint
+	}
+
+	out, err := os.OpenFile(partPath, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", partPath, err)
+	}
+
+	written := resumeFrom
+	buf := make([]byte, 32*1024)
+	for {
+		n, readErr := resp.Body.Read(buf)
+		if n > 0 {
+			if _, werr := out.Write(buf[:n]); werr != nil {
+				out.Close()
+				return fmt.Errorf("failed to write %s: %w", partPath, werr)
+			}
+			written += int64(n)
+			if progress != nil {
+				progress(target.url, written, total)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			out.Close()
+			return fmt.Errorf("failed to download %s: %w", target.url, readErr)
+		}
+	}
+
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to finalize %s: %w", partPath, err)
+	}
+
+	if err := os.Rename(partPath, target.path); err != nil {
+		return fmt.Errorf("failed to move %s into place: %w", partPath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+//go:build !disable_codec_aac
+
+package encoder
+
+import (
+	"io"
+	"strconv"
+)
+
+func init() {
+	Register(&AACEncoder{})
+}
+
+// AACEncoder wraps the "fdkaac" CLI (libfdk-aac). This codec is gated
+// behind the disable_codec_aac build tag by default expectations in
+// distro packaging, since libfdk-aac's license is not GPL-compatible;
+// distributors who can't redistribute it build with
+// -tags disable_codec_aac.
+type AACEncoder struct{}
+
+// Name implements Encoder.
+func (a *AACEncoder) Name() string { return "aac" }
+
+// Extension implements Encoder.
+func (a *AACEncoder) Extension() string { return "m4a" }
+
+// Encode implements Encoder, feeding raw PCM to fdkaac.
+func (a *AACEncoder) Encode(pcm io.Reader, cfg Config, out io.Writer) error {
+	args := []string{
+		"-R", strconv.Itoa(cfg.SampleRate),
+		"-C", strconv.Itoa(nonZeroInt(cfg.Channels, 1)),
+		"-b", strconv.Itoa(nonZeroInt(cfg.Bitrate, 128)) + "000",
+		"-o", "-",
+		"-",
+	}
+
+	return runPipedEncoder("fdkaac", args, pcm, out)
+}
@@ -0,0 +1,34 @@
+// Package encoder defines the pluggable audio encoder contract used to
+// turn raw PCM into a distributable container/codec. Each codec is
+// implemented in its own build-tag gated file so distributors can compile
+// out codecs they don't want to ship (most notably the non-redistributable
+// libfdk-aac), producing a smaller, license-simpler binary.
+package encoder
+
+import "io"
+
+// Config carries the encoding parameters common to every codec plus a few
+// codec-specific knobs that implementations ignore if irrelevant.
+type Config struct {
+	SampleRate int
+	Channels   int
+
+	Bitrate int  // kbps; meaning is codec-specific (CBR target for mp3/aac, VBR hint for opus)
+	VBR     bool // prefer variable bitrate when the codec supports it
+
+	FLACCompression int // 0 (fastest) - 8 (smallest), FLAC only
+}
+
+// Encoder converts raw little-endian signed 16-bit PCM into an encoded
+// audio stream.
+type Encoder interface {
+	// Name is the encoder's registry key, e.g. "mp3" or "opus".
+	Name() string
+
+	// Extension is the conventional file extension for this codec's
+	// output, without a leading dot.
+	Extension() string
+
+	// Encode reads PCM from pcm and writes the encoded result to out.
+	Encode(pcm io.Reader, cfg Config, out io.Writer) error
+}
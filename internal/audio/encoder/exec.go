@@ -0,0 +1,38 @@
+package encoder
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+// runPipedEncoder feeds pcm to cmdName's stdin and copies its stdout to
+// out, the shape every CLI-backed codec implementation in this package
+// needs.
+func runPipedEncoder(cmdName string, args []string, pcm io.Reader, out io.Writer) error {
+	if _, err := exec.LookPath(cmdName); err != nil {
+		return fmt.Errorf("%s not found in PATH: %w", cmdName, err)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(cmdName, args...)
+	cmd.Stdin = pcm
+	cmd.Stdout = out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed: %w\nOutput: %s", cmdName, err, stderr.String())
+	}
+	return nil
+}
+
+// nonZeroInt returns v, or fallback if v is the zero value. It centralizes
+// "apply a default when the caller left a Config field unset" across
+// codec implementations.
+func nonZeroInt(v, fallback int) int {
+	if v == 0 {
+		return fallback
+	}
+	return v
+}
@@ -0,0 +1,44 @@
+//go:build !disable_codec_flac
+
+package encoder
+
+import (
+	"io"
+	"strconv"
+)
+
+func init() {
+	Register(&FLACEncoder{})
+}
+
+// FLACEncoder wraps the "flac" CLI (libFLAC) for lossless archival output.
+type FLACEncoder struct{}
+
+// Name implements Encoder.
+func (f *FLACEncoder) Name() string { return "flac" }
+
+// Extension implements Encoder.
+func (f *FLACEncoder) Extension() string { return "flac" }
+
+// Encode implements Encoder, feeding raw PCM to the flac CLI via
+// "--force-raw-format" so no intermediate WAV container is needed.
+func (f *FLACEncoder) Encode(pcm io.Reader, cfg Config, out io.Writer) error {
+	compression := cfg.FLACCompression
+	if compression < 0 || compression > 8 {
+		compression = 5
+	}
+
+	args := []string{
+		"--force-raw-format",
+		"--endian=little",
+		"--sign=signed",
+		"--channels", strconv.Itoa(nonZeroInt(cfg.Channels, 1)),
+		"--bps", "16",
+		"--sample-rate", strconv.Itoa(cfg.SampleRate),
+		"-" + strconv.Itoa(compression),
+		"--stdout",
+		"-",
+	}
+
+	return runPipedEncoder("flac", args, pcm, out)
+}
@@ -0,0 +1,42 @@
+//go:build !disable_codec_mp3
+
+package encoder
+
+import (
+	"io"
+	"strconv"
+)
+
+func init() {
+	Register(&MP3Encoder{})
+}
+
+// MP3Encoder wraps the "lame" CLI encoder.
+type MP3Encoder struct{}
+
+// Name implements Encoder.
+func (m *MP3Encoder) Name() string { return "mp3" }
+
+// Extension implements Encoder.
+func (m *MP3Encoder) Extension() string { return "mp3" }
+
+// Encode implements Encoder by piping raw PCM into lame, reading from
+// stdin ("-r") as signed 16-bit little-endian ("-s") mono/stereo input.
+func (m *MP3Encoder) Encode(pcm io.Reader, cfg Config, out io.Writer) error {
+	args := []string{
+		"-r",
+		"-s", strconv.Itoa(cfg.SampleRate),
+		"--bitwidth", "16",
+	}
+	if cfg.Channels == 1 {
+		args = append(args, "-m", "m")
+	}
+	if cfg.VBR {
+		args = append(args, "-V", "2")
+	} else {
+		args = append(args, "-b", strconv.Itoa(nonZeroInt(cfg.Bitrate, 192)))
+	}
+	args = append(args, "-", "-")
+
+	return runPipedEncoder("lame", args, pcm, out)
+}
@@ -0,0 +1,42 @@
+//go:build !disable_codec_opus
+
+package encoder
+
+import (
+	"io"
+	"strconv"
+)
+
+func init() {
+	Register(&OpusEncoder{})
+}
+
+// OpusEncoder wraps the "opusenc" CLI (libopusenc), producing small,
+// royalty-free output well suited to YouTube uploads.
+type OpusEncoder struct{}
+
+// Name implements Encoder.
+func (o *OpusEncoder) Name() string { return "opus" }
+
+// Extension implements Encoder.
+func (o *OpusEncoder) Extension() string { return "opus" }
+
+// Encode implements Encoder. opusenc only reads WAV or raw PCM from a
+// named input, so raw PCM is passed via "--raw" with the sample rate and
+// channel count opusenc would otherwise read from a WAV header.
+func (o *OpusEncoder) Encode(pcm io.Reader, cfg Config, out io.Writer) error {
+	args := []string{
+		"--raw",
+		"--raw-rate", strconv.Itoa(cfg.SampleRate),
+		"--raw-chan", strconv.Itoa(nonZeroInt(cfg.Channels, 1)),
+	}
+	if cfg.VBR {
+		args = append(args, "--vbr")
+	} else {
+		args = append(args, "--hard-cbr")
+	}
+	args = append(args, "--bitrate", strconv.Itoa(nonZeroInt(cfg.Bitrate, 96)))
+	args = append(args, "-", "-")
+
+	return runPipedEncoder("opusenc", args, pcm, out)
+}
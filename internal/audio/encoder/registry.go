@@ -0,0 +1,37 @@
+package encoder
+
+import "sync"
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Encoder{}
+)
+
+// Register adds a codec to the registry under e.Name(). Build-tag gated
+// codec files call this from their own init(), so a codec is selectable
+// exactly when its file was compiled in.
+func Register(e Encoder) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[e.Name()] = e
+}
+
+// Get looks up a compiled-in codec by name.
+func Get(name string) (Encoder, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	e, ok := registry[name]
+	return e, ok
+}
+
+// Enabled returns the names of every codec compiled into this binary.
+func Enabled() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,22 @@
+package encoder
+
+import "testing"
+
+func TestRegistry_DefaultBuildEnablesAllCodecs(t *testing.T) {
+	// With no disable_codec_* build tags set, every codec in this
+	// package should have registered itself.
+	for _, name := range []string{"wav", "mp3", "opus", "flac", "aac", "vorbis"} {
+		if _, ok := Get(name); !ok {
+			t.Errorf("expected codec %q to be registered in the default build", name)
+		}
+	}
+}
+
+func TestNonZeroInt(t *testing.T) {
+	if got := nonZeroInt(0, 42); got != 42 {
+		t.Errorf("nonZeroInt(0, 42) = %d, want 42", got)
+	}
+	if got := nonZeroInt(7, 42); got != 7 {
+		t.Errorf("nonZeroInt(7, 42) = %d, want 7", got)
+	}
+}
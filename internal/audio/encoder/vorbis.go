@@ -0,0 +1,40 @@
+//go:build !disable_codec_vorbis
+
+package encoder
+
+import (
+	"io"
+	"strconv"
+)
+
+func init() {
+	Register(&VorbisEncoder{})
+}
+
+// VorbisEncoder wraps the "oggenc" CLI (libvorbis).
+type VorbisEncoder struct{}
+
+// Name implements Encoder.
+func (v *VorbisEncoder) Name() string { return "vorbis" }
+
+// Extension implements Encoder.
+func (v *VorbisEncoder) Extension() string { return "ogg" }
+
+// Encode implements Encoder. oggenc only reads WAV or raw PCM from a
+// named input, so raw PCM is passed via "--raw" with the sample rate and
+// channel count oggenc would otherwise read from a WAV header.
+func (v *VorbisEncoder) Encode(pcm io.Reader, cfg Config, out io.Writer) error {
+	args := []string{
+		"--raw",
+		"--raw-rate", strconv.Itoa(cfg.SampleRate),
+		"--raw-chan", strconv.Itoa(nonZeroInt(cfg.Channels, 1)),
+	}
+	if cfg.VBR {
+		args = append(args, "-q", "6")
+	} else {
+		args = append(args, "--bitrate", strconv.Itoa(nonZeroInt(cfg.Bitrate, 128)))
+	}
+	args = append(args, "--output=-", "-")
+
+	return runPipedEncoder("oggenc", args, pcm, out)
+}
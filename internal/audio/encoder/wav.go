@@ -0,0 +1,58 @@
+package encoder
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+func init() {
+	Register(&WAVEncoder{})
+}
+
+// WAVEncoder wraps raw PCM in a canonical RIFF/WAVE header. Unlike the
+// other codecs in this package it shells out to nothing, so it is always
+// compiled in (no disable_codec_wav build tag) and acts as the universal
+// fallback format every build supports.
+type WAVEncoder struct{}
+
+// Name implements Encoder.
+func (w *WAVEncoder) Name() string { return "wav" }
+
+// Extension implements Encoder.
+func (w *WAVEncoder) Extension() string { return "wav" }
+
+// Encode implements Encoder by writing a 44-byte canonical WAV header
+// ahead of the PCM data. The data chunk's size has to be known up front,
+// so pcm is buffered fully before anything is written to out.
+func (w *WAVEncoder) Encode(pcm io.Reader, cfg Config, out io.Writer) error {
+	data, err := io.ReadAll(pcm)
+	if err != nil {
+		return err
+	}
+
+	channels := nonZeroInt(cfg.Channels, 1)
+	const bitsPerSample = 16
+	byteRate := cfg.SampleRate * channels * bitsPerSample / 8
+	blockAlign := channels * bitsPerSample / 8
+
+	header := make([]byte, 44)
+	copy(header[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:8], uint32(36+len(data)))
+	copy(header[8:12], "WAVE")
+	copy(header[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(header[16:20], 16) // fmt chunk size (PCM)
+	binary.LittleEndian.PutUint16(header[20:22], 1)  // audio format: PCM
+	binary.LittleEndian.PutUint16(header[22:24], uint16(channels))
+	binary.LittleEndian.PutUint32(header[24:28], uint32(cfg.SampleRate))
+	binary.LittleEndian.PutUint32(header[28:32], uint32(byteRate))
+	binary.LittleEndian.PutUint16(header[32:34], uint16(blockAlign))
+	binary.LittleEndian.PutUint16(header[34:36], bitsPerSample)
+	copy(header[36:40], "data")
+	binary.LittleEndian.PutUint32(header[40:44], uint32(len(data)))
+
+	if _, err := out.Write(header); err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	return err
+}
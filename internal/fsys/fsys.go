@@ -0,0 +1,38 @@
+// Package fsys abstracts file access for agents that read input
+// documents, write intermediate/output files, or maintain an on-disk
+// cache, so those agents can run against the real filesystem, an
+// in-memory fixture (for tests), or a remote store addressed by a
+// scheme-prefixed URI (http(s)://, s3://) without knowing which.
+package fsys
+
+import (
+	"io"
+	"os"
+)
+
+// FS is the filesystem interface agents depend on instead of calling
+// os.* directly.
+type FS interface {
+	// Open returns a reader for path.
+	Open(path string) (io.ReadCloser, error)
+	// Create returns a writer for path, creating or truncating it.
+	Create(path string) (io.WriteCloser, error)
+	// ReadFile reads the entire contents of path.
+	ReadFile(path string) ([]byte, error)
+	// WriteFile writes data to path, creating it with perm if needed.
+	WriteFile(path string, data []byte, perm os.FileMode) error
+	// Stat returns file metadata for path.
+	Stat(path string) (os.FileInfo, error)
+	// Remove deletes path.
+	Remove(path string) error
+	// MkdirAll creates path and any missing parents with perm.
+	MkdirAll(path string, perm os.FileMode) error
+	// Rename moves oldpath to newpath.
+	Rename(oldpath, newpath string) error
+	// Stage guarantees path is available as a real file on local disk,
+	// copying it there first if it's remote or in-memory, for
+	// third-party libraries (docx/pdf readers, exec.Command arguments)
+	// that require a local path rather than an io.Reader. cleanup
+	// removes any temporary copy it made and must always be called.
+	Stage(path string) (localPath string, cleanup func(), err error)
+}
@@ -0,0 +1,144 @@
+package fsys
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOSFS_ReadWrite(t *testing.T) {
+	fs := NewOSFS()
+	path := filepath.Join(t.TempDir(), "hello.txt")
+
+	if err := fs.WriteFile(path, []byte("hello"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if string(data) != "hello" {
+		t.Errorf("ReadFile = %q, want %q", data, "hello")
+	}
+}
+
+func TestOSFS_Stage_IsNoOp(t *testing.T) {
+	fs := NewOSFS()
+	path := filepath.Join(t.TempDir(), "hello.txt")
+	if err := os.WriteFile(path, []byte("hi"), 0644); err != nil {
+		t.Fatalf("failed to seed file: %v", err)
+	}
+
+	localPath, cleanup, err := fs.Stage(path)
+	defer cleanup()
+	if err != nil {
+		t.Fatalf("Stage failed: %v", err)
+	}
+	if localPath != path {
+		t.Errorf("Stage path = %q, want %q", localPath, path)
+	}
+}
+
+func TestMemFS_ReadWriteRemoveRename(t *testing.T) {
+	fs := NewMemFS()
+
+	if err := fs.WriteFile("/doc.txt", []byte("content"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	data, err := fs.ReadFile("/doc.txt")
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if string(data) != "content" {
+		t.Errorf("ReadFile = %q, want %q", data, "content")
+	}
+
+	if err := fs.Rename("/doc.txt", "/renamed.txt"); err != nil {
+		t.Fatalf("Rename failed: %v", err)
+	}
+	if _, err := fs.ReadFile("/doc.txt"); err == nil {
+		t.Error("expected an error reading the renamed-away path")
+	}
+	if _, err := fs.ReadFile("/renamed.txt"); err != nil {
+		t.Fatalf("ReadFile after rename failed: %v", err)
+	}
+
+	if err := fs.Remove("/renamed.txt"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, err := fs.ReadFile("/renamed.txt"); err == nil {
+		t.Error("expected an error reading a removed file")
+	}
+}
+
+func TestMemFS_Stage(t *testing.T) {
+	fs := NewMemFS()
+	if err := fs.WriteFile("/doc.pdf", []byte("%PDF-fake"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	localPath, cleanup, err := fs.Stage("/doc.pdf")
+	defer cleanup()
+	if err != nil {
+		t.Fatalf("Stage failed: %v", err)
+	}
+
+	data, err := os.ReadFile(localPath)
+	if err != nil {
+		t.Fatalf("failed to read staged file: %v", err)
+	}
+	if string(data) != "%PDF-fake" {
+		t.Errorf("staged content = %q, want %q", data, "%PDF-fake")
+	}
+}
+
+func TestRemoteFS_DelegatesLocalPaths(t *testing.T) {
+	base := NewMemFS()
+	remote := NewRemoteFS(base)
+
+	if err := remote.WriteFile("/local.txt", []byte("local"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	data, err := remote.ReadFile("/local.txt")
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if string(data) != "local" {
+		t.Errorf("ReadFile = %q, want %q", data, "local")
+	}
+}
+
+func TestRemoteFS_HTTPRoundTrip(t *testing.T) {
+	var uploaded []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			body, _ := io.ReadAll(r.Body)
+			uploaded = body
+			return
+		}
+		io.WriteString(w, "remote content")
+	}))
+	defer server.Close()
+
+	remote := NewRemoteFS(NewMemFS())
+
+	data, err := remote.ReadFile(server.URL + "/fetch")
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if string(data) != "remote content" {
+		t.Errorf("ReadFile = %q, want %q", data, "remote content")
+	}
+
+	if err := remote.WriteFile(server.URL+"/put", []byte("uploaded content"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if string(uploaded) != "uploaded content" {
+		t.Errorf("server received %q, want %q", uploaded, "uploaded content")
+	}
+}
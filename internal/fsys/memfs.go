@@ -0,0 +1,151 @@
+package fsys
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// MemFS is an in-memory FS for unit tests that exercise ingestion,
+// caching, or post-processing without touching disk.
+type MemFS struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+// NewMemFS creates an empty in-memory FS.
+func NewMemFS() *MemFS {
+	return &MemFS{files: make(map[string][]byte)}
+}
+
+type memFileInfo struct {
+	name string
+	size int64
+}
+
+func (fi memFileInfo) Name() string       { return fi.name }
+func (fi memFileInfo) Size() int64        { return fi.size }
+func (fi memFileInfo) Mode() os.FileMode  { return 0644 }
+func (fi memFileInfo) ModTime() time.Time { return time.Time{} }
+func (fi memFileInfo) IsDir() bool        { return false }
+func (fi memFileInfo) Sys() interface{}   { return nil }
+
+type memWriteCloser struct {
+	fs   *MemFS
+	path string
+	buf  bytes.Buffer
+}
+
+func (w *memWriteCloser) Write(p []byte) (int, error) { return w.buf.Write(p) }
+
+func (w *memWriteCloser) Close() error {
+	w.fs.mu.Lock()
+	defer w.fs.mu.Unlock()
+	w.fs.files[w.path] = w.buf.Bytes()
+	return nil
+}
+
+// Open implements FS.
+func (m *MemFS) Open(path string) (io.ReadCloser, error) {
+	m.mu.Lock()
+	data, ok := m.files[path]
+	m.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("file does not exist: %s", path)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// Create implements FS.
+func (m *MemFS) Create(path string) (io.WriteCloser, error) {
+	return &memWriteCloser{fs: m, path: path}, nil
+}
+
+// ReadFile implements FS.
+func (m *MemFS) ReadFile(path string) ([]byte, error) {
+	m.mu.Lock()
+	data, ok := m.files[path]
+	m.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("file does not exist: %s", path)
+	}
+	out := make([]byte, len(data))
+	copy(out, data)
+	return out, nil
+}
+
+// WriteFile implements FS.
+func (m *MemFS) WriteFile(path string, data []byte, _ os.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := make([]byte, len(data))
+	copy(cp, data)
+	m.files[path] = cp
+	return nil
+}
+
+// Stat implements FS.
+func (m *MemFS) Stat(path string) (os.FileInfo, error) {
+	m.mu.Lock()
+	data, ok := m.files[path]
+	m.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("file does not exist: %s", path)
+	}
+	return memFileInfo{name: filepath.Base(path), size: int64(len(data))}, nil
+}
+
+// Remove implements FS.
+func (m *MemFS) Remove(path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.files[path]; !ok {
+		return fmt.Errorf("file does not exist: %s", path)
+	}
+	delete(m.files, path)
+	return nil
+}
+
+// MkdirAll implements FS. MemFS has no directory structure of its own,
+// so this is always a no-op.
+func (m *MemFS) MkdirAll(string, os.FileMode) error { return nil }
+
+// Rename implements FS.
+func (m *MemFS) Rename(oldpath, newpath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.files[oldpath]
+	if !ok {
+		return fmt.Errorf("file does not exist: %s", oldpath)
+	}
+	m.files[newpath] = data
+	delete(m.files, oldpath)
+	return nil
+}
+
+// Stage copies path's in-memory content out to a real temporary file,
+// for third-party libraries that require a local path. The caller must
+// call cleanup to remove it.
+func (m *MemFS) Stage(path string) (string, func(), error) {
+	data, err := m.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	tmp, err := os.CreateTemp("", "memfs_stage_*"+filepath.Ext(path))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to stage %s: %w", path, err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", nil, fmt.Errorf("failed to stage %s: %w", path, err)
+	}
+	tmp.Close()
+
+	return tmp.Name(), func() { os.Remove(tmp.Name()) }, nil
+}
@@ -0,0 +1,43 @@
+package fsys
+
+import (
+	"io"
+	"os"
+)
+
+// OSFS is the default FS, operating directly on the local filesystem.
+type OSFS struct{}
+
+// NewOSFS creates an OS-backed FS.
+func NewOSFS() *OSFS { return &OSFS{} }
+
+// Open implements FS.
+func (OSFS) Open(path string) (io.ReadCloser, error) { return os.Open(path) }
+
+// Create implements FS.
+func (OSFS) Create(path string) (io.WriteCloser, error) { return os.Create(path) }
+
+// ReadFile implements FS.
+func (OSFS) ReadFile(path string) ([]byte, error) { return os.ReadFile(path) }
+
+// WriteFile implements FS.
+func (OSFS) WriteFile(path string, data []byte, perm os.FileMode) error {
+	return os.WriteFile(path, data, perm)
+}
+
+// Stat implements FS.
+func (OSFS) Stat(path string) (os.FileInfo, error) { return os.Stat(path) }
+
+// Remove implements FS.
+func (OSFS) Remove(path string) error { return os.Remove(path) }
+
+// MkdirAll implements FS.
+func (OSFS) MkdirAll(path string, perm os.FileMode) error { return os.MkdirAll(path, perm) }
+
+// Rename implements FS.
+func (OSFS) Rename(oldpath, newpath string) error { return os.Rename(oldpath, newpath) }
+
+// Stage is a no-op for OSFS: path is already a real local file.
+func (OSFS) Stage(path string) (string, func(), error) {
+	return path, func() {}, nil
+}
@@ -0,0 +1,246 @@
+package fsys
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// RemoteFS wraps a base FS and adds transparent http(s):// and s3://
+// URI support: callers can pass a URI anywhere they'd pass a local
+// path, and every other path falls through to Base unchanged.
+type RemoteFS struct {
+	Base FS
+
+	// s3Client is created lazily on first use of an s3:// URI, since
+	// config.LoadDefaultConfig does network/credential-chain I/O that
+	// callers using only http(s):// or local paths shouldn't pay for.
+	s3Client *s3.Client
+}
+
+// NewRemoteFS wraps base with http(s)/s3 URI support.
+func NewRemoteFS(base FS) *RemoteFS {
+	return &RemoteFS{Base: base}
+}
+
+func isHTTP(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
+
+func isS3(path string) bool {
+	return strings.HasPrefix(path, "s3://")
+}
+
+func isRemote(path string) bool {
+	return isHTTP(path) || isS3(path)
+}
+
+// Open implements FS.
+func (r *RemoteFS) Open(path string) (io.ReadCloser, error) {
+	switch {
+	case isHTTP(path):
+		resp, err := http.Get(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", path, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to fetch %s: status %s", path, resp.Status)
+		}
+		return resp.Body, nil
+	case isS3(path):
+		data, err := r.getS3(path)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(data)), nil
+	default:
+		return r.Base.Open(path)
+	}
+}
+
+// ReadFile implements FS.
+func (r *RemoteFS) ReadFile(path string) ([]byte, error) {
+	if !isRemote(path) {
+		return r.Base.ReadFile(path)
+	}
+	rc, err := r.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+type remoteWriteCloser struct {
+	flush func([]byte) error
+	buf   bytes.Buffer
+}
+
+func (w *remoteWriteCloser) Write(p []byte) (int, error) { return w.buf.Write(p) }
+func (w *remoteWriteCloser) Close() error                { return w.flush(w.buf.Bytes()) }
+
+// Create implements FS. The returned writer buffers in memory and is
+// only actually staged out to http(s)/s3 when Close is called.
+func (r *RemoteFS) Create(path string) (io.WriteCloser, error) {
+	switch {
+	case isHTTP(path):
+		return &remoteWriteCloser{flush: func(data []byte) error { return r.putHTTP(path, data) }}, nil
+	case isS3(path):
+		return &remoteWriteCloser{flush: func(data []byte) error { return r.putS3(path, data) }}, nil
+	default:
+		return r.Base.Create(path)
+	}
+}
+
+// WriteFile implements FS.
+func (r *RemoteFS) WriteFile(path string, data []byte, perm os.FileMode) error {
+	if !isRemote(path) {
+		return r.Base.WriteFile(path, data, perm)
+	}
+	w, err := r.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+// Stat implements FS. Remote paths have no cheap metadata-only fetch in
+// either the http(s) or s3 case handled here, so Stat is unsupported for
+// them; callers that need remote existence checks should use Open or
+// ReadFile instead.
+func (r *RemoteFS) Stat(path string) (os.FileInfo, error) {
+	if !isRemote(path) {
+		return r.Base.Stat(path)
+	}
+	return nil, fmt.Errorf("stat is not supported for remote path %s", path)
+}
+
+// Remove implements FS.
+func (r *RemoteFS) Remove(path string) error {
+	if !isRemote(path) {
+		return r.Base.Remove(path)
+	}
+	return fmt.Errorf("remove is not supported for remote path %s", path)
+}
+
+// MkdirAll implements FS. Remote stores have no directory hierarchy of
+// their own, so this is a no-op for remote paths.
+func (r *RemoteFS) MkdirAll(path string, perm os.FileMode) error {
+	if !isRemote(path) {
+		return r.Base.MkdirAll(path, perm)
+	}
+	return nil
+}
+
+// Rename implements FS.
+func (r *RemoteFS) Rename(oldpath, newpath string) error {
+	if !isRemote(oldpath) {
+		return r.Base.Rename(oldpath, newpath)
+	}
+	return fmt.Errorf("rename is not supported for remote path %s", oldpath)
+}
+
+// Stage downloads path to a real local temp file for libraries that
+// require a path rather than an io.Reader (e.g. docx/pdf parsing).
+// Local paths are delegated to Base.Stage unchanged.
+func (r *RemoteFS) Stage(path string) (string, func(), error) {
+	if !isRemote(path) {
+		return r.Base.Stage(path)
+	}
+
+	data, err := r.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	tmp, err := os.CreateTemp("", "remotefs_stage_*")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to stage %s: %w", path, err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", nil, fmt.Errorf("failed to stage %s: %w", path, err)
+	}
+	tmp.Close()
+
+	return tmp.Name(), func() { os.Remove(tmp.Name()) }, nil
+}
+
+// s3Bucket splits an "s3://bucket/key" URI into its bucket and key.
+func s3Bucket(path string) (bucket, key string) {
+	trimmed := strings.TrimPrefix(path, "s3://")
+	parts := strings.SplitN(trimmed, "/", 2)
+	if len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return parts[0], ""
+}
+
+func (r *RemoteFS) client(ctx context.Context) (*s3.Client, error) {
+	if r.s3Client != nil {
+		return r.s3Client, nil
+	}
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	r.s3Client = s3.NewFromConfig(cfg)
+	return r.s3Client, nil
+}
+
+func (r *RemoteFS) getS3(path string) ([]byte, error) {
+	ctx := context.Background()
+	client, err := r.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bucket, key := s3Bucket(path)
+	out, err := client.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", path, err)
+	}
+	defer out.Body.Close()
+	return io.ReadAll(out.Body)
+}
+
+func (r *RemoteFS) putS3(path string, data []byte) error {
+	ctx := context.Background()
+	client, err := r.client(ctx)
+	if err != nil {
+		return err
+	}
+	bucket, key := s3Bucket(path)
+	if _, err := client.PutObject(ctx, &s3.PutObjectInput{Bucket: aws.String(bucket), Key: aws.String(key), Body: bytes.NewReader(data)}); err != nil {
+		return fmt.Errorf("failed to stage output to %s: %w", path, err)
+	}
+	return nil
+}
+
+func (r *RemoteFS) putHTTP(path string, data []byte) error {
+	req, err := http.NewRequest(http.MethodPut, path, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to stage output to %s: %w", path, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to stage output to %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to stage output to %s: status %s", path, resp.Status)
+	}
+	return nil
+}
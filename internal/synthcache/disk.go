@@ -0,0 +1,223 @@
+package synthcache
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// entryMeta is the sidecar JSON written alongside each blob, recording
+// enough to answer Stats and enforce TTL expiry without touching the
+// blob itself.
+type entryMeta struct {
+	CreatedAt time.Time `json:"created_at"`
+	Size      int64     `json:"size"`
+}
+
+// DiskCache stores each entry under baseDir/<key[:2]>/<key>.<ext>, with a
+// sidecar baseDir/<key[:2]>/<key>.json holding entryMeta -- a two-level
+// sharded layout that mirrors agents.CacheAgent's own cacheDir/xx/yy/
+// shape, just one level shallower since keys here are already full
+// SHA256 hashes rather than cache keys the shard prefix independently.
+type DiskCache struct {
+	baseDir string
+	ext     string
+	ttl     time.Duration // 0 disables expiry
+}
+
+// NewDiskCache creates a disk-backed cache rooted at baseDir, storing
+// blobs with the given extension (no leading dot, e.g. "mp3"). A ttl of
+// 0 disables expiry: entries only go away via GC/Purge.
+func NewDiskCache(baseDir, ext string, ttl time.Duration) *DiskCache {
+	return &DiskCache{baseDir: baseDir, ext: ext, ttl: ttl}
+}
+
+// DefaultDiskCacheDir returns $XDG_CACHE_HOME/ttscli, falling back to
+// $HOME/.cache/ttscli per the XDG Base Directory spec when
+// XDG_CACHE_HOME isn't set.
+func DefaultDiskCacheDir() string {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "ttscli")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "ttscli-cache")
+	}
+	return filepath.Join(home, ".cache", "ttscli")
+}
+
+func (d *DiskCache) paths(key string) (blobPath, metaPath string) {
+	shard := key
+	if len(shard) > 2 {
+		shard = shard[:2]
+	}
+	dir := filepath.Join(d.baseDir, shard)
+	return filepath.Join(dir, key+"."+d.ext), filepath.Join(dir, key+".json")
+}
+
+// Get returns the cached blob for key, or ok=false if it's missing or
+// its TTL (if any) has expired.
+func (d *DiskCache) Get(key string) (io.ReadCloser, bool) {
+	blobPath, metaPath := d.paths(key)
+
+	if d.ttl > 0 {
+		meta, err := readMeta(metaPath)
+		if err != nil {
+			return nil, false
+		}
+		if time.Since(meta.CreatedAt) > d.ttl {
+			return nil, false
+		}
+	}
+
+	f, err := os.Open(blobPath)
+	if err != nil {
+		return nil, false
+	}
+	return f, true
+}
+
+// Put stores r's bytes under key, writing to a temp file in the same
+// shard directory and renaming it into place so a reader never observes
+// a partially-written blob.
+func (d *DiskCache) Put(key string, r io.Reader) error {
+	blobPath, metaPath := d.paths(key)
+
+	if err := os.MkdirAll(filepath.Dir(blobPath), 0755); err != nil {
+		return fmt.Errorf("synthcache: failed to create cache dir: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(blobPath), "tmp-*")
+	if err != nil {
+		return fmt.Errorf("synthcache: failed to create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+
+	size, copyErr := io.Copy(tmp, r)
+	closeErr := tmp.Close()
+	if copyErr != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("synthcache: failed to write blob: %w", copyErr)
+	}
+	if closeErr != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("synthcache: failed to close temp file: %w", closeErr)
+	}
+
+	if err := os.Rename(tmpPath, blobPath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("synthcache: failed to finalize blob: %w", err)
+	}
+
+	data, err := json.Marshal(entryMeta{CreatedAt: time.Now(), Size: size})
+	if err != nil {
+		return fmt.Errorf("synthcache: failed to marshal metadata: %w", err)
+	}
+	if err := os.WriteFile(metaPath, data, 0644); err != nil {
+		return fmt.Errorf("synthcache: failed to write metadata: %w", err)
+	}
+
+	return nil
+}
+
+// Stats reports the entry count and total blob bytes currently on disk.
+type Stats struct {
+	Entries int
+	Bytes   int64
+}
+
+// Stats walks baseDir and totals every entry's sidecar-recorded size.
+func (d *DiskCache) Stats() (Stats, error) {
+	var stats Stats
+
+	err := filepath.Walk(d.baseDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+		meta, err := readMeta(path)
+		if err != nil {
+			return nil // skip unreadable/corrupt sidecars rather than failing Stats entirely
+		}
+		stats.Entries++
+		stats.Bytes += meta.Size
+		return nil
+	})
+	if err != nil {
+		return Stats{}, fmt.Errorf("synthcache: failed to walk %s: %w", d.baseDir, err)
+	}
+
+	return stats, nil
+}
+
+// GC removes every entry whose TTL has expired. It's a no-op, removing
+// nothing, when the cache has no TTL configured.
+func (d *DiskCache) GC() (removed int, err error) {
+	if d.ttl <= 0 {
+		return 0, nil
+	}
+	return d.sweep(func(meta entryMeta) bool {
+		return time.Since(meta.CreatedAt) > d.ttl
+	})
+}
+
+// Purge removes every entry unconditionally.
+func (d *DiskCache) Purge() (removed int, err error) {
+	return d.sweep(func(entryMeta) bool { return true })
+}
+
+// sweep walks baseDir's sidecars, removing the blob+sidecar pair for
+// every entry where shouldRemove reports true.
+func (d *DiskCache) sweep(shouldRemove func(entryMeta) bool) (removed int, err error) {
+	walkErr := filepath.Walk(d.baseDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		meta, err := readMeta(path)
+		if err != nil {
+			return nil
+		}
+		if !shouldRemove(meta) {
+			return nil
+		}
+
+		blobPath := strings.TrimSuffix(path, ".json") + "." + d.ext
+		os.Remove(blobPath)
+		if rmErr := os.Remove(path); rmErr == nil {
+			removed++
+		}
+		return nil
+	})
+	if walkErr != nil {
+		return removed, fmt.Errorf("synthcache: failed to walk %s: %w", d.baseDir, walkErr)
+	}
+	return removed, nil
+}
+
+func readMeta(path string) (entryMeta, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return entryMeta{}, err
+	}
+	var meta entryMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return entryMeta{}, err
+	}
+	return meta, nil
+}
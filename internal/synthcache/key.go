@@ -0,0 +1,19 @@
+package synthcache
+
+import (
+	"crypto/sha256"
+	"fmt"
+)
+
+// Key hashes the full set of inputs that determine a synthesized
+// utterance's rendered bytes: the normalized text that was fed to the
+// engine, the resolved language, the voice, the rate/pitch synthesis
+// parameters, the output format, and the TTS engine's own version (so
+// upgrading piper/espeak/coqui invalidates old entries instead of
+// silently reusing audio a newer engine would render differently).
+func Key(normalizedText, language, voiceID string, rate, pitch float64, format, engineVersion string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%.6f\x00%.6f\x00%s\x00%s",
+		normalizedText, language, voiceID, rate, pitch, format, engineVersion)
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
@@ -0,0 +1,83 @@
+package synthcache
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// MemoryCache is an in-process, size-bounded LRU cache: useful for a
+// short batch run where paying disk I/O for every lookup isn't worth it,
+// or for tests that don't want to touch the filesystem at all.
+type MemoryCache struct {
+	mu       sync.Mutex
+	maxBytes int64
+	curBytes int64
+	order    *list.List // front = most recently used
+	items    map[string]*list.Element
+}
+
+type memoryEntry struct {
+	key  string
+	data []byte
+}
+
+// NewMemoryCache creates an LRU cache that evicts least-recently-used
+// entries once the total stored bytes would exceed maxBytes.
+func NewMemoryCache(maxBytes int64) *MemoryCache {
+	return &MemoryCache{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached blob for key, or ok=false on a miss. A hit
+// moves key to the front of the LRU order.
+func (m *MemoryCache) Get(key string) (io.ReadCloser, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+	m.order.MoveToFront(elem)
+
+	entry := elem.Value.(*memoryEntry)
+	return io.NopCloser(bytes.NewReader(entry.data)), true
+}
+
+// Put stores r's bytes under key, evicting least-recently-used entries
+// until the cache fits within maxBytes.
+func (m *MemoryCache) Put(key string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("synthcache: failed to read blob for %q: %w", key, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.items[key]; ok {
+		m.curBytes -= int64(len(elem.Value.(*memoryEntry).data))
+		m.order.Remove(elem)
+		delete(m.items, key)
+	}
+
+	elem := m.order.PushFront(&memoryEntry{key: key, data: data})
+	m.items[key] = elem
+	m.curBytes += int64(len(data))
+
+	for m.curBytes > m.maxBytes && m.order.Len() > 0 {
+		oldest := m.order.Back()
+		entry := oldest.Value.(*memoryEntry)
+		m.order.Remove(oldest)
+		delete(m.items, entry.key)
+		m.curBytes -= int64(len(entry.data))
+	}
+
+	return nil
+}
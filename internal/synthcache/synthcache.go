@@ -0,0 +1,25 @@
+// Package synthcache implements a pluggable, content-addressed cache for
+// synthesized audio, keyed by the full set of inputs that determine an
+// utterance's rendered bytes (see Key). It's directly motivated by the
+// Playground's memcache-keyed commandHandler pattern of hashing request
+// bodies to avoid re-running expensive backend work -- here the backend
+// work being avoided is a real TTS engine invocation.
+//
+// This is deliberately a narrower interface than agents.CacheAgent
+// (which indexes synthesized files in SQLite alongside richer metadata
+// and quota-based eviction): synthcache is meant for a single pipeline
+// stage to check "have I already rendered exactly this?" against a swappable
+// backend, not to be the system of record for a whole synthesis run.
+package synthcache
+
+import "io"
+
+// Cache is implemented by every cache backend.
+type Cache interface {
+	// Get looks up key, returning ok=false on a miss. The caller must
+	// Close the returned ReadCloser on a hit.
+	Get(key string) (io.ReadCloser, bool)
+	// Put stores the bytes read from r under key, replacing any existing
+	// entry.
+	Put(key string, r io.Reader) error
+}
@@ -0,0 +1,166 @@
+package synthcache
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestKey_Deterministic(t *testing.T) {
+	k1 := Key("hello world", "en-US", "en_US-amy", 1.0, 0.5, "mp3", "piper-1.2.0")
+	k2 := Key("hello world", "en-US", "en_US-amy", 1.0, 0.5, "mp3", "piper-1.2.0")
+	if k1 != k2 {
+		t.Errorf("Key() is not deterministic: %q != %q", k1, k2)
+	}
+}
+
+func TestKey_DiffersOnAnyInput(t *testing.T) {
+	base := Key("hello world", "en-US", "en_US-amy", 1.0, 0.5, "mp3", "piper-1.2.0")
+	variants := []string{
+		Key("goodbye world", "en-US", "en_US-amy", 1.0, 0.5, "mp3", "piper-1.2.0"),
+		Key("hello world", "el-GR", "en_US-amy", 1.0, 0.5, "mp3", "piper-1.2.0"),
+		Key("hello world", "en-US", "en_US-ryan", 1.0, 0.5, "mp3", "piper-1.2.0"),
+		Key("hello world", "en-US", "en_US-amy", 1.2, 0.5, "mp3", "piper-1.2.0"),
+		Key("hello world", "en-US", "en_US-amy", 1.0, 0.8, "mp3", "piper-1.2.0"),
+		Key("hello world", "en-US", "en_US-amy", 1.0, 0.5, "wav", "piper-1.2.0"),
+		Key("hello world", "en-US", "en_US-amy", 1.0, 0.5, "mp3", "piper-1.3.0"),
+	}
+	for i, v := range variants {
+		if v == base {
+			t.Errorf("variant %d produced the same key as base, want a different one", i)
+		}
+	}
+}
+
+func TestDiskCache_PutGet(t *testing.T) {
+	cache := NewDiskCache(t.TempDir(), "mp3", 0)
+
+	if err := cache.Put("abc123", bytes.NewReader([]byte("fake mp3 bytes"))); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	rc, ok := cache.Get("abc123")
+	if !ok {
+		t.Fatal("Get() ok = false, want true")
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if string(data) != "fake mp3 bytes" {
+		t.Errorf("got %q, want %q", data, "fake mp3 bytes")
+	}
+}
+
+func TestDiskCache_Miss(t *testing.T) {
+	cache := NewDiskCache(t.TempDir(), "mp3", 0)
+	if _, ok := cache.Get("does-not-exist"); ok {
+		t.Error("Get() ok = true for a key that was never Put, want false")
+	}
+}
+
+func TestDiskCache_TTLExpiry(t *testing.T) {
+	cache := NewDiskCache(t.TempDir(), "mp3", time.Nanosecond)
+
+	if err := cache.Put("expiring", bytes.NewReader([]byte("data"))); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	time.Sleep(time.Millisecond)
+
+	if _, ok := cache.Get("expiring"); ok {
+		t.Error("Get() ok = true for an expired entry, want false")
+	}
+}
+
+func TestDiskCache_StatsAndPurge(t *testing.T) {
+	cache := NewDiskCache(t.TempDir(), "mp3", 0)
+	cache.Put("one", bytes.NewReader([]byte("aaa")))
+	cache.Put("two", bytes.NewReader([]byte("bbbbb")))
+
+	stats, err := cache.Stats()
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	if stats.Entries != 2 || stats.Bytes != 8 {
+		t.Errorf("Stats() = %+v, want {Entries:2 Bytes:8}", stats)
+	}
+
+	removed, err := cache.Purge()
+	if err != nil {
+		t.Fatalf("Purge() error = %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Purge() removed = %d, want 2", removed)
+	}
+
+	stats, err = cache.Stats()
+	if err != nil {
+		t.Fatalf("Stats() after Purge error = %v", err)
+	}
+	if stats.Entries != 0 {
+		t.Errorf("Stats() after Purge = %+v, want 0 entries", stats)
+	}
+}
+
+func TestDiskCache_GC_NoTTLIsNoOp(t *testing.T) {
+	cache := NewDiskCache(t.TempDir(), "mp3", 0)
+	cache.Put("one", bytes.NewReader([]byte("aaa")))
+
+	removed, err := cache.GC()
+	if err != nil {
+		t.Fatalf("GC() error = %v", err)
+	}
+	if removed != 0 {
+		t.Errorf("GC() removed = %d, want 0 (no TTL configured)", removed)
+	}
+	if _, ok := cache.Get("one"); !ok {
+		t.Error("entry was removed by a no-TTL GC, want it kept")
+	}
+}
+
+func TestMemoryCache_PutGet(t *testing.T) {
+	cache := NewMemoryCache(1024)
+
+	if err := cache.Put("key", bytes.NewReader([]byte("value"))); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	rc, ok := cache.Get("key")
+	if !ok {
+		t.Fatal("Get() ok = false, want true")
+	}
+	defer rc.Close()
+
+	data, _ := io.ReadAll(rc)
+	if string(data) != "value" {
+		t.Errorf("got %q, want %q", data, "value")
+	}
+}
+
+func TestMemoryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewMemoryCache(10)
+
+	cache.Put("a", bytes.NewReader([]byte("12345"))) // 5 bytes
+	cache.Put("b", bytes.NewReader([]byte("12345"))) // 5 bytes, total 10: at capacity
+
+	// Touch "a" so "b" becomes the least-recently-used entry.
+	if rc, ok := cache.Get("a"); ok {
+		rc.Close()
+	}
+
+	// Pushes total to 15 bytes; must evict "b" (not "a") to get back to <=10.
+	cache.Put("c", bytes.NewReader([]byte("12345")))
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Get(\"b\") ok = true, want false (should have been evicted)")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Error("Get(\"a\") ok = false, want true (recently used, should survive)")
+	}
+	if _, ok := cache.Get("c"); !ok {
+		t.Error("Get(\"c\") ok = false, want true (just inserted)")
+	}
+}
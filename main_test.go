@@ -3,7 +3,11 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"studiospeech/internal/agents"
+	"studiospeech/internal/agents/respipeline"
 )
 
 // TestMainIntegration tests the main CLI integration
@@ -97,18 +101,90 @@ func TestFileProcessingIntegration(t *testing.T) {
 				t.Fatalf("Failed to create test file: %v", err)
 			}
 
-			// Test file processing pipeline
-			_ = filepath.Join(tempDir, "output.mp3")
-			
-			// This would run the actual pipeline
-			// For now, we'll test file creation and basic validation
 			if _, err := os.Stat(testFile); os.IsNotExist(err) {
 				t.Errorf("Test file was not created: %s", testFile)
 			}
+
+			// Run the file through a representative respipeline chain
+			// (dry-run, so it needs neither piper nor ffmpeg installed)
+			// and confirm it comes out the other end as a fingerprinted,
+			// manifest-tracked MP3.
+			outputFile := filepath.Join(tempDir, strings.TrimSuffix(tt.filename, ".txt")+".mp3")
+			runRepresentativePipeline(t, testFile, tt.expectedLang, outputFile)
 		})
 	}
 }
 
+// runRepresentativePipeline drives the synth command's normalize |
+// detectLang | synthesize | transcodeMP3 | loudnessNorm | fingerprint
+// chain over textFile in dry-run mode, and asserts the resulting
+// Resource and manifest look like what a real run would produce.
+func runRepresentativePipeline(t *testing.T, textFile, expectedLang, outputFile string) {
+	t.Helper()
+
+	content, err := agents.NewTextIngestAgent().ProcessFile(textFile)
+	if err != nil {
+		t.Fatalf("ProcessFile(%s) error = %v", textFile, err)
+	}
+
+	specs, err := respipeline.ParseStages(
+		"normalize|detectLang:lang=" + expectedLang + "|synthesize|transcodeMP3|loudnessNorm|fingerprint:as=speech",
+	)
+	if err != nil {
+		t.Fatalf("ParseStages() error = %v", err)
+	}
+
+	manifest := &respipeline.Manifest{}
+	opts := &respipeline.Options{
+		DryRun:      true,
+		TempDir:     t.TempDir(),
+		CatalogPath: integrationTestCatalogPath(t),
+		Manifest:    manifest,
+	}
+
+	out, _, err := respipeline.New(specs).Run(opts, respipeline.NewTextResource(content))
+	if err != nil {
+		t.Fatalf("pipeline run failed: %v", err)
+	}
+
+	if out.Language != expectedLang {
+		t.Errorf("out.Language = %q, want %q", out.Language, expectedLang)
+	}
+	if out.Fingerprint == "" {
+		t.Error("out.Fingerprint is empty, want a computed hash")
+	}
+	if !strings.Contains(filepath.Base(out.AudioPath), out.Fingerprint) {
+		t.Errorf("out.AudioPath = %q does not embed fingerprint %q", out.AudioPath, out.Fingerprint)
+	}
+	if len(manifest.Entries) != 1 || manifest.Entries[0].SHA256 != out.Fingerprint {
+		t.Errorf("manifest entries = %+v, want one entry with sha256 %q", manifest.Entries, out.Fingerprint)
+	}
+
+	data, err := os.ReadFile(out.AudioPath)
+	if err != nil {
+		t.Fatalf("failed to read pipeline output %s: %v", out.AudioPath, err)
+	}
+	if err := os.WriteFile(outputFile, data, 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", outputFile, err)
+	}
+}
+
+// integrationTestCatalogPath writes a minimal catalog covering both
+// fixture languages, so runRepresentativePipeline can select a voice for
+// either without depending on voices/catalog.json being present.
+func integrationTestCatalogPath(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	catalog := `{"voices":[` +
+		`{"id":"en_US-test-medium","language":"en-US","gender":"female","commercial_use_allowed":true,"license_name":"CC0","sample_rate":22050},` +
+		`{"id":"el_GR-test-medium","language":"el-GR","gender":"female","commercial_use_allowed":true,"license_name":"CC0","sample_rate":22050}` +
+		`]}`
+	if err := os.WriteFile(path, []byte(catalog), 0644); err != nil {
+		t.Fatalf("failed to write test catalog: %v", err)
+	}
+	return path
+}
+
 // TestErrorHandling tests error scenarios
 func TestErrorHandling(t *testing.T) {
 	tempDir := t.TempDir()